@@ -0,0 +1,304 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// ArchiveRecord is a request/response pair handed to an Archiver.
+// Request and Response hold the JSON payloads; text payloads are stored as JSON strings.
+// Binary responses, such as speech audio or file contents, are left out of Response, and
+// ResponseContentType records their media type.
+type ArchiveRecord struct {
+	Time       time.Time         `json:"time"`
+	Method     string            `json:"method"`
+	Path       string            `json:"path"`
+	StatusCode int               `json:"status_code,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	Tag        string            `json:"tag,omitempty"`
+	Request    json.RawMessage   `json:"request,omitempty"`
+	Response   json.RawMessage   `json:"response,omitempty"`
+	// ResponseContentType is only set when the response body is not archived.
+	ResponseContentType string `json:"response_content_type,omitempty"`
+	Error               string `json:"error,omitempty"`
+}
+
+// Archiver stores request/response pairs, e.g. to build datasets or audit trails.
+type Archiver interface {
+	Archive(ctx context.Context, record ArchiveRecord) error
+}
+
+const defaultArchiveQueueSize = 1000
+
+var (
+	ErrArchiveQueueFull = errors.New("archive queue is full, record dropped")
+	ErrArchiveClosed    = errors.New("archive is closed, record dropped")
+)
+
+// ArchiveConfig enables archiving of JSON API calls. Multipart uploads and streams are not
+// archived, nor are binary response bodies.
+type ArchiveConfig struct {
+	Archiver Archiver
+	// Redact is called before the record is archived and may remove secrets or personal data.
+	Redact func(record *ArchiveRecord)
+	// OnError receives the errors returned by Archiver, and ErrArchiveQueueFull or
+	// ErrArchiveClosed for dropped records. Records are archived asynchronously, so they cannot
+	// fail the call itself.
+	OnError func(err error)
+	// QueueSize is the number of records waiting to be archived, defaults to 1000. Records are
+	// archived one at a time in call order, and dropped while the queue is full.
+	QueueSize int
+}
+
+// archiveItem is a record to archive, or a marker closing flushed once the records queued
+// before it are archived.
+type archiveItem struct {
+	record  ArchiveRecord
+	flushed chan struct{}
+}
+
+// archiveQueue hands records to a single worker archiving them in order.
+type archiveQueue struct {
+	items chan archiveItem
+	done  chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+}
+
+func (c *Client) startArchive() {
+	size := c.config.Archive.QueueSize
+	if size <= 0 {
+		size = defaultArchiveQueueSize
+	}
+	q := &archiveQueue{items: make(chan archiveItem, size), done: make(chan struct{})}
+	go func() {
+		defer close(q.done)
+		for item := range q.items {
+			if item.flushed != nil {
+				close(item.flushed)
+				continue
+			}
+			c.archive(item.record)
+		}
+	}()
+	c.archiveQueue = q
+}
+
+// enqueue queues record without blocking the call that produced it.
+func (q *archiveQueue) enqueue(record ArchiveRecord) error {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	if q.closed {
+		return ErrArchiveClosed
+	}
+	select {
+	case q.items <- archiveItem{record: record}:
+		return nil
+	default:
+		return ErrArchiveQueueFull
+	}
+}
+
+// FlushArchive waits until the records of the calls that completed before it are archived,
+// or until ctx is done. It does nothing when ClientConfig.Archive is not set.
+func (c *Client) FlushArchive(ctx context.Context) error {
+	q := c.archiveQueue
+	if q == nil {
+		return nil
+	}
+	flushed := make(chan struct{})
+	q.mu.RLock()
+	if q.closed {
+		q.mu.RUnlock()
+		return waitDone(ctx, q.done)
+	}
+	select {
+	case q.items <- archiveItem{flushed: flushed}:
+		q.mu.RUnlock()
+	case <-ctx.Done():
+		q.mu.RUnlock()
+		return ctx.Err()
+	}
+	return waitDone(ctx, flushed)
+}
+
+// CloseArchive stops archiving: records of later calls are dropped with ErrArchiveClosed. It
+// waits until the queued records are archived, or until ctx is done. It does nothing when
+// ClientConfig.Archive is not set.
+func (c *Client) CloseArchive(ctx context.Context) error {
+	q := c.archiveQueue
+	if q == nil {
+		return nil
+	}
+	q.mu.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.items)
+	}
+	q.mu.Unlock()
+	return waitDone(ctx, q.done)
+}
+
+func waitDone(ctx context.Context, done <-chan struct{}) error {
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// captureForArchive buffers the response body of res so it can be both decoded and archived.
+// Bodies whose Content-Type is neither JSON nor text are not read.
+func (c *Client) captureForArchive(req *http.Request, res *http.Response) (finish func(err error)) {
+	if c.config.Archive == nil || !strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return func(error) {}
+	}
+
+	record := ArchiveRecord{
+		Time:       time.Now(),
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		StatusCode: res.StatusCode,
+		Metadata:   RequestMetadataFromContext(req.Context()),
+		Tag:        c.requestTag(req.Context()),
+	}
+	if req.GetBody != nil {
+		if body, err := req.GetBody(); err == nil {
+			b, _ := io.ReadAll(body)
+			record.Request = archivePayload(b)
+		}
+	}
+	var readErr error
+	if contentType := res.Header.Get("Content-Type"); isArchivedContentType(contentType) {
+		var b []byte
+		b, readErr = io.ReadAll(res.Body)
+		res.Body = io.NopCloser(bytes.NewReader(b))
+		if record.Response = archivePayload(b); record.Response == nil && len(b) > 0 {
+			record.ResponseContentType = contentType
+		}
+	} else {
+		record.ResponseContentType = contentType
+	}
+
+	return func(err error) {
+		if readErr != nil {
+			err = readErr
+		}
+		if err != nil {
+			record.Error = err.Error()
+		}
+		if err = c.archiveQueue.enqueue(record); err != nil && c.config.Archive.OnError != nil {
+			c.config.Archive.OnError(err)
+		}
+	}
+}
+
+func (c *Client) archive(record ArchiveRecord) {
+	config := c.config.Archive
+	if config.Redact != nil {
+		config.Redact(&record)
+	}
+	err := config.Archiver.Archive(context.Background(), record)
+	if err != nil && config.OnError != nil {
+		config.OnError(err)
+	}
+}
+
+// isArchivedContentType reports whether a body of contentType is archived: JSON, text, or
+// unspecified.
+func isArchivedContentType(contentType string) bool {
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasPrefix(mediaType, "text/"))
+}
+
+// archivePayload returns b itself if it is valid JSON, otherwise b encoded as a JSON string.
+// It returns nil for binary data, which would not survive the encoding.
+func archivePayload(b []byte) json.RawMessage {
+	if len(b) == 0 {
+		return nil
+	}
+	if json.Valid(b) {
+		return b
+	}
+	if !utf8.Valid(b) {
+		return nil
+	}
+	quoted, _ := json.Marshal(string(b))
+	return quoted
+}
+
+// FileArchiver appends records as JSON lines to a file. It is safe for concurrent use.
+type FileArchiver struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileArchiver opens (or creates) path for appending.
+func NewFileArchiver(path string) (*FileArchiver, error) {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	return &FileArchiver{file: file}, nil
+}
+
+// Archive implements Archiver.
+func (a *FileArchiver) Archive(_ context.Context, record ArchiveRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	_, err = a.file.Write(append(line, '\n'))
+	return err
+}
+
+// Close closes the underlying file.
+func (a *FileArchiver) Close() error {
+	return a.file.Close()
+}
+
+// ObjectStoreArchiver stores every record as its own object, e.g. in S3-compatible storage.
+// Put is typically a thin wrapper around the PutObject call of the storage SDK in use.
+type ObjectStoreArchiver struct {
+	// Prefix is prepended to the object keys, e.g. "openai/archive/".
+	Prefix string
+	Put    func(ctx context.Context, key string, data []byte) error
+}
+
+// Archive implements Archiver. Objects are keyed by date and time so they list chronologically,
+// followed by a random suffix, so records of the same time, e.g. from several replicas, do not
+// overwrite each other.
+func (a *ObjectStoreArchiver) Archive(ctx context.Context, record ArchiveRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	var suffix [8]byte
+	if _, err = rand.Read(suffix[:]); err != nil {
+		return err
+	}
+	key := fmt.Sprintf("%s%s/%s-%s.json", a.Prefix, record.Time.UTC().Format("2006/01/02"),
+		record.Time.UTC().Format("150405.000000000"), hex.EncodeToString(suffix[:]))
+	return a.Put(ctx, key, data)
+}
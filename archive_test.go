@@ -0,0 +1,222 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type channelArchiver chan ArchiveRecord
+
+func (a channelArchiver) Archive(_ context.Context, record ArchiveRecord) error {
+	a <- record
+	return nil
+}
+
+func TestArchive(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/moderations", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"modr-1","results":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	records := make(channelArchiver, 1)
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.Archive = &ArchiveConfig{
+		Archiver: records,
+		Redact: func(record *ArchiveRecord) {
+			record.Request = json.RawMessage(strings.ReplaceAll(string(record.Request), "secret", "[redacted]"))
+		},
+	}
+	client := NewClientWithConfig(config)
+
+	ctx := WithRequestMetadata(context.Background(), map[string]string{"tenant": "acme"})
+	_, err := client.Moderations(ctx, ModerationRequest{Input: "my secret"})
+	checks.NoError(t, err, "Moderations error")
+
+	select {
+	case record := <-records:
+		if record.Method != http.MethodPost || record.Path != "/v1/moderations" || record.StatusCode != http.StatusOK {
+			t.Fatalf("Unexpected record: %+v", record)
+		}
+		if string(record.Request) != `{"input":"my [redacted]"}` {
+			t.Fatalf("Request should be redacted, got %s", record.Request)
+		}
+		if string(record.Response) != `{"id":"modr-1","results":[]}` || record.Metadata["tenant"] != "acme" {
+			t.Fatalf("Unexpected record: %+v", record)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Record was not archived")
+	}
+}
+
+func TestArchiveBinaryResponse(t *testing.T) {
+	mp3 := []byte{0xFF, 0xFB, 0x90, 0x00, 0xC4}
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write(mp3)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	records := make(channelArchiver, 1)
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.Archive = &ArchiveConfig{Archiver: records}
+	client := NewClientWithConfig(config)
+
+	request := SpeechRequest{Model: TTSModel1, Input: "Hi", Voice: VoiceAlloy}
+	audio, err := client.CreateSpeech(context.Background(), request)
+	checks.NoError(t, err, "CreateSpeech error")
+	if string(audio) != string(mp3) {
+		t.Fatalf("Unexpected audio: %x", audio)
+	}
+
+	select {
+	case record := <-records:
+		if record.Response != nil || record.ResponseContentType != "audio/mpeg" {
+			t.Fatalf("Binary responses should not be archived, got %q (%s)", record.Response, record.ResponseContentType)
+		}
+		if !strings.Contains(string(record.Request), `"input":"Hi"`) {
+			t.Fatalf("Unexpected request: %s", record.Request)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Record was not archived")
+	}
+}
+
+// blockingArchiver archives records once release is closed.
+type blockingArchiver struct {
+	mu       sync.Mutex
+	release  chan struct{}
+	archived []string
+}
+
+func (a *blockingArchiver) Archive(_ context.Context, record ArchiveRecord) error {
+	<-a.release
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.archived = append(a.archived, record.Tag)
+	return nil
+}
+
+func TestArchiveQueue(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/moderations", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"modr-1","results":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var (
+		mu       sync.Mutex
+		errs     []error
+		ctx      = context.Background()
+		archiver = &blockingArchiver{release: make(chan struct{})}
+	)
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.Archive = &ArchiveConfig{
+		Archiver:  archiver,
+		QueueSize: 2,
+		OnError: func(err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, err)
+		},
+	}
+	client := NewClientWithConfig(config)
+
+	// The worker holds the first record, the queue the next two, and the fourth is dropped.
+	for _, tag := range []string{"a", "b", "c", "d"} {
+		_, err := client.Moderations(WithRequestTag(ctx, tag), ModerationRequest{Input: "hi"})
+		checks.NoError(t, err, "Moderations error")
+		time.Sleep(10 * time.Millisecond)
+	}
+	close(archiver.release)
+	checks.NoError(t, client.FlushArchive(ctx), "FlushArchive error")
+	checks.NoError(t, client.CloseArchive(ctx), "CloseArchive error")
+	_, err := client.Moderations(ctx, ModerationRequest{Input: "hi"})
+	checks.NoError(t, err, "Moderations error")
+
+	mu.Lock()
+	defer mu.Unlock()
+	if fmt.Sprint(archiver.archived) != "[a b c]" {
+		t.Fatalf("Expected the records archived in order, got %v", archiver.archived)
+	}
+	if len(errs) != 2 || !errors.Is(errs[0], ErrArchiveQueueFull) || !errors.Is(errs[1], ErrArchiveClosed) {
+		t.Fatalf("Expected a full queue and a closed archive, got %v", errs)
+	}
+}
+
+func TestFileArchiver(t *testing.T) {
+	dir, cleanup := test.CreateTestDirectory(t)
+	defer cleanup()
+	path := filepath.Join(dir, "archive.jsonl")
+
+	archiver, err := NewFileArchiver(path)
+	checks.NoError(t, err, "NewFileArchiver error")
+	for _, status := range []int{http.StatusOK, http.StatusTooManyRequests} {
+		err = archiver.Archive(context.Background(), ArchiveRecord{StatusCode: status, Response: json.RawMessage(`"text"`)})
+		checks.NoError(t, err, "Archive error")
+	}
+	checks.NoError(t, archiver.Close(), "Close error")
+
+	file, err := os.Open(path)
+	checks.NoError(t, err, "Open error")
+	defer file.Close()
+
+	var lines int
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record ArchiveRecord
+		checks.NoError(t, json.Unmarshal(scanner.Bytes(), &record), "Unmarshal error")
+		lines++
+	}
+	if lines != 2 {
+		t.Fatalf("Expected 2 archived lines, got %d", lines)
+	}
+}
+
+func TestObjectStoreArchiver(t *testing.T) {
+	objects := map[string][]byte{}
+	archiver := &ObjectStoreArchiver{
+		Prefix: "archive/",
+		Put: func(_ context.Context, key string, data []byte) error {
+			objects[key] = data
+			return nil
+		},
+	}
+	record := ArchiveRecord{Time: time.Date(2023, 5, 9, 10, 30, 0, 0, time.UTC), Path: "/v1/embeddings"}
+	checks.NoError(t, archiver.Archive(context.Background(), record), "Archive error")
+	checks.NoError(t, archiver.Archive(context.Background(), record), "Archive error")
+
+	if len(objects) != 2 {
+		t.Fatalf("Records of the same time should not overwrite each other: %v", objects)
+	}
+	for key, data := range objects {
+		if !strings.HasPrefix(key, "archive/2023/05/09/103000.000000000-") || !strings.HasSuffix(key, ".json") ||
+			!strings.Contains(string(data), `"path":"/v1/embeddings"`) {
+			t.Fatalf("Unexpected object %s: %s", key, data)
+		}
+	}
+}
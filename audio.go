@@ -3,50 +3,160 @@ package openai
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"mime"
 	"net/http"
 	"os"
+	"sort"
+	"strconv"
 	"strings"
 )
 
 // Whisper Defines the models provided by OpenAI to use when processing audio with OpenAI.
 const (
-	Whisper1 = "whisper-1"
+	Whisper1            = "whisper-1"
+	GPT4oTranscribe     = "gpt-4o-transcribe"
+	GPT4oMiniTranscribe = "gpt-4o-mini-transcribe"
+)
+
+var (
+	ErrAudioResponseFormatNotSupported = errors.New("response format is not supported by this model")
+	ErrAudioTimestampGranularities     = errors.New("timestamp granularities require the verbose_json response format")
+	ErrAudioTranslationNotSupported    = errors.New("this model or option is not supported for translations")
+	ErrAudioIncludeNotSupported        = errors.New("logprobs require a gpt-4o transcribe model and the json response format") //nolint:lll
+	ErrAudioPreflightReader            = errors.New("preflight is not supported for uploads from a Reader")
 )
 
 // Response formats; Whisper uses AudioResponseFormatJSON by default.
 type AudioResponseFormat string
 
 const (
-	AudioResponseFormatJSON AudioResponseFormat = "json"
-	AudioResponseFormatSRT  AudioResponseFormat = "srt"
-	AudioResponseFormatVTT  AudioResponseFormat = "vtt"
+	AudioResponseFormatJSON        AudioResponseFormat = "json"
+	AudioResponseFormatText        AudioResponseFormat = "text"
+	AudioResponseFormatSRT         AudioResponseFormat = "srt"
+	AudioResponseFormatVTT         AudioResponseFormat = "vtt"
+	AudioResponseFormatVerboseJSON AudioResponseFormat = "verbose_json"
+)
+
+// TranscriptionInclude is additional information included in a transcription.
+type TranscriptionInclude string
+
+const (
+	// TranscriptionIncludeLogprobs returns the log probabilities of the tokens of the transcription.
+	// It is only supported by GPT4oTranscribe and GPT4oMiniTranscribe with AudioResponseFormatJSON.
+	TranscriptionIncludeLogprobs TranscriptionInclude = "logprobs"
+)
+
+// TranscriptionTimestampGranularity selects the timestamps of an AudioResponseFormatVerboseJSON
+// transcription; segment timestamps are returned by default.
+type TranscriptionTimestampGranularity string
+
+const (
+	TranscriptionTimestampGranularityWord    TranscriptionTimestampGranularity = "word"
+	TranscriptionTimestampGranularitySegment TranscriptionTimestampGranularity = "segment"
 )
 
 // AudioRequest represents a request structure for audio API.
-// ResponseFormat is not supported for now. We only return JSON text, which may be sufficient.
+// Format selects the response format for both transcriptions and translations: JSON formats
+// are decoded into AudioResponse, while the text, SRT and VTT formats are returned as they are
+// in AudioResponse.Text, see ParseSubtitles.
 type AudioRequest struct {
 	Model       string
 	FilePath    string  // Local file path - leave empty if using FileBytes + FileName
 	FileBytes   *[]byte // File as bytes, also requires FileName to be set (see below)
 	FileName    *string // File name for usage together with FileBytes. The API requires this parameter and use them as file format, so at least correct extension is required.
+	FileURL     string  // https URL or data URI the client downloads the file from, instead of FilePath or FileBytes.
 	Prompt      string  // For translation, it should be 'English'
 	Temperature float32
 	Language    string // For better and faster recognition, but optional.
 	Format      AudioResponseFormat
+	Preflight   bool // Check the size and duration limits locally before uploading, see ProbeAudio.
+	// TimestampGranularities requires AudioResponseFormatVerboseJSON; Words are only returned
+	// when it includes TranscriptionTimestampGranularityWord.
+	TimestampGranularities []TranscriptionTimestampGranularity
+	// Include requests additional information in the response, see TranscriptionIncludeLogprobs.
+	Include []TranscriptionInclude
+	// Reader is streamed into the upload instead of FilePath or FileBytes, so large files need not
+	// be held in memory; it also requires FileName. Uploads from a Reader cannot be retried, and
+	// are rejected with ErrAudioPreflightReader when Preflight is set.
+	Reader io.Reader
+	// Diarize asks OpenAI-compatible backends that support it to label the speaker of every
+	// segment, see AudioSegment.Speaker; NumSpeakers is the expected number of speakers,
+	// if known. Both are sent as the diarize and num_speakers form fields.
+	Diarize     bool
+	NumSpeakers int
+	// TextMetadata requests AudioResponseFormatText transcriptions as JSON under the hood, so the
+	// response has Language and Duration where the model reports them, along with the plain Text:
+	// verbose_json is requested from Whisper and json, which reports Usage, from the gpt-4o models.
+	TextMetadata bool
+	// ExtraFields are written verbatim as additional form fields, in key order, for proxies and
+	// alternative Whisper servers that accept provider-specific fields such as vad_filter.
+	ExtraFields map[string]string
+	// OnProgress is called as the upload is sent, with the bytes of the multipart body sent so
+	// far and its total size, which is -1 for uploads from a Reader. Retried uploads report
+	// their progress from zero again.
+	OnProgress func(bytesSent, total int64)
+
+	stream bool
 }
 
-// AudioResponse represents a response structure for audio API.
+// AudioResponse represents a response structure for audio API. Only Text is set unless the
+// request Format is AudioResponseFormatVerboseJSON, or AudioRequest.TextMetadata is set.
 type AudioResponse struct {
-	Text string `json:"text"`
+	Task     string         `json:"task,omitempty"`
+	Language string         `json:"language,omitempty"`
+	Duration float64        `json:"duration,omitempty"` // Seconds.
+	Segments []AudioSegment `json:"segments,omitempty"`
+	Words    []AudioWord    `json:"words,omitempty"`
+	Text     string         `json:"text"`
+	// Logprobs is only set when the request includes TranscriptionIncludeLogprobs.
+	Logprobs []AudioLogprob `json:"logprobs,omitempty"`
+	// Usage is reported by the gpt-4o transcribe models for JSON responses.
+	Usage *TranscriptionUsage `json:"usage,omitempty"`
+
+	ResponseMeta `json:"-"`
+}
+
+// AudioLogprob is the log probability of a token of a transcription.
+type AudioLogprob struct {
+	Token   string  `json:"token"`
+	Logprob float64 `json:"logprob"`
+	Bytes   []int   `json:"bytes,omitempty"`
+}
+
+// AudioSegment is a segment of a verbose_json transcription, with times in seconds.
+type AudioSegment struct {
+	ID               int     `json:"id"`
+	Seek             int     `json:"seek"`
+	Start            float64 `json:"start"`
+	End              float64 `json:"end"`
+	Text             string  `json:"text"`
+	Tokens           []int   `json:"tokens"`
+	Temperature      float64 `json:"temperature"`
+	AvgLogprob       float64 `json:"avg_logprob"`
+	CompressionRatio float64 `json:"compression_ratio"`
+	NoSpeechProb     float64 `json:"no_speech_prob"`
+	// Speaker is the speaker label of diarized transcriptions, see AudioRequest.Diarize.
+	Speaker string `json:"speaker,omitempty"`
+}
+
+// AudioWord is a word of a verbose_json transcription, with times in seconds.
+type AudioWord struct {
+	Word  string  `json:"word"`
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
 }
 
 // CreateTranscription — API call to create a transcription. Returns transcribed text.
 func (c *Client) CreateTranscription(
 	ctx context.Context,
 	request AudioRequest,
+	opts ...RequestOption,
 ) (response AudioResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
 	return c.callAudioAPI(ctx, request, "transcriptions")
 }
 
@@ -54,7 +164,9 @@ func (c *Client) CreateTranscription(
 func (c *Client) CreateTranslation(
 	ctx context.Context,
 	request AudioRequest,
+	opts ...RequestOption,
 ) (response AudioResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
 	return c.callAudioAPI(ctx, request, "translations")
 }
 
@@ -64,34 +176,254 @@ func (c *Client) callAudioAPI(
 	request AudioRequest,
 	endpointSuffix string,
 ) (response AudioResponse, err error) {
-	var formBody bytes.Buffer
-	builder := c.createFormBuilder(&formBody)
-
-	if err = audioMultipartForm(request, builder); err != nil {
-		return AudioResponse{}, err
+	if request.TextMetadata && request.Format == AudioResponseFormatText {
+		request.Format = textMetadataFormat(request.Model)
 	}
-
-	urlSuffix := fmt.Sprintf("/audio/%s", endpointSuffix)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.fullURL(urlSuffix), &formBody)
+	// Uploads are large and slow, so transient failures are retried unless configured otherwise.
+	if c.retryConfig(ctx) == nil && !requestOptionsFromContext(ctx).retrySet {
+		ctx = WithRequestOptions(ctx, WithRetry(&RetryConfig{MaxAttempts: defaultAudioRetryAttempts}))
+	}
+	req, err := c.newAudioAPIRequest(ctx, request, endpointSuffix)
 	if err != nil {
 		return AudioResponse{}, err
 	}
-	req.Header.Add("Content-Type", builder.formDataContentType())
 
 	if request.HasJSONResponse() {
 		err = c.sendRequest(req, &response)
 	} else {
-		err = c.sendRequest(req, &response.Text)
+		// Text, SRT and VTT, and formats unknown to the client, which HasTextResponse leaves out.
+		var text audioTextResponse
+		if err = c.sendRequest(req, &text); err == nil {
+			response, err = text.decode()
+		}
 	}
 	if err != nil {
 		return AudioResponse{}, err
 	}
+	if response.Duration == 0 && response.Usage != nil && response.Usage.Type == "duration" {
+		response.Duration = response.Usage.Seconds
+	}
+	return
+}
+
+// audioTextResponse receives the body of a response in a text format, along with its headers.
+type audioTextResponse struct {
+	bytes.Buffer
+	header http.Header
+}
+
+func (r *audioTextResponse) setResponseMeta(h http.Header) {
+	r.header = h
+}
+
+// decode returns the body as Text, unless the server ignored the requested format and
+// answered with JSON, as some OpenAI-compatible servers do.
+func (r *audioTextResponse) decode() (response AudioResponse, err error) {
+	if mediaType, _, _ := mime.ParseMediaType(r.header.Get("Content-Type")); mediaType == "application/json" {
+		err = json.Unmarshal(r.Bytes(), &response)
+	} else {
+		response.Text = r.String()
+	}
+	response.setResponseMeta(r.header)
+	return
+}
+
+// textMetadataFormat returns the JSON format requested instead of text for AudioRequest.TextMetadata:
+// verbose_json, which has the language and duration, unless model is known not to support it.
+func textMetadataFormat(model string) AudioResponseFormat {
+	if validateAudioResponseFormat(model, AudioResponseFormatVerboseJSON) == nil {
+		return AudioResponseFormatVerboseJSON
+	}
+	return AudioResponseFormatJSON
+}
+
+// newAudioAPIRequest validates request and builds the request to an audio endpoint.
+func (c *Client) newAudioAPIRequest(
+	ctx context.Context,
+	request AudioRequest,
+	endpointSuffix string,
+) (req *http.Request, err error) {
+	if err = validateAudioResponseFormat(request.Model, request.Format); err != nil {
+		return
+	}
+	if endpointSuffix == "translations" {
+		if err = validateTranslation(request); err != nil {
+			return
+		}
+	}
+	if len(request.TimestampGranularities) > 0 && request.Format != AudioResponseFormatVerboseJSON {
+		err = ErrAudioTimestampGranularities
+		return
+	}
+	if err = validateAudioInclude(request); err != nil {
+		return
+	}
+	if request.FileURL != "" {
+		if err = c.fetchAudioURL(ctx, &request); err != nil {
+			return
+		}
+	}
+	streamed := request.FilePath == "" && request.FileBytes == nil && request.Reader != nil
+	if request.Preflight {
+		if streamed {
+			err = ErrAudioPreflightReader
+			return
+		}
+		if err = preflightAudio(request); err != nil {
+			return
+		}
+	}
+
+	url := c.fullURL(fmt.Sprintf("/audio/%s", endpointSuffix))
+	if streamed {
+		req, err = c.newStreamedAudioRequest(ctx, url, request)
+	} else {
+		req, err = c.newAudioRequest(ctx, url, request)
+	}
+	if err == nil && request.OnProgress != nil {
+		withUploadProgress(req, request.OnProgress)
+	}
+	return
+}
+
+// withUploadProgress makes the body of req report its progress to onProgress as it is read.
+func withUploadProgress(req *http.Request, onProgress func(bytesSent, total int64)) {
+	total := req.ContentLength
+	if total <= 0 {
+		total = -1
+	}
+	req.Body = &progressReadCloser{ReadCloser: req.Body, total: total, onProgress: onProgress}
+	if getBody := req.GetBody; getBody != nil {
+		req.GetBody = func() (io.ReadCloser, error) {
+			body, err := getBody()
+			if err != nil {
+				return nil, err
+			}
+			return &progressReadCloser{ReadCloser: body, total: total, onProgress: onProgress}, nil
+		}
+	}
+}
+
+type progressReadCloser struct {
+	io.ReadCloser
+	sent       int64
+	total      int64
+	onProgress func(bytesSent, total int64)
+}
+
+func (r *progressReadCloser) Read(p []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(p)
+	if n > 0 {
+		r.sent += int64(n)
+		r.onProgress(r.sent, r.total)
+	}
 	return
 }
 
-// HasJSONResponse returns true if the response format is JSON.
+// newAudioRequest builds the multipart form of request in memory, so the request can be resent.
+func (c *Client) newAudioRequest(ctx context.Context, url string, request AudioRequest) (*http.Request, error) {
+	var formBody bytes.Buffer
+	builder := c.createFormBuilder(&formBody)
+
+	if err := audioMultipartForm(request, builder); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, &formBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", builder.formDataContentType())
+	return req, nil
+}
+
+// newStreamedAudioRequest writes the multipart form of request while it is sent, streaming
+// request.Reader into it.
+func (c *Client) newStreamedAudioRequest(ctx context.Context, url string, request AudioRequest) (*http.Request, error) {
+	if request.FileName == nil || !strings.Contains(*request.FileName, ".") {
+		return nil, errors.New("FileName with correct extension is required while Reader is used")
+	}
+
+	pr, pw := io.Pipe()
+	builder := c.createFormBuilder(pw)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, pr)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Add("Content-Type", builder.formDataContentType())
+
+	// The transport closes pr once it stops reading, failing the writes of an abandoned upload.
+	go func() {
+		pw.CloseWithError(audioMultipartForm(request, builder))
+	}()
+	return req, nil
+}
+
+// audioModelFormats lists the response formats of the OpenAI audio models. Other models,
+// e.g. those of OpenAI-compatible servers, are not validated.
+var audioModelFormats = map[string][]AudioResponseFormat{
+	Whisper1: {
+		AudioResponseFormatJSON, AudioResponseFormatText, AudioResponseFormatSRT,
+		AudioResponseFormatVerboseJSON, AudioResponseFormatVTT,
+	},
+	GPT4oTranscribe:     {AudioResponseFormatJSON, AudioResponseFormatText},
+	GPT4oMiniTranscribe: {AudioResponseFormatJSON, AudioResponseFormatText},
+}
+
+// validateAudioResponseFormat fails locally when model is known not to support format,
+// instead of letting the API respond with a 400.
+func validateAudioResponseFormat(model string, format AudioResponseFormat) error {
+	formats, ok := audioModelFormats[model]
+	if !ok || format == "" {
+		return nil
+	}
+	for _, supported := range formats {
+		if format == supported {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: %s does not support %q, use one of %v",
+		ErrAudioResponseFormatNotSupported, model, format, formats)
+}
+
+// validateTranslation rejects the models and options known to be transcription only.
+func validateTranslation(request AudioRequest) error {
+	switch {
+	case request.Model == GPT4oTranscribe || request.Model == GPT4oMiniTranscribe:
+		return fmt.Errorf("%w: %s only transcribes", ErrAudioTranslationNotSupported, request.Model)
+	case len(request.TimestampGranularities) > 0:
+		return fmt.Errorf("%w: timestamp granularities", ErrAudioTranslationNotSupported)
+	case len(request.Include) > 0:
+		return fmt.Errorf("%w: include", ErrAudioTranslationNotSupported)
+	}
+	return nil
+}
+
+// validateAudioInclude fails locally when logprobs are requested for a request that cannot
+// return them. Unknown models, e.g. those of OpenAI-compatible servers, are not validated.
+func validateAudioInclude(request AudioRequest) error {
+	for _, include := range request.Include {
+		if include != TranscriptionIncludeLogprobs {
+			continue
+		}
+		if request.Model == Whisper1 || !request.HasJSONResponse() || request.Format == AudioResponseFormatVerboseJSON {
+			return fmt.Errorf("%w: %s with %q", ErrAudioIncludeNotSupported, request.Model, request.Format)
+		}
+	}
+	return nil
+}
+
+// HasJSONResponse returns true if the response format is JSON, which is decoded into AudioResponse.
 func (r AudioRequest) HasJSONResponse() bool {
-	return r.Format == "" || r.Format == AudioResponseFormatJSON
+	return r.Format == "" || r.Format == AudioResponseFormatJSON || r.Format == AudioResponseFormatVerboseJSON
+}
+
+// HasTextResponse returns true if the response format is AudioResponseFormatText, SRT or VTT,
+// which are returned as they are in AudioResponse.Text. It returns false for formats unknown
+// to the client, although their responses are returned in AudioResponse.Text too.
+func (r AudioRequest) HasTextResponse() bool {
+	return r.Format == AudioResponseFormatText || r.Format == AudioResponseFormatSRT ||
+		r.Format == AudioResponseFormatVTT
 }
 
 // audioMultipartForm creates a form with audio file contents and the name of the model to use for
@@ -124,8 +456,16 @@ func audioMultipartForm(request AudioRequest, b formBuilder) error {
 			}
 		}
 
+	} else if request.Reader != nil {
+		if request.FileName == nil || !strings.Contains(*request.FileName, ".") {
+			return errors.New("FileName with correct extension is required while Reader is used")
+		}
+		err := b.createFormFileFromReader("file", *request.FileName, request.Reader)
+		if err != nil {
+			return fmt.Errorf("creating form file from reader: %w", err)
+		}
 	} else {
-		return errors.New("either FilePath or FileBytes should be specified")
+		return errors.New("either FilePath, FileBytes or Reader should be specified")
 	}
 
 	err := b.writeField("model", request.Model)
@@ -165,6 +505,56 @@ func audioMultipartForm(request AudioRequest, b formBuilder) error {
 		}
 	}
 
+	// Create form fields for diarization (if requested)
+	if request.Diarize {
+		err = b.writeField("diarize", "true")
+		if err != nil {
+			return fmt.Errorf("writing diarize: %w", err)
+		}
+	}
+	if request.NumSpeakers > 0 {
+		err = b.writeField("num_speakers", strconv.Itoa(request.NumSpeakers))
+		if err != nil {
+			return fmt.Errorf("writing num_speakers: %w", err)
+		}
+	}
+
+	if request.stream {
+		err = b.writeField("stream", "true")
+		if err != nil {
+			return fmt.Errorf("writing stream: %w", err)
+		}
+	}
+
+	// Create a form field for each included information (if provided)
+	for _, include := range request.Include {
+		err = b.writeField("include[]", string(include))
+		if err != nil {
+			return fmt.Errorf("writing include: %w", err)
+		}
+	}
+
+	// Create a form field for each timestamp granularity (if provided)
+	for _, granularity := range request.TimestampGranularities {
+		err = b.writeField("timestamp_granularities[]", string(granularity))
+		if err != nil {
+			return fmt.Errorf("writing timestamp granularity: %w", err)
+		}
+	}
+
+	// Create a form field for each extra field (if provided)
+	keys := make([]string, 0, len(request.ExtraFields))
+	for key := range request.ExtraFields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		err = b.writeField(key, request.ExtraFields[key])
+		if err != nil {
+			return fmt.Errorf("writing %s: %w", key, err)
+		}
+	}
+
 	// Close the multipart writer
 	return b.close()
 }
@@ -0,0 +1,88 @@
+package openai
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const (
+	defaultTranscribeAllConcurrency = 4
+	defaultTranscribeAllRetryDelay  = time.Second
+)
+
+// TranscribeAllOptions configures TranscribeAll.
+type TranscribeAllOptions struct {
+	// Concurrency is the number of transcriptions in flight, defaults to 4.
+	Concurrency int
+	// MaxAttempts is how many times a transcription failing with a rate limit, server or network
+	// error is tried, defaults to 1. Requests uploading from a Reader are only tried once. When set
+	// above 1, it is used as the RetryConfig of the calls in place of the default retries of audio
+	// calls, unless a RetryConfig is configured.
+	MaxAttempts int
+	// RetryDelay is the delay before the first retry of a transcription, doubled for every further
+	// retry. Defaults to one second.
+	RetryDelay time.Duration
+}
+
+// TranscriptionResult is the outcome of one request of TranscribeAll.
+type TranscriptionResult struct {
+	Response AudioResponse
+	Err      error
+}
+
+// TranscribeAll transcribes requests concurrently and returns their results in input order.
+// A failed request does not stop the others; once ctx is done, the requests not yet started
+// fail with its error.
+func (c *Client) TranscribeAll(
+	ctx context.Context,
+	requests []AudioRequest,
+	options TranscribeAllOptions,
+	opts ...RequestOption,
+) []TranscriptionResult {
+	ctx = withCallOptions(ctx, opts)
+	results := make([]TranscriptionResult, len(requests))
+	concurrency := options.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultTranscribeAllConcurrency
+	}
+
+	var wg sync.WaitGroup
+	work := make(chan int)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range work {
+				response, err := c.transcribeWithRetries(ctx, requests[index], options)
+				results[index] = TranscriptionResult{Response: response, Err: err}
+			}
+		}()
+	}
+
+	for index := range requests {
+		if err := ctx.Err(); err != nil {
+			results[index].Err = err
+			continue
+		}
+		work <- index
+	}
+	close(work)
+	wg.Wait()
+	return results
+}
+
+func (c *Client) transcribeWithRetries(
+	ctx context.Context,
+	request AudioRequest,
+	options TranscribeAllOptions,
+) (AudioResponse, error) {
+	if options.MaxAttempts > 1 && c.retryConfig(ctx) == nil {
+		delay := options.RetryDelay
+		if delay <= 0 {
+			delay = defaultTranscribeAllRetryDelay
+		}
+		ctx = WithRequestOptions(ctx, WithRetry(&RetryConfig{MaxAttempts: options.MaxAttempts, InitialDelay: delay}))
+	}
+	return c.CreateTranscription(ctx, request)
+}
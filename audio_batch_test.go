@@ -0,0 +1,107 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestTranscribeAll(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		attempts = map[string]int{}
+		inFlight int32
+		peak     int32
+	)
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		if n := atomic.AddInt32(&inFlight, 1); n > atomic.LoadInt32(&peak) {
+			atomic.StoreInt32(&peak, n)
+		}
+		defer atomic.AddInt32(&inFlight, -1)
+		time.Sleep(10 * time.Millisecond)
+
+		_, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, "missing file", http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		attempts[header.Filename]++
+		attempt := attempts[header.Filename]
+		mu.Unlock()
+
+		switch {
+		case header.Filename == "flaky.mp3" && attempt == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		case header.Filename == "invalid.mp3":
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"invalid file"}}`)
+		default:
+			fmt.Fprintf(w, `{"text":%q}`, header.Filename)
+		}
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	names := []string{"a.mp3", "flaky.mp3", "invalid.mp3", "b.mp3", "c.mp3"}
+	requests := make([]AudioRequest, len(names))
+	for i := range names {
+		data := []byte("ID3")
+		requests[i] = AudioRequest{Model: Whisper1, FileBytes: &data, FileName: &names[i]}
+	}
+	results := client.TranscribeAll(context.Background(), requests, TranscribeAllOptions{
+		Concurrency: 2,
+		MaxAttempts: 3,
+		RetryDelay:  time.Millisecond,
+	})
+
+	for i, result := range results {
+		if names[i] == "invalid.mp3" {
+			var apiErr *APIError
+			if !errors.As(result.Err, &apiErr) || attempts[names[i]] != 1 {
+				t.Errorf("Expected %s to fail once with an APIError, got %d attempts: %v",
+					names[i], attempts[names[i]], result.Err)
+			}
+			continue
+		}
+		if result.Err != nil || result.Response.Text != names[i] {
+			t.Errorf("Unexpected result %d: %+v", i, result)
+		}
+	}
+	if attempts["flaky.mp3"] != 2 {
+		t.Errorf("Expected flaky.mp3 to be retried once, got %d attempts", attempts["flaky.mp3"])
+	}
+	if peak > 2 {
+		t.Errorf("Expected at most 2 transcriptions in flight, got %d", peak)
+	}
+}
+
+func TestTranscribeAllCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	data, name := []byte("ID3"), "a.mp3"
+	results := NewClient(test.GetTestToken()).TranscribeAll(ctx, []AudioRequest{
+		{Model: Whisper1, FileBytes: &data, FileName: &name},
+		{Model: Whisper1, FileBytes: &data, FileName: &name},
+	}, TranscribeAllOptions{})
+	for i, result := range results {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("Expected result %d to be canceled, got %v", i, result.Err)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package openai
+
+import "math"
+
+const defaultReviewThreshold = 0.6
+
+// ConfidenceOptions configures AudioResponse.Confidence.
+type ConfidenceOptions struct {
+	// ReviewThreshold is the confidence, between 0 and 1, below which a segment or transcript
+	// needs human review. Defaults to 0.6.
+	ReviewThreshold float64
+}
+
+// SegmentConfidence is the confidence of a segment of a transcription.
+type SegmentConfidence struct {
+	Segment     AudioSegment
+	Confidence  float64
+	NeedsReview bool
+}
+
+// TranscriptionConfidence is the confidence report of a transcription.
+type TranscriptionConfidence struct {
+	// Overall is the mean confidence of the segments weighted by their duration, or the mean
+	// token probability of the logprobs for responses without segments.
+	Overall float64
+	// Segments is empty for responses without segments.
+	Segments []SegmentConfidence
+	// NeedsReview is set when Overall or the confidence of any segment is below the threshold.
+	NeedsReview bool
+}
+
+// Confidence scores the transcription from the segments of AudioResponseFormatVerboseJSON
+// responses or the logprobs of responses including TranscriptionIncludeLogprobs; ok is false
+// when the response has neither. Scores are between 0 and 1: the probability of the tokens of
+// a segment, discounted by the probability that the segment holds no speech at all.
+func (r AudioResponse) Confidence(options ConfidenceOptions) (report TranscriptionConfidence, ok bool) {
+	threshold := options.ReviewThreshold
+	if threshold <= 0 {
+		threshold = defaultReviewThreshold
+	}
+
+	switch {
+	case len(r.Segments) > 0:
+		var weighted, duration float64
+		for _, segment := range r.Segments {
+			confidence := segmentConfidence(segment)
+			report.Segments = append(report.Segments, SegmentConfidence{
+				Segment:     segment,
+				Confidence:  confidence,
+				NeedsReview: confidence < threshold,
+			})
+			report.NeedsReview = report.NeedsReview || confidence < threshold
+			weighted += confidence * (segment.End - segment.Start)
+			duration += segment.End - segment.Start
+		}
+		if duration > 0 {
+			report.Overall = weighted / duration
+		} else {
+			for _, segment := range report.Segments {
+				report.Overall += segment.Confidence / float64(len(report.Segments))
+			}
+		}
+	case len(r.Logprobs) > 0:
+		var sum float64
+		for _, logprob := range r.Logprobs {
+			sum += logprob.Logprob
+		}
+		report.Overall = math.Exp(sum / float64(len(r.Logprobs)))
+	default:
+		return
+	}
+	report.NeedsReview = report.NeedsReview || report.Overall < threshold
+	return report, true
+}
+
+// AverageLogprob returns the mean avg_logprob of the segments weighted by their duration, or
+// the mean logprob of the tokens for responses without segments; ok is false when the response
+// has neither.
+func (r AudioResponse) AverageLogprob() (logprob float64, ok bool) {
+	switch {
+	case len(r.Segments) > 0:
+		var weighted, duration, sum float64
+		for _, segment := range r.Segments {
+			weighted += segment.AvgLogprob * (segment.End - segment.Start)
+			duration += segment.End - segment.Start
+			sum += segment.AvgLogprob
+		}
+		if duration > 0 {
+			return weighted / duration, true
+		}
+		return sum / float64(len(r.Segments)), true
+	case len(r.Logprobs) > 0:
+		var sum float64
+		for _, token := range r.Logprobs {
+			sum += token.Logprob
+		}
+		return sum / float64(len(r.Logprobs)), true
+	}
+	return
+}
+
+// LowConfidenceSegments returns the segments whose confidence, as scored by Confidence, is
+// below threshold, in the order of the transcript.
+func (r AudioResponse) LowConfidenceSegments(threshold float64) []AudioSegment {
+	var low []AudioSegment
+	for _, segment := range r.Segments {
+		if segmentConfidence(segment) < threshold {
+			low = append(low, segment)
+		}
+	}
+	return low
+}
+
+func segmentConfidence(segment AudioSegment) float64 {
+	return math.Exp(segment.AvgLogprob) * (1 - segment.NoSpeechProb)
+}
@@ -0,0 +1,62 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+
+	"math"
+	"testing"
+)
+
+func TestAudioResponseConfidence(t *testing.T) {
+	response := AudioResponse{Segments: []AudioSegment{
+		{Start: 0, End: 3, AvgLogprob: math.Log(0.9)},
+		{Start: 3, End: 4, AvgLogprob: math.Log(0.8), NoSpeechProb: 0.5},
+	}}
+	report, ok := response.Confidence(ConfidenceOptions{})
+	if !ok || len(report.Segments) != 2 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	if math.Abs(report.Segments[0].Confidence-0.9) > 1e-9 || report.Segments[0].NeedsReview ||
+		math.Abs(report.Segments[1].Confidence-0.4) > 1e-9 || !report.Segments[1].NeedsReview {
+		t.Errorf("Unexpected segments: %+v", report.Segments)
+	}
+	if math.Abs(report.Overall-(0.9*3+0.4)/4) > 1e-9 || !report.NeedsReview {
+		t.Errorf("Unexpected overall confidence: %+v", report)
+	}
+	if report, _ = response.Confidence(ConfidenceOptions{ReviewThreshold: 0.3}); report.NeedsReview {
+		t.Errorf("Expected no review below a threshold of 0.3: %+v", report)
+	}
+
+	response = AudioResponse{Logprobs: []AudioLogprob{{Logprob: math.Log(0.5)}, {Logprob: math.Log(0.5)}}}
+	if report, ok = response.Confidence(ConfidenceOptions{}); !ok || math.Abs(report.Overall-0.5) > 1e-9 ||
+		!report.NeedsReview {
+		t.Errorf("Unexpected logprobs report: %+v", report)
+	}
+
+	if _, ok = (AudioResponse{Text: "Hi"}).Confidence(ConfidenceOptions{}); ok {
+		t.Error("Expected no report without segments or logprobs")
+	}
+}
+
+func TestAudioResponseLogprobHelpers(t *testing.T) {
+	response := AudioResponse{Segments: []AudioSegment{
+		{ID: 0, Start: 0, End: 3, AvgLogprob: -0.1},
+		{ID: 1, Start: 3, End: 4, AvgLogprob: -0.5},
+		{ID: 2, Start: 4, End: 5, AvgLogprob: -0.1, NoSpeechProb: 0.9},
+	}}
+	if logprob, ok := response.AverageLogprob(); !ok || math.Abs(logprob-(-0.3-0.5-0.1)/5) > 1e-9 {
+		t.Errorf("Unexpected average logprob: %v, %v", logprob, ok)
+	}
+	low := response.LowConfidenceSegments(0.7)
+	if len(low) != 2 || low[0].ID != 1 || low[1].ID != 2 {
+		t.Errorf("Unexpected low confidence segments: %+v", low)
+	}
+
+	response = AudioResponse{Logprobs: []AudioLogprob{{Logprob: -1}, {Logprob: -2}}}
+	if logprob, ok := response.AverageLogprob(); !ok || logprob != -1.5 {
+		t.Errorf("Unexpected average token logprob: %v, %v", logprob, ok)
+	}
+	if _, ok := (AudioResponse{Text: "Hi"}).AverageLogprob(); ok {
+		t.Error("Expected no average without segments or logprobs")
+	}
+}
@@ -0,0 +1,247 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// defaultAudioChunkBytes leaves room below AudioUploadLimit for the multipart envelope.
+	defaultAudioChunkBytes = AudioUploadLimit - 1<<20
+	// continuityPromptChars bounds the transcript tail passed on as the prompt of the next chunk;
+	// the API only considers the last 224 tokens of a prompt.
+	continuityPromptChars = 600
+)
+
+// LargeAudioConfig configures TranscribeLargeFile.
+type LargeAudioConfig struct {
+	// MaxChunkBytes defaults to 24 MiB.
+	MaxChunkBytes int
+	// MaxChunkDuration defaults to the duration limit of the model, if it has one.
+	MaxChunkDuration time.Duration
+	// Concurrency is the number of chunks transcribed at a time; one by default. It is ignored
+	// with PromptContinuity, as every chunk then waits for the transcript of the previous one.
+	Concurrency int
+	// PromptContinuity prompts every chunk with the end of the transcript of the previous one,
+	// which keeps spelling and style consistent across chunk boundaries.
+	PromptContinuity bool
+}
+
+// TranscribeLargeFile transcribes audio of any size: the WAV or MP3 file of request is split
+// with SplitAudio into chunks the API accepts, the chunks are transcribed, and the results are
+// stitched into a single response, segment and word timestamps shifted to the offsets of their
+// chunks. Files within the limits are sent as they are. The JSON, text and verbose_json formats
+// are supported, so that results can be stitched, and the file may not be given by FileURL.
+func (c *Client) TranscribeLargeFile(
+	ctx context.Context,
+	request AudioRequest,
+	config LargeAudioConfig,
+	opts ...RequestOption,
+) (response AudioResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
+	switch request.Format {
+	case "", AudioResponseFormatJSON, AudioResponseFormatText, AudioResponseFormatVerboseJSON:
+	default:
+		err = fmt.Errorf("%w: chunked transcriptions cannot be stitched in %q",
+			ErrAudioResponseFormatNotSupported, request.Format)
+		return
+	}
+	if config.MaxChunkBytes <= 0 {
+		config.MaxChunkBytes = defaultAudioChunkBytes
+	}
+	if config.MaxChunkDuration <= 0 {
+		config.MaxChunkDuration = audioModelMaxDuration[request.Model]
+	}
+
+	data, name, err := readAudioRequest(request)
+	if err != nil {
+		return
+	}
+	probe := ProbeAudio(data)
+	if len(data) <= config.MaxChunkBytes && (config.MaxChunkDuration <= 0 || probe.Duration <= config.MaxChunkDuration) {
+		return c.CreateTranscription(ctx, audioChunkRequest(request, data, name))
+	}
+
+	chunks, err := SplitAudio(data, config.MaxChunkBytes, config.MaxChunkDuration)
+	if err != nil {
+		return
+	}
+	ext := filepath.Ext(name)
+	requests := make([]AudioRequest, len(chunks))
+	for i, chunk := range chunks {
+		chunkName := fmt.Sprintf("%s-part%d%s", strings.TrimSuffix(name, ext), i+1, ext)
+		requests[i] = audioChunkRequest(request, chunk.Data, chunkName)
+	}
+
+	var responses []AudioResponse
+	if config.PromptContinuity {
+		responses, err = c.transcribeChunksInOrder(ctx, requests)
+	} else {
+		responses, err = c.transcribeChunksConcurrently(ctx, requests, config.Concurrency)
+	}
+	if err != nil {
+		return
+	}
+	return stitchTranscriptions(chunks, responses), nil
+}
+
+// readAudioRequest returns the contents and file name of the audio of request.
+func readAudioRequest(request AudioRequest) (data []byte, name string, err error) {
+	switch {
+	case request.FilePath != "":
+		data, err = os.ReadFile(request.FilePath)
+		if err != nil {
+			err = fmt.Errorf("opening audio file: %w", err)
+		}
+		name = filepath.Base(request.FilePath)
+		return
+	case request.FileBytes != nil:
+		data = *request.FileBytes
+	case request.Reader != nil:
+		if data, err = io.ReadAll(request.Reader); err != nil {
+			err = fmt.Errorf("reading audio: %w", err)
+			return
+		}
+	default:
+		err = errors.New("either FilePath, FileBytes or Reader should be specified")
+		return
+	}
+	if request.FileName == nil || !strings.Contains(*request.FileName, ".") {
+		err = errors.New("FileName with correct extension is required while FileBytes or Reader is used")
+		return
+	}
+	name = *request.FileName
+	return
+}
+
+// audioChunkRequest returns a copy of request uploading data as name.
+func audioChunkRequest(request AudioRequest, data []byte, name string) AudioRequest {
+	request.FilePath, request.FileURL, request.Reader = "", "", nil
+	request.FileBytes, request.FileName = &data, &name
+	return request
+}
+
+func (c *Client) transcribeChunksInOrder(ctx context.Context, requests []AudioRequest) ([]AudioResponse, error) {
+	responses := make([]AudioResponse, len(requests))
+	for i, request := range requests {
+		if i > 0 {
+			request.Prompt = continuityPrompt(request.Prompt, responses[i-1].Text)
+		}
+		response, err := c.CreateTranscription(ctx, request)
+		if err != nil {
+			return nil, fmt.Errorf("transcribing chunk %d of %d: %w", i+1, len(requests), err)
+		}
+		responses[i] = response
+	}
+	return responses, nil
+}
+
+func (c *Client) transcribeChunksConcurrently(
+	ctx context.Context,
+	requests []AudioRequest,
+	concurrency int,
+) ([]AudioResponse, error) {
+	if concurrency <= 0 {
+		concurrency = 1
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	responses := make([]AudioResponse, len(requests))
+	work := make(chan int)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for index := range work {
+				response, err := c.CreateTranscription(ctx, requests[index])
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = fmt.Errorf("transcribing chunk %d of %d: %w", index+1, len(requests), err)
+					cancel()
+				}
+				responses[index] = response
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i := range requests {
+		select {
+		case work <- i:
+		case <-ctx.Done():
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return responses, nil
+}
+
+// continuityPrompt appends the end of the previous transcript to the prompt of the request,
+// cut at a word boundary, or at a rune boundary for text without spaces.
+func continuityPrompt(prompt, previous string) string {
+	previous = strings.TrimSpace(previous)
+	if len(previous) > continuityPromptChars {
+		cut := len(previous) - continuityPromptChars
+		for cut < len(previous) && !utf8.RuneStart(previous[cut]) {
+			cut++
+		}
+		previous = previous[cut:]
+		if i := strings.IndexByte(previous, ' '); i >= 0 {
+			previous = previous[i+1:]
+		}
+	}
+	if prompt == "" {
+		return previous
+	}
+	return prompt + " " + previous
+}
+
+// stitchTranscriptions joins the transcriptions of chunks into one response.
+func stitchTranscriptions(chunks []AudioChunk, responses []AudioResponse) (stitched AudioResponse) {
+	texts := make([]string, 0, len(responses))
+	for i, response := range responses {
+		offset := chunks[i].Offset.Seconds()
+		if i == 0 {
+			stitched.Task, stitched.Language = response.Task, response.Language
+		}
+		if text := strings.TrimSpace(response.Text); text != "" {
+			texts = append(texts, text)
+		}
+		stitched.Duration += response.Duration
+		for _, segment := range response.Segments {
+			segment.ID = len(stitched.Segments)
+			segment.Start += offset
+			segment.End += offset
+			stitched.Segments = append(stitched.Segments, segment)
+		}
+		for _, word := range response.Words {
+			word.Start += offset
+			word.End += offset
+			stitched.Words = append(stitched.Words, word)
+		}
+		stitched.Logprobs = append(stitched.Logprobs, response.Logprobs...)
+	}
+	stitched.Text = strings.Join(texts, " ")
+	return
+}
@@ -0,0 +1,77 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestTranscribeLargeFile(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		prompts = map[string]string{}
+	)
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		part := strings.TrimSuffix(strings.TrimPrefix(header.Filename, "talk-"), ".wav")
+		mu.Lock()
+		prompts[part] = r.FormValue("prompt")
+		mu.Unlock()
+		fmt.Fprintf(w, `{"text":"Text of %[1]s.","duration":0.375,"language":"english",
+			"segments":[{"id":0,"start":0,"end":0.375,"text":"Text of %[1]s."}],
+			"words":[{"word":"Text","start":0.1,"end":0.2}]}`, part)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	data, name := testWAV(time.Second), "talk.wav"
+	request := AudioRequest{Model: Whisper1, FileBytes: &data, FileName: &name, Format: AudioResponseFormatVerboseJSON}
+
+	res, err := client.TranscribeLargeFile(ctx, request, LargeAudioConfig{MaxChunkBytes: 12044, Concurrency: 3})
+	checks.NoError(t, err, "TranscribeLargeFile error")
+	if res.Text != "Text of part1. Text of part2. Text of part3." || res.Language != "english" || res.Duration != 1.125 {
+		t.Fatalf("Unexpected response: %+v", res)
+	}
+	if len(res.Segments) != 3 || res.Segments[2].ID != 2 || res.Segments[2].Start != 0.75 || res.Segments[2].End != 1.125 {
+		t.Errorf("Unexpected segments: %+v", res.Segments)
+	}
+	if len(res.Words) != 3 || res.Words[1].Start != 0.475 {
+		t.Errorf("Unexpected words: %+v", res.Words)
+	}
+
+	request.Prompt = "Glossary: gopher."
+	_, err = client.TranscribeLargeFile(ctx, request, LargeAudioConfig{MaxChunkBytes: 12044, PromptContinuity: true})
+	checks.NoError(t, err, "TranscribeLargeFile error")
+	if prompts["part1"] != "Glossary: gopher." || prompts["part3"] != "Glossary: gopher. Text of part2." {
+		t.Errorf("Unexpected prompts: %v", prompts)
+	}
+
+	res, err = client.TranscribeLargeFile(ctx, request, LargeAudioConfig{})
+	checks.NoError(t, err, "TranscribeLargeFile error")
+	if res.Text != "Text of talk." {
+		t.Errorf("Small files should be sent as they are, got %q", res.Text)
+	}
+
+	request.Format = AudioResponseFormatSRT
+	_, err = client.TranscribeLargeFile(ctx, request, LargeAudioConfig{})
+	checks.ErrorIs(t, err, ErrAudioResponseFormatNotSupported, "TranscribeLargeFile should reject srt")
+}
@@ -0,0 +1,257 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// AudioUploadLimit is the largest audio file accepted by the audio endpoints.
+const AudioUploadLimit = 25 << 20
+
+var (
+	ErrAudioFileTooLarge      = errors.New("audio file exceeds the upload limit")
+	ErrAudioTooLong           = errors.New("audio duration exceeds the limit of the model")
+	ErrUnsupportedAudioFormat = errors.New("audio format is not supported by the API or does not match the file name")
+)
+
+// audioExtensionFormats maps the file extensions accepted by the audio endpoints to the
+// container ProbeAudio recognizes them by.
+var audioExtensionFormats = map[string]string{
+	".flac": "flac",
+	".m4a":  "mp4",
+	".mp3":  "mp3",
+	".mp4":  "mp4",
+	".mpeg": "mp3",
+	".mpga": "mp3",
+	".oga":  "ogg",
+	".ogg":  "ogg",
+	".wav":  "wav",
+	".webm": "webm",
+}
+
+// audioHeaderSize is the prefix of a file read to recognize its format.
+const audioHeaderSize = 64 << 10
+
+// audioModelMaxDuration lists the models that limit the duration of the audio, not only its size.
+var audioModelMaxDuration = map[string]time.Duration{
+	GPT4oTranscribe:     1500 * time.Second,
+	GPT4oMiniTranscribe: 1500 * time.Second,
+}
+
+// AudioProbe describes an audio file as far as it can be told without decoding it.
+type AudioProbe struct {
+	Size int64
+	// Format is the container recognized from the file header: "wav", "mp3", "ogg", "flac",
+	// "mp4" or "webm", empty for other formats.
+	Format string
+	// Duration is approximate (for MP3 it assumes a constant bit rate) and zero if unknown.
+	Duration time.Duration
+}
+
+// ProbeAudio reads the size, the format and, for WAV, MP3 and OGG files, the approximate
+// duration of data.
+func ProbeAudio(data []byte) (probe AudioProbe) {
+	probe.Size = int64(len(data))
+	switch {
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WAVE":
+		probe.Format = "wav"
+		probe.Duration = wavDuration(data)
+	case len(data) >= 4 && string(data[:4]) == "OggS":
+		probe.Format = "ogg"
+		probe.Duration = oggDuration(data)
+	case len(data) >= 4 && string(data[:4]) == "fLaC":
+		probe.Format = "flac"
+	case len(data) >= 8 && string(data[4:8]) == "ftyp":
+		probe.Format = "mp4"
+	case len(data) >= 4 && string(data[:4]) == "\x1a\x45\xdf\xa3":
+		// The EBML header of Matroska and WebM files.
+		probe.Format = "webm"
+	default:
+		if duration, ok := mp3Duration(data); ok {
+			probe.Format = "mp3"
+			probe.Duration = duration
+		} else if len(data) >= 3 && string(data[:3]) == "ID3" {
+			probe.Format = "mp3"
+		}
+	}
+	return
+}
+
+// validateAudioFormat rejects files whose extension is not accepted by the API, and files
+// whose header ProbeAudio does not recognize or recognizes as another format than the extension.
+func validateAudioFormat(name string, header []byte) error {
+	ext := strings.ToLower(filepath.Ext(name))
+	expected, ok := audioExtensionFormats[ext]
+	if !ok {
+		return fmt.Errorf("%w: %s, use one of flac, m4a, mp3, mp4, mpeg, mpga, oga, ogg, wav or webm",
+			ErrUnsupportedAudioFormat, name)
+	}
+	probe := ProbeAudio(header)
+	if probe.Format == "" {
+		return fmt.Errorf("%w: the header of %s is not a known audio format", ErrUnsupportedAudioFormat, name)
+	}
+	if probe.Format != expected {
+		return fmt.Errorf("%w: %s is named %s but contains %s audio", ErrUnsupportedAudioFormat, name, ext, probe.Format)
+	}
+	return nil
+}
+
+// preflightAudio rejects requests whose file is known to exceed the limits of the API, or to
+// be of a format it does not accept.
+func preflightAudio(request AudioRequest) error {
+	name, data := "", []byte(nil)
+	if request.FilePath != "" {
+		info, err := os.Stat(request.FilePath)
+		if err != nil {
+			return fmt.Errorf("opening audio file: %w", err)
+		}
+		name = filepath.Base(request.FilePath)
+		if info.Size() > AudioUploadLimit {
+			return audioTooLargeError(name, info.Size())
+		}
+		if _, limited := audioModelMaxDuration[request.Model]; !limited {
+			header, err := readAudioHeader(request.FilePath)
+			if err != nil {
+				return err
+			}
+			return validateAudioFormat(name, header)
+		}
+		if data, err = os.ReadFile(request.FilePath); err != nil {
+			return fmt.Errorf("opening audio file: %w", err)
+		}
+	} else if request.FileBytes != nil {
+		if request.FileName == nil || !strings.Contains(*request.FileName, ".") {
+			return errors.New("FileName with correct extension is required while FileBytes is used")
+		}
+		data, name = *request.FileBytes, *request.FileName
+	}
+
+	probe := ProbeAudio(data)
+	if probe.Size > AudioUploadLimit {
+		return audioTooLargeError(name, probe.Size)
+	}
+	if err := validateAudioFormat(name, data); err != nil {
+		return err
+	}
+	if limit, ok := audioModelMaxDuration[request.Model]; ok && probe.Duration > limit {
+		return fmt.Errorf("%w: %s is about %s long, %s accepts at most %s; split the audio into shorter chunks",
+			ErrAudioTooLong, name, probe.Duration.Round(time.Second), request.Model, limit)
+	}
+	return nil
+}
+
+// readAudioHeader reads the first audioHeaderSize bytes of the file at path.
+func readAudioHeader(path string) ([]byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening audio file: %w", err)
+	}
+	defer f.Close()
+
+	header, err := io.ReadAll(io.LimitReader(f, audioHeaderSize))
+	if err != nil {
+		return nil, fmt.Errorf("reading audio file: %w", err)
+	}
+	return header, nil
+}
+
+func audioTooLargeError(name string, size int64) error {
+	return fmt.Errorf("%w: %s is %.1f MiB, at most %d MiB can be uploaded; split the audio into smaller chunks",
+		ErrAudioFileTooLarge, name, float64(size)/(1<<20), AudioUploadLimit>>20)
+}
+
+// wavDuration divides the size of the data chunk by the byte rate of the fmt chunk.
+func wavDuration(data []byte) time.Duration {
+	var byteRate, dataSize uint32
+	for offset := 12; offset+8 <= len(data); {
+		id := string(data[offset : offset+4])
+		size := binary.LittleEndian.Uint32(data[offset+4 : offset+8])
+		body := offset + 8
+		switch {
+		case id == "fmt " && body+12 <= len(data):
+			byteRate = binary.LittleEndian.Uint32(data[body+8 : body+12])
+		case id == "data":
+			dataSize = size
+		}
+		if byteRate != 0 && dataSize != 0 {
+			return time.Duration(float64(dataSize) / float64(byteRate) * float64(time.Second))
+		}
+		// Chunks are padded to an even size.
+		offset = body + int(size) + int(size&1)
+	}
+	return 0
+}
+
+var (
+	mp3BitratesV1 = [16]int{0, 32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 0}
+	mp3BitratesV2 = [16]int{0, 8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160, 0}
+)
+
+// mp3Duration estimates the duration from the bit rate of the first MPEG layer III frame,
+// after skipping an ID3v2 tag.
+func mp3Duration(data []byte) (time.Duration, bool) {
+	offset := 0
+	if len(data) >= 10 && string(data[:3]) == "ID3" {
+		// The tag size is a syncsafe integer: 7 bits per byte.
+		offset = 10 + (int(data[6])<<21 | int(data[7])<<14 | int(data[8])<<7 | int(data[9]))
+	}
+	if offset+4 > len(data) || data[offset] != 0xFF || data[offset+1]&0xE0 != 0xE0 {
+		return 0, false
+	}
+
+	header := data[offset:]
+	version, layer := (header[1]>>3)&3, (header[1]>>1)&3
+	if layer != 1 || version == 1 {
+		return 0, false
+	}
+	bitrates := mp3BitratesV2
+	if version == 3 {
+		bitrates = mp3BitratesV1
+	}
+	bitrate := bitrates[header[2]>>4] * 1000
+	if bitrate == 0 {
+		return 0, false
+	}
+	audioBytes := len(data) - offset
+	return time.Duration(float64(audioBytes) * 8 / float64(bitrate) * float64(time.Second)), true
+}
+
+// oggDuration divides the granule position of the last page by the sample rate
+// of the Vorbis or Opus stream.
+func oggDuration(data []byte) time.Duration {
+	const pageHeaderSize = 27
+	if len(data) < pageHeaderSize+1 {
+		return 0
+	}
+	packet := pageHeaderSize + int(data[26])
+	if packet >= len(data) {
+		return 0
+	}
+
+	var sampleRate, preSkip int64
+	switch body := data[packet:]; {
+	case len(body) >= 16 && strings.HasPrefix(string(body[:7]), "\x01vorbis"):
+		sampleRate = int64(binary.LittleEndian.Uint32(body[12:16]))
+	case len(body) >= 12 && string(body[:8]) == "OpusHead":
+		// Opus granule positions always count 48 kHz samples.
+		sampleRate = 48000
+		preSkip = int64(binary.LittleEndian.Uint16(body[10:12]))
+	}
+	last := bytes.LastIndex(data, []byte("OggS"))
+	if sampleRate == 0 || last+14 > len(data) {
+		return 0
+	}
+
+	granule := int64(binary.LittleEndian.Uint64(data[last+6:last+14])) - preSkip
+	if granule <= 0 {
+		return 0
+	}
+	return time.Duration(float64(granule) / float64(sampleRate) * float64(time.Second))
+}
@@ -0,0 +1,145 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/binary"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testWAV returns a 16 kHz, 16-bit mono WAV file of the given duration.
+func testWAV(duration time.Duration) []byte {
+	const byteRate = 32000
+	dataSize := int(duration.Seconds() * byteRate)
+	data := make([]byte, 44+dataSize)
+	copy(data, "RIFF")
+	binary.LittleEndian.PutUint32(data[4:], uint32(36+dataSize))
+	copy(data[8:], "WAVEfmt ")
+	binary.LittleEndian.PutUint32(data[16:], 16)
+	binary.LittleEndian.PutUint16(data[20:], 1)
+	binary.LittleEndian.PutUint16(data[22:], 1)
+	binary.LittleEndian.PutUint32(data[24:], 16000)
+	binary.LittleEndian.PutUint32(data[28:], byteRate)
+	binary.LittleEndian.PutUint16(data[32:], 2)
+	binary.LittleEndian.PutUint16(data[34:], 16)
+	copy(data[36:], "data")
+	binary.LittleEndian.PutUint32(data[40:], uint32(dataSize))
+	return data
+}
+
+// testOggPage returns an Ogg page with a single segment holding packet.
+func testOggPage(granule uint64, packet []byte) []byte {
+	page := make([]byte, 28)
+	copy(page, "OggS")
+	binary.LittleEndian.PutUint64(page[6:], granule)
+	page[26] = 1
+	page[27] = byte(len(packet))
+	return append(page, packet...)
+}
+
+func TestProbeAudio(t *testing.T) {
+	probe := ProbeAudio(testWAV(2 * time.Second))
+	if probe.Format != "wav" || probe.Duration != 2*time.Second {
+		t.Fatalf("Unexpected WAV probe: %+v", probe)
+	}
+
+	// MPEG-1 layer III, 128 kbit/s: 16000 bytes per second.
+	mp3 := make([]byte, 32000)
+	copy(mp3, []byte{0xFF, 0xFB, 0x90, 0x00})
+	probe = ProbeAudio(mp3)
+	if probe.Format != "mp3" || probe.Duration != 2*time.Second {
+		t.Fatalf("Unexpected MP3 probe: %+v", probe)
+	}
+
+	// Vorbis identification header with a 44.1 kHz sample rate.
+	vorbis := make([]byte, 30)
+	copy(vorbis, "\x01vorbis")
+	binary.LittleEndian.PutUint32(vorbis[12:], 44100)
+	ogg := append(testOggPage(0, vorbis), testOggPage(44100*3, []byte{0})...)
+	probe = ProbeAudio(ogg)
+	if probe.Format != "ogg" || probe.Duration != 3*time.Second {
+		t.Fatalf("Unexpected OGG probe: %+v", probe)
+	}
+
+	probe = ProbeAudio([]byte("not audio"))
+	if probe.Format != "" || probe.Duration != 0 || probe.Size != 9 {
+		t.Fatalf("Unexpected probe: %+v", probe)
+	}
+}
+
+func TestAudioPreflight(t *testing.T) {
+	config := DefaultConfig("whatever")
+	config.BaseURL = "http://localhost/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	name := "long.wav"
+	long := testWAV(1501 * time.Second)
+	_, err := client.CreateTranscription(ctx, AudioRequest{
+		Model:     GPT4oTranscribe,
+		FileBytes: &long,
+		FileName:  &name,
+		Preflight: true,
+	})
+	checks.ErrorIs(t, err, ErrAudioFileTooLarge, "a 48 MB file should be rejected")
+
+	// Only the header is needed to tell the duration, so stay below the size limit.
+	header := testWAV(30 * time.Minute)[:44]
+	_, err = client.CreateTranscription(ctx, AudioRequest{
+		Model:     GPT4oTranscribe,
+		FileBytes: &header,
+		FileName:  &name,
+		Preflight: true,
+	})
+	checks.ErrorIs(t, err, ErrAudioTooLong, "a 30 minute recording should be rejected")
+}
+
+func TestAudioPreflightFormat(t *testing.T) {
+	config := DefaultConfig("whatever")
+	config.BaseURL = "http://localhost/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	wav := testWAV(time.Second)
+	m4a := append([]byte{0, 0, 0, 0x20}, "ftypM4A "...)
+	testcases := []struct {
+		name string
+		data []byte
+	}{
+		{"speech.aiff", wav},
+		{"speech.mp3", wav},
+		{"speech.wav", []byte("not audio")},
+		{"speech.webm", m4a},
+	}
+	for _, tc := range testcases {
+		name, data := tc.name, tc.data
+		_, err := client.CreateTranscription(ctx, AudioRequest{
+			Model:     Whisper1,
+			FileBytes: &data,
+			FileName:  &name,
+			Preflight: true,
+		})
+		checks.ErrorIs(t, err, ErrUnsupportedAudioFormat, name+" should be rejected")
+	}
+
+	path := filepath.Join(t.TempDir(), "speech.mp3")
+	checks.NoError(t, os.WriteFile(path, wav, 0o600), "WriteFile error")
+	_, err := client.CreateTranscription(ctx, AudioRequest{Model: Whisper1, FilePath: path, Preflight: true})
+	checks.ErrorIs(t, err, ErrUnsupportedAudioFormat, "a WAV file named .mp3 should be rejected")
+
+	_, err = client.CreateTranscription(ctx, AudioRequest{Model: Whisper1, FileBytes: &wav, Preflight: true})
+	if err == nil || errors.Is(err, ErrUnsupportedAudioFormat) || !strings.Contains(err.Error(), "FileName") {
+		t.Fatalf("Expected FileBytes without FileName to ask for it, got %v", err)
+	}
+
+	if probe := ProbeAudio(m4a); probe.Format != "mp4" {
+		t.Fatalf("Unexpected M4A probe: %+v", probe)
+	}
+}
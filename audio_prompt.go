@@ -0,0 +1,66 @@
+package openai
+
+import (
+	"strings"
+)
+
+// WhisperPromptTokenLimit is the number of prompt tokens Whisper takes into account.
+// Anything before the last 224 tokens of the prompt is ignored by the model.
+const WhisperPromptTokenLimit = 224
+
+// TranscriptionPrompt builds an AudioRequest.Prompt that helps the model to recognise
+// names and jargon. Glossary terms take priority; the remaining budget is filled with
+// the end of PreviousTranscript, which keeps style and context consistent across chunks.
+type TranscriptionPrompt struct {
+	Glossary           []string
+	PreviousTranscript string
+	// MaxTokens defaults to WhisperPromptTokenLimit.
+	MaxTokens int
+}
+
+// String returns the prompt truncated to fit into MaxTokens.
+func (p TranscriptionPrompt) String() string {
+	budget := p.MaxTokens
+	if budget <= 0 {
+		budget = WhisperPromptTokenLimit
+	}
+
+	var glossary []string
+	for _, term := range p.Glossary {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		candidate := strings.Join(append(glossary, term), ", ") + "."
+		if estimateTokens(candidate) > budget {
+			break
+		}
+		glossary = append(glossary, term)
+	}
+
+	var prefix string
+	if len(glossary) > 0 {
+		prefix = strings.Join(glossary, ", ") + "."
+		budget -= estimateTokens(prefix)
+	}
+
+	tail := lastWordsWithinTokens(p.PreviousTranscript, budget)
+	if prefix == "" {
+		return tail
+	}
+	if tail == "" {
+		return prefix
+	}
+	return prefix + " " + tail
+}
+
+// lastWordsWithinTokens returns the longest suffix of text consisting of whole words
+// that fits into maxTokens.
+func lastWordsWithinTokens(text string, maxTokens int) string {
+	words := strings.Fields(text)
+	start := len(words)
+	for start > 0 && estimateTokens(strings.Join(words[start-1:], " ")) <= maxTokens {
+		start--
+	}
+	return strings.Join(words[start:], " ")
+}
@@ -0,0 +1,50 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+
+	"strings"
+	"testing"
+)
+
+func TestTranscriptionPrompt(t *testing.T) {
+	prompt := TranscriptionPrompt{
+		Glossary:           []string{"Kubernetes", " ", "gRPC", "Aloteq"},
+		PreviousTranscript: "we deployed the new cluster yesterday",
+	}
+	expected := "Kubernetes, gRPC, Aloteq. we deployed the new cluster yesterday"
+	if got := prompt.String(); got != expected {
+		t.Fatalf("Unexpected prompt: %q, expected: %q", got, expected)
+	}
+
+	if got := (TranscriptionPrompt{}).String(); got != "" {
+		t.Fatalf("Empty prompt expected, got %q", got)
+	}
+}
+
+func TestTranscriptionPromptTruncation(t *testing.T) {
+	previous := strings.Repeat("lorem ipsum ", 500) + "final words"
+	prompt := TranscriptionPrompt{
+		Glossary:           []string{"OpenAI"},
+		PreviousTranscript: previous,
+	}
+	got := prompt.String()
+	if !strings.HasPrefix(got, "OpenAI. ") {
+		t.Fatalf("Glossary should come first, got %q", got)
+	}
+	if !strings.HasSuffix(got, "final words") {
+		t.Fatalf("The end of the previous transcript should be kept, got %q", got)
+	}
+	// 224 tokens of ASCII text are at most 896 characters.
+	if len(got) > WhisperPromptTokenLimit*4 {
+		t.Fatalf("Prompt is too long: %d characters", len(got))
+	}
+
+	prompt = TranscriptionPrompt{
+		Glossary:  []string{"first", "second", "third"},
+		MaxTokens: 4,
+	}
+	if got = prompt.String(); got != "first, second." {
+		t.Fatalf("Glossary should be truncated to the budget, got %q", got)
+	}
+}
@@ -0,0 +1,162 @@
+package openai
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+)
+
+var (
+	ErrAudioURLNotSupported = errors.New("audio URL must be an https URL or a data URI")
+	ErrAudioURLInvalid      = errors.New("invalid audio data URI")
+)
+
+// fetchAudioURL replaces the FileURL of request with the bytes and file name of the audio
+// it points to. Remote files are downloaded with ClientConfig.HTTPClient and, like data URIs,
+// may not exceed AudioUploadLimit.
+func (c *Client) fetchAudioURL(ctx context.Context, request *AudioRequest) (err error) {
+	var (
+		data        []byte
+		name        string
+		contentType string
+	)
+	if strings.HasPrefix(request.FileURL, "data:") {
+		data, contentType, err = decodeAudioDataURI(request.FileURL)
+		name = "audio"
+	} else {
+		data, contentType, err = c.downloadAudio(ctx, request.FileURL)
+		if parsed, parseErr := url.Parse(request.FileURL); parseErr == nil {
+			name = path.Base(parsed.Path)
+		}
+	}
+	if err != nil {
+		return
+	}
+
+	if name == "" || name == "." || name == "/" {
+		name = "audio"
+	}
+	if path.Ext(name) == "" {
+		name += audioExtension(contentType)
+	}
+	request.FileURL = ""
+	request.FilePath = ""
+	request.FileBytes = &data
+	request.FileName = &name
+	return
+}
+
+// downloadAudio fetches an https audio URL, failing before the whole body is read when it
+// exceeds AudioUploadLimit.
+func (c *Client) downloadAudio(ctx context.Context, rawURL string) (data []byte, contentType string, err error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || parsed.Scheme != "https" || parsed.Host == "" {
+		err = fmt.Errorf("%w: %q", ErrAudioURLNotSupported, rawURL)
+		return
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return
+	}
+	res, err := c.config.HTTPClient.Do(req)
+	if err != nil {
+		err = fmt.Errorf("downloading audio: %w", err)
+		return
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		err = fmt.Errorf("downloading audio: unexpected status %s", res.Status)
+		return
+	}
+	name := path.Base(parsed.Path)
+	if res.ContentLength > AudioUploadLimit {
+		err = audioTooLargeError(name, res.ContentLength)
+		return
+	}
+	data, err = io.ReadAll(io.LimitReader(res.Body, AudioUploadLimit+1))
+	if err != nil {
+		err = fmt.Errorf("downloading audio: %w", err)
+		return
+	}
+	if len(data) > AudioUploadLimit {
+		err = fmt.Errorf("%w: %s is larger than %d MiB; split the audio into smaller chunks",
+			ErrAudioFileTooLarge, name, AudioUploadLimit>>20)
+		return
+	}
+	contentType = res.Header.Get("Content-Type")
+	return
+}
+
+// decodeAudioDataURI decodes a data URI of the form data:[<media type>][;base64],<data>.
+func decodeAudioDataURI(uri string) (data []byte, contentType string, err error) {
+	header, payload, found := strings.Cut(strings.TrimPrefix(uri, "data:"), ",")
+	if !found {
+		err = fmt.Errorf("%w: missing comma", ErrAudioURLInvalid)
+		return
+	}
+
+	contentType, isBase64 := header, false
+	if strings.HasSuffix(header, ";base64") {
+		contentType, isBase64 = strings.TrimSuffix(header, ";base64"), true
+	}
+
+	if isBase64 {
+		if base64.StdEncoding.DecodedLen(len(payload)) > AudioUploadLimit+2 {
+			err = audioTooLargeError("audio", int64(base64.StdEncoding.DecodedLen(len(payload))))
+			return
+		}
+		data, err = base64.StdEncoding.DecodeString(payload)
+	} else {
+		var unescaped string
+		unescaped, err = url.PathUnescape(payload)
+		data = []byte(unescaped)
+	}
+	if err != nil {
+		err = fmt.Errorf("%w: %s", ErrAudioURLInvalid, err.Error())
+		return
+	}
+	if len(data) > AudioUploadLimit {
+		err = audioTooLargeError("audio", int64(len(data)))
+	}
+	return
+}
+
+// audioContentTypeExtensions maps the media types of the audio formats accepted by the API
+// that mime.ExtensionsByType does not reliably know.
+var audioContentTypeExtensions = map[string]string{
+	"audio/mpeg":  ".mp3",
+	"audio/mp3":   ".mp3",
+	"audio/mp4":   ".m4a",
+	"audio/x-m4a": ".m4a",
+	"audio/wav":   ".wav",
+	"audio/x-wav": ".wav",
+	"audio/wave":  ".wav",
+	"audio/ogg":   ".ogg",
+	"audio/webm":  ".webm",
+	"audio/flac":  ".flac",
+	"video/mp4":   ".mp4",
+}
+
+// audioExtension returns the file extension the API needs to recognize audio of contentType.
+func audioExtension(contentType string) string {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ".mp3"
+	}
+	if ext, ok := audioContentTypeExtensions[mediaType]; ok {
+		return ext
+	}
+	if exts, _ := mime.ExtensionsByType(mediaType); len(exts) > 0 {
+		return exts[0]
+	}
+	return ".mp3"
+}
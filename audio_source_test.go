@@ -0,0 +1,94 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// newAudioURLTestClient returns a client whose transcription endpoint replies with the name
+// and contents of the uploaded file.
+func newAudioURLTestClient(t *testing.T, httpClient *http.Client) *Client {
+	t.Helper()
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		_, _ = w.Write([]byte(`{"text":` + strconv.Quote(header.Filename+":"+string(data)) + `}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	t.Cleanup(ts.Close)
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	if httpClient != nil {
+		config.HTTPClient = httpClient
+	}
+	return NewClientWithConfig(config)
+}
+
+func TestAudioFromDataURI(t *testing.T) {
+	client := newAudioURLTestClient(t, nil)
+	ctx := context.Background()
+
+	uri := "data:audio/wav;base64," + base64.StdEncoding.EncodeToString([]byte("RIFF"))
+	res, err := client.CreateTranscription(ctx, AudioRequest{Model: Whisper1, FileURL: uri})
+	checks.NoError(t, err, "CreateTranscription error")
+	if res.Text != "audio.wav:RIFF" {
+		t.Fatalf("Unexpected upload: %q", res.Text)
+	}
+
+	_, err = client.CreateTranscription(ctx, AudioRequest{Model: Whisper1, FileURL: "data:audio/wav;base64"})
+	checks.ErrorIs(t, err, ErrAudioURLInvalid, "CreateTranscription should reject malformed data URIs")
+
+	_, err = client.CreateTranscription(ctx, AudioRequest{Model: Whisper1, FileURL: "http://example.com/a.mp3"})
+	checks.ErrorIs(t, err, ErrAudioURLNotSupported, "CreateTranscription should reject plain http URLs")
+}
+
+func TestAudioFromRemoteURL(t *testing.T) {
+	remote := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/recording":
+			w.Header().Set("Content-Type", "audio/mpeg")
+			_, _ = w.Write([]byte("ID3"))
+		case "/huge.mp3":
+			w.Header().Set("Content-Length", strconv.Itoa(AudioUploadLimit+1))
+			_, _ = w.Write([]byte(strings.Repeat("x", 1024)))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer remote.Close()
+
+	// The remote server is only trusted by its own client, so the download must use the
+	// configured HTTP client.
+	client := newAudioURLTestClient(t, remote.Client())
+	ctx := context.Background()
+
+	res, err := client.CreateTranscription(ctx, AudioRequest{Model: Whisper1, FileURL: remote.URL + "/recording"})
+	checks.NoError(t, err, "CreateTranscription error")
+	if res.Text != "recording.mp3:ID3" {
+		t.Fatalf("Unexpected upload: %q", res.Text)
+	}
+
+	_, err = client.CreateTranscription(ctx, AudioRequest{Model: Whisper1, FileURL: remote.URL + "/huge.mp3"})
+	checks.ErrorIs(t, err, ErrAudioFileTooLarge, "CreateTranscription should reject files over the upload limit")
+
+	_, err = client.CreateTranscription(ctx, AudioRequest{Model: Whisper1, FileURL: remote.URL + "/missing.mp3"})
+	checks.HasError(t, err, "CreateTranscription should fail when the download fails")
+}
@@ -0,0 +1,182 @@
+package openai
+
+import (
+	"encoding/binary"
+	"errors"
+	"time"
+)
+
+var (
+	ErrAudioSplitNotSupported = errors.New("audio can only be split into chunks for WAV and MP3 files")
+)
+
+// AudioChunk is a self-contained piece of a split audio file, starting Offset into the original.
+type AudioChunk struct {
+	Data     []byte
+	Offset   time.Duration
+	Duration time.Duration
+}
+
+// SplitAudio splits a WAV or MP3 file into files of at most maxBytes bytes and, when
+// maxDuration is greater than zero, maxDuration long. WAV files are split at sample frames
+// and MP3 files at MPEG frames, so no chunk needs to be decoded or re-encoded; ID3 tags are
+// dropped. Other formats fail with ErrAudioSplitNotSupported.
+func SplitAudio(data []byte, maxBytes int, maxDuration time.Duration) ([]AudioChunk, error) {
+	switch {
+	case len(data) >= 12 && string(data[:4]) == "RIFF" && string(data[8:12]) == "WAVE":
+		return splitWAV(data, maxBytes, maxDuration)
+	default:
+		chunks, ok := splitMP3(data, maxBytes, maxDuration)
+		if !ok {
+			return nil, ErrAudioSplitNotSupported
+		}
+		return chunks, nil
+	}
+}
+
+const wavHeaderSize = 12
+
+// splitWAV copies the fmt chunk of data into every chunk, followed by a run of whole sample
+// frames of its data chunk.
+func splitWAV(data []byte, maxBytes int, maxDuration time.Duration) ([]AudioChunk, error) {
+	var format, samples []byte
+	for offset := wavHeaderSize; offset+8 <= len(data); {
+		id := string(data[offset : offset+4])
+		size := int(binary.LittleEndian.Uint32(data[offset+4 : offset+8]))
+		body := offset + 8
+		end := body + size
+		if end > len(data) {
+			end = len(data)
+		}
+		switch id {
+		case "fmt ":
+			format = data[offset:end]
+		case "data":
+			samples = data[body:end]
+		}
+		// Chunks are padded to an even size.
+		offset = body + size + size&1
+	}
+	if len(format) < 8+16 || samples == nil {
+		return nil, ErrAudioSplitNotSupported
+	}
+
+	byteRate := int(binary.LittleEndian.Uint32(format[8+8 : 8+12]))
+	blockAlign := int(binary.LittleEndian.Uint16(format[8+12 : 8+14]))
+	if byteRate == 0 || blockAlign == 0 {
+		return nil, ErrAudioSplitNotSupported
+	}
+
+	perChunk := maxBytes - wavHeaderSize - len(format) - 8
+	if maxDuration > 0 {
+		if byDuration := int(int64(byteRate) * int64(maxDuration) / int64(time.Second)); byDuration < perChunk {
+			perChunk = byDuration
+		}
+	}
+	perChunk -= perChunk % blockAlign
+	if perChunk <= 0 {
+		return nil, ErrAudioSplitNotSupported
+	}
+
+	var chunks []AudioChunk
+	for start := 0; start < len(samples); start += perChunk {
+		end := start + perChunk
+		if end > len(samples) {
+			end = len(samples)
+		}
+		chunks = append(chunks, AudioChunk{
+			Data:     wavFile(format, samples[start:end]),
+			Offset:   bytesDuration(start, byteRate),
+			Duration: bytesDuration(end-start, byteRate),
+		})
+	}
+	return chunks, nil
+}
+
+// wavFile builds a WAV file from a complete fmt chunk and the samples of its data chunk.
+func wavFile(format, samples []byte) []byte {
+	file := make([]byte, 0, wavHeaderSize+len(format)+8+len(samples)+1)
+	file = append(file, "RIFF\x00\x00\x00\x00WAVE"...)
+	file = append(file, format...)
+	if len(format)&1 == 1 {
+		file = append(file, 0)
+	}
+	file = append(file, "data\x00\x00\x00\x00"...)
+	binary.LittleEndian.PutUint32(file[len(file)-4:], uint32(len(samples)))
+	file = append(file, samples...)
+	if len(samples)&1 == 1 {
+		file = append(file, 0)
+	}
+	binary.LittleEndian.PutUint32(file[4:8], uint32(len(file)-8))
+	return file
+}
+
+func bytesDuration(n, byteRate int) time.Duration {
+	return time.Duration(int64(n) * int64(time.Second) / int64(byteRate))
+}
+
+var mp3SampleRates = map[byte][3]int{
+	3: {44100, 48000, 32000}, // MPEG 1
+	2: {22050, 24000, 16000}, // MPEG 2
+	0: {11025, 12000, 8000},  // MPEG 2.5
+}
+
+// mp3Frame returns the size and duration of the MPEG layer III frame at the start of data.
+func mp3Frame(data []byte) (size int, duration time.Duration, ok bool) {
+	if len(data) < 4 || data[0] != 0xFF || data[1]&0xE0 != 0xE0 {
+		return 0, 0, false
+	}
+	version, layer := (data[1]>>3)&3, (data[1]>>1)&3
+	rates, known := mp3SampleRates[version]
+	rateIndex := (data[2] >> 2) & 3
+	if layer != 1 || !known || rateIndex == 3 {
+		return 0, 0, false
+	}
+	bitrates, coefficient, samples := mp3BitratesV2, 72, 576
+	if version == 3 {
+		bitrates, coefficient, samples = mp3BitratesV1, 144, 1152
+	}
+	bitrate := bitrates[data[2]>>4] * 1000
+	if bitrate == 0 {
+		return 0, 0, false
+	}
+	sampleRate := rates[rateIndex]
+	size = coefficient*bitrate/sampleRate + int((data[2]>>1)&1)
+	duration = time.Duration(int64(samples) * int64(time.Second) / int64(sampleRate))
+	return size, duration, true
+}
+
+// splitMP3 walks the frames of data, after skipping an ID3v2 tag, and packs them into
+// chunks. Frame durations are summed, so variable bit rate files are timed exactly.
+func splitMP3(data []byte, maxBytes int, maxDuration time.Duration) (chunks []AudioChunk, ok bool) {
+	offset := 0
+	if len(data) >= 10 && string(data[:3]) == "ID3" {
+		offset = 10 + (int(data[6])<<21 | int(data[7])<<14 | int(data[8])<<7 | int(data[9]))
+	}
+
+	start, elapsed := offset, time.Duration(0)
+	current := AudioChunk{}
+	for offset < len(data) {
+		size, duration, isFrame := mp3Frame(data[offset:])
+		if !isFrame || offset+size > len(data) {
+			// Trailing ID3v1 tags and truncated frames end the audio.
+			break
+		}
+		if offset > start && (offset+size-start > maxBytes || maxDuration > 0 && current.Duration+duration > maxDuration) {
+			current.Data = data[start:offset]
+			chunks = append(chunks, current)
+			start, current = offset, AudioChunk{Offset: elapsed}
+		}
+		current.Duration += duration
+		elapsed += duration
+		offset += size
+	}
+	if offset == start && len(chunks) == 0 {
+		return nil, false
+	}
+	if offset > start {
+		current.Data = data[start:offset]
+		chunks = append(chunks, current)
+	}
+	return chunks, true
+}
@@ -0,0 +1,65 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"testing"
+	"time"
+)
+
+// testMP3 returns an MP3 file of n 128 kbit/s 44.1 kHz frames of 417 bytes, after an ID3 tag.
+func testMP3(n int) []byte {
+	data := []byte("ID3\x04\x00\x00\x00\x00\x00\x02\x00\x00")
+	frame := append([]byte{0xFF, 0xFB, 0x90, 0x00}, make([]byte, 413)...)
+	for i := 0; i < n; i++ {
+		data = append(data, frame...)
+	}
+	return append(data, "TAG"...)
+}
+
+func TestSplitWAV(t *testing.T) {
+	chunks, err := SplitAudio(testWAV(time.Second), 12044, 0)
+	checks.NoError(t, err, "SplitAudio error")
+	if len(chunks) != 3 {
+		t.Fatalf("Expected 3 chunks, got %d", len(chunks))
+	}
+	var total time.Duration
+	for i, chunk := range chunks {
+		if len(chunk.Data) > 12044 || chunk.Offset != total {
+			t.Errorf("Chunk %d: %d bytes at %s", i, len(chunk.Data), chunk.Offset)
+		}
+		// Every chunk is a valid WAV file of its own.
+		if probe := ProbeAudio(chunk.Data); probe.Format != "wav" || probe.Duration != chunk.Duration {
+			t.Errorf("Chunk %d: probed %+v, expected %s", i, probe, chunk.Duration)
+		}
+		total += chunk.Duration
+	}
+	if total != time.Second {
+		t.Errorf("Expected chunks of one second in total, got %s", total)
+	}
+
+	chunks, err = SplitAudio(testWAV(time.Second), 1<<20, 300*time.Millisecond)
+	checks.NoError(t, err, "SplitAudio error")
+	if len(chunks) != 4 || chunks[0].Duration != 300*time.Millisecond || chunks[3].Duration != 100*time.Millisecond {
+		t.Errorf("Unexpected chunks by duration: %d", len(chunks))
+	}
+}
+
+func TestSplitMP3(t *testing.T) {
+	chunks, err := SplitAudio(testMP3(10), 1000, 0)
+	checks.NoError(t, err, "SplitAudio error")
+	if len(chunks) != 5 {
+		t.Fatalf("Expected 5 chunks, got %d", len(chunks))
+	}
+	frame := 1152 * time.Second / 44100
+	for i, chunk := range chunks {
+		if len(chunk.Data) != 2*417 || chunk.Data[0] != 0xFF || chunk.Offset != time.Duration(2*i)*frame ||
+			chunk.Duration != 2*frame {
+			t.Errorf("Chunk %d: %d bytes at %s for %s", i, len(chunk.Data), chunk.Offset, chunk.Duration)
+		}
+	}
+
+	_, err = SplitAudio([]byte("OggS\x00\x02"), 1000, 0)
+	checks.ErrorIs(t, err, ErrAudioSplitNotSupported, "SplitAudio should reject other formats")
+}
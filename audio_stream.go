@@ -0,0 +1,103 @@
+package openai
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+)
+
+var (
+	ErrAudioStreamNotSupported = errors.New("streaming transcriptions are not supported by whisper-1")
+)
+
+// TranscriptionStreamEventType is the type of a TranscriptionStreamEvent.
+type TranscriptionStreamEventType string
+
+const (
+	// TranscriptionStreamEventDelta carries the next piece of the transcript in Delta.
+	TranscriptionStreamEventDelta TranscriptionStreamEventType = "transcript.text.delta"
+	// TranscriptionStreamEventDone is the last event, carrying the complete transcript in Text.
+	TranscriptionStreamEventDone TranscriptionStreamEventType = "transcript.text.done"
+)
+
+// TranscriptionStreamEvent is an event of a TranscriptionStream.
+type TranscriptionStreamEvent struct {
+	Type  TranscriptionStreamEventType `json:"type"`
+	Delta string                       `json:"delta,omitempty"`
+	Text  string                       `json:"text,omitempty"`
+	// Logprobs is only set when the request includes TranscriptionIncludeLogprobs.
+	Logprobs []AudioLogprob `json:"logprobs,omitempty"`
+	// Usage is only set on TranscriptionStreamEventDone.
+	Usage *TranscriptionUsage `json:"usage,omitempty"`
+}
+
+// TranscriptionUsage is the token usage of a transcription.
+type TranscriptionUsage struct {
+	Type         string `json:"type"`
+	InputTokens  int    `json:"input_tokens"`
+	OutputTokens int    `json:"output_tokens"`
+	TotalTokens  int    `json:"total_tokens"`
+	// Seconds is the billed audio duration of usage of the "duration" type.
+	Seconds float64 `json:"seconds,omitempty"`
+}
+
+type TranscriptionStream struct {
+	*streamReader[TranscriptionStreamEvent]
+}
+
+// CreateTranscriptionStream — API call to create a transcription w/ streaming support.
+// The transcript is sent as TranscriptionStreamEventDelta events while the audio is being
+// transcribed, followed by a TranscriptionStreamEventDone event, after which Recv returns io.EOF.
+func (c *Client) CreateTranscriptionStream(
+	ctx context.Context,
+	request AudioRequest,
+	opts ...RequestOption,
+) (stream *TranscriptionStream, err error) {
+	ctx = withCallOptions(ctx, opts)
+	if request.Model == Whisper1 {
+		err = ErrAudioStreamNotSupported
+		return
+	}
+
+	request.stream = true
+	ctx, cancel := callContext(ctx)
+	req, err := c.newAudioAPIRequest(ctx, request, "transcriptions")
+	if err != nil {
+		cancel()
+		return
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	req.Header.Set("Connection", "keep-alive")
+	c.setCommonHeaders(req)
+
+	req, finishCall := c.startCall(req, true)
+	resp, err := c.do(req) //nolint:bodyclose // body is closed in stream.Close()
+	if err != nil {
+		cancel()
+		finishCall(err, nil)
+		return
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		err = c.handleErrorResp(resp)
+		cancel()
+		finishCall(err, nil)
+		return nil, err
+	}
+
+	stream = &TranscriptionStream{
+		streamReader: &streamReader[TranscriptionStreamEvent]{
+			emptyMessagesLimit: c.config.EmptyMessagesLimit,
+			reader:             bufio.NewReader(resp.Body),
+			response:           resp,
+			errAccumulator:     newErrorAccumulator(),
+			unmarshaler:        c.unmarshaler(),
+			onEvent:            c.streamEventHook(req),
+			watch:              c.newStreamWatch(req),
+			finishCall:         finishCall,
+			cancel:             cancel,
+		},
+	}
+	return
+}
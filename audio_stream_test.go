@@ -0,0 +1,70 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCreateTranscriptionStream(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil || r.FormValue("stream") != "true" {
+			http.Error(w, "expected a streaming request", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte(`data: {"type":"transcript.text.delta","delta":"Hello"}` + "\n\n" +
+			`data: {"type":"transcript.text.delta","delta":" there."}` + "\n\n" +
+			`data: {"type":"transcript.text.done","text":"Hello there.",` +
+			`"usage":{"type":"tokens","input_tokens":14,"output_tokens":4,"total_tokens":18}}` + "\n\n"))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	data, name := []byte("ID3"), "hello.mp3"
+	stream, err := client.CreateTranscriptionStream(ctx, AudioRequest{
+		Model:     GPT4oMiniTranscribe,
+		FileBytes: &data,
+		FileName:  &name,
+	})
+	checks.NoError(t, err, "CreateTranscriptionStream error")
+	defer stream.Close()
+
+	var transcript string
+	var done TranscriptionStreamEvent
+	for {
+		event, recvErr := stream.Recv()
+		if errors.Is(recvErr, io.EOF) {
+			break
+		}
+		checks.NoError(t, recvErr, "Recv error")
+		switch event.Type {
+		case TranscriptionStreamEventDelta:
+			transcript += event.Delta
+		case TranscriptionStreamEventDone:
+			done = event
+		}
+	}
+	if transcript != "Hello there." || done.Text != transcript {
+		t.Fatalf("Unexpected transcript %q, done event %+v", transcript, done)
+	}
+	if done.Usage == nil || done.Usage.TotalTokens != 18 {
+		t.Errorf("Unexpected usage: %+v", done.Usage)
+	}
+
+	_, err = client.CreateTranscriptionStream(ctx, AudioRequest{Model: Whisper1, FileBytes: &data, FileName: &name})
+	checks.ErrorIs(t, err, ErrAudioStreamNotSupported, "whisper-1 should not support streaming")
+}
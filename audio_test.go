@@ -11,6 +11,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
 
 	"github.com/sashabaranov/go-openai/internal/test"
 	"github.com/sashabaranov/go-openai/internal/test/checks"
@@ -36,7 +37,7 @@ func TestAudio(t *testing.T) {
 
 	testcases := []struct {
 		name     string
-		createFn func(context.Context, AudioRequest) (AudioResponse, error)
+		createFn func(context.Context, AudioRequest, ...RequestOption) (AudioResponse, error)
 	}{
 		{
 			"transcribe",
@@ -84,7 +85,7 @@ func TestAudioWithOptionalArgs(t *testing.T) {
 
 	testcases := []struct {
 		name     string
-		createFn func(context.Context, AudioRequest) (AudioResponse, error)
+		createFn func(context.Context, AudioRequest, ...RequestOption) (AudioResponse, error)
 	}{
 		{
 			"transcribe",
@@ -213,3 +214,499 @@ func TestAudioWithFailingFormBuilder(t *testing.T) {
 		checks.ErrorIs(t, err, mockFailedErr, "audioMultipartForm should return error if form builder fails")
 	}
 }
+
+func TestAudioResponseFormatValidation(t *testing.T) {
+	config := DefaultConfig("whatever")
+	config.BaseURL = "http://localhost/v1"
+	client := NewClientWithConfig(config)
+
+	_, err := client.CreateTranscription(context.Background(), AudioRequest{
+		Model:  GPT4oTranscribe,
+		Format: AudioResponseFormatSRT,
+	})
+	checks.ErrorIs(t, err, ErrAudioResponseFormatNotSupported, "gpt-4o-transcribe should not support srt")
+
+	for _, req := range []AudioRequest{
+		{Model: Whisper1, Format: AudioResponseFormatVTT},
+		{Model: GPT4oMiniTranscribe, Format: AudioResponseFormatJSON},
+		{Model: "whisper-large-v3", Format: AudioResponseFormatSRT},
+	} {
+		checks.NoError(t, validateAudioResponseFormat(req.Model, req.Format), "format should be accepted")
+	}
+}
+
+func TestAudioBodyIsResentOnRedirect(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/v1/audio/transcriptions/moved", http.StatusTemporaryRedirect)
+	})
+	server.RegisterHandler("/v1/audio/transcriptions/moved", handleAudioEndpoint)
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	// Go does not forward the Authorization header on redirects by default.
+	config.HTTPClient.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+		req.Header.Set("Authorization", via[0].Header.Get("Authorization"))
+		return nil
+	}
+	client := NewClientWithConfig(config)
+
+	dir, cleanup := test.CreateTestDirectory(t)
+	defer cleanup()
+	path := filepath.Join(dir, "fake.mp3")
+	test.CreateTestFile(t, path)
+
+	_, err := client.CreateTranscription(context.Background(), AudioRequest{FilePath: path, Model: Whisper1})
+	checks.NoError(t, err, "redirected audio request should resend the file")
+}
+
+func TestAudioVerboseJSON(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil ||
+			r.FormValue("response_format") != "verbose_json" ||
+			strings.Join(r.MultipartForm.Value["timestamp_granularities[]"], ",") != "word,segment" {
+			http.Error(w, "unexpected form", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"task":"transcribe","language":"english","duration":1.5,"text":"Hello there.",
+			"segments":[{"id":0,"seek":0,"start":0,"end":1.5,"text":"Hello there.","tokens":[50364,2425],
+				"temperature":0,"avg_logprob":-0.25,"compression_ratio":0.8,"no_speech_prob":0.01}],
+			"words":[{"word":"Hello","start":0,"end":0.6},{"word":"there","start":0.7,"end":1.4}]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	data, name := []byte("ID3"), "hello.mp3"
+	res, err := client.CreateTranscription(context.Background(), AudioRequest{
+		Model:     Whisper1,
+		FileBytes: &data,
+		FileName:  &name,
+		Format:    AudioResponseFormatVerboseJSON,
+		TimestampGranularities: []TranscriptionTimestampGranularity{
+			TranscriptionTimestampGranularityWord,
+			TranscriptionTimestampGranularitySegment,
+		},
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if res.Text != "Hello there." || res.Language != "english" || res.Duration != 1.5 || res.Task != "transcribe" {
+		t.Errorf("Unexpected response: %+v", res)
+	}
+	if len(res.Segments) != 1 || res.Segments[0].AvgLogprob != -0.25 || res.Segments[0].NoSpeechProb != 0.01 ||
+		len(res.Segments[0].Tokens) != 2 {
+		t.Errorf("Unexpected segments: %+v", res.Segments)
+	}
+	if len(res.Words) != 2 || res.Words[1] != (AudioWord{Word: "there", Start: 0.7, End: 1.4}) {
+		t.Errorf("Unexpected words: %+v", res.Words)
+	}
+}
+
+func TestAudioTextMetadata(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			http.Error(w, "unexpected form", http.StatusBadRequest)
+			return
+		}
+		switch r.FormValue("model") + " " + r.FormValue("response_format") {
+		case Whisper1 + " verbose_json":
+			fmt.Fprint(w, `{"task":"transcribe","language":"english","duration":1.5,"text":"Hello there."}`)
+		case GPT4oTranscribe + " json":
+			fmt.Fprint(w, `{"text":"Hello there.","usage":{"type":"duration","seconds":2}}`)
+		default:
+			http.Error(w, "unexpected format", http.StatusBadRequest)
+		}
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	data, name := []byte("ID3"), "hello.mp3"
+	for model, want := range map[string]AudioResponse{
+		Whisper1:        {Text: "Hello there.", Language: "english", Duration: 1.5},
+		GPT4oTranscribe: {Text: "Hello there.", Duration: 2},
+	} {
+		res, err := client.CreateTranscription(context.Background(), AudioRequest{
+			Model:        model,
+			FileBytes:    &data,
+			FileName:     &name,
+			Format:       AudioResponseFormatText,
+			TextMetadata: true,
+		})
+		checks.NoError(t, err, "CreateTranscription error")
+		if res.Text != want.Text || res.Language != want.Language || res.Duration != want.Duration {
+			t.Errorf("Unexpected %s response: %+v", model, res)
+		}
+	}
+}
+
+func TestAudioTimestampGranularitiesRequireVerboseJSON(t *testing.T) {
+	client := NewClient(test.GetTestToken())
+	data, name := []byte("ID3"), "hello.mp3"
+	_, err := client.CreateTranscription(context.Background(), AudioRequest{
+		Model:                  Whisper1,
+		FileBytes:              &data,
+		FileName:               &name,
+		TimestampGranularities: []TranscriptionTimestampGranularity{TranscriptionTimestampGranularityWord},
+	})
+	checks.ErrorIs(t, err, ErrAudioTimestampGranularities, "granularities should require verbose_json")
+}
+
+type failingReader struct{ err error }
+
+func (r failingReader) Read([]byte) (int, error) { return 0, r.err }
+
+func TestAudioFromReader(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		file, header, err := r.FormFile("file")
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		defer file.Close()
+		data, _ := io.ReadAll(file)
+		fmt.Fprintf(w, `{"text":%q}`, header.Filename+":"+string(data)+":"+r.FormValue("model"))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	// A pipe is not seekable and has no length, like a network stream.
+	pr, pw := io.Pipe()
+	go func() {
+		_, _ = pw.Write([]byte("chunk1-"))
+		_, _ = pw.Write([]byte("chunk2"))
+		pw.Close()
+	}()
+	name := "stream.ogg"
+	res, err := client.CreateTranscription(ctx, AudioRequest{Model: Whisper1, Reader: pr, FileName: &name})
+	checks.NoError(t, err, "CreateTranscription error")
+	if res.Text != "stream.ogg:chunk1-chunk2:whisper-1" {
+		t.Fatalf("Unexpected upload: %q", res.Text)
+	}
+
+	readErr := errors.New("connection reset")
+	request := AudioRequest{Model: Whisper1, Reader: failingReader{readErr}, FileName: &name}
+	_, err = client.CreateTranscription(ctx, request)
+	checks.ErrorIs(t, err, readErr, "CreateTranscription should fail with the error of the reader")
+
+	_, err = client.CreateTranscription(ctx, AudioRequest{Model: Whisper1, Reader: strings.NewReader("x")})
+	checks.HasError(t, err, "CreateTranscription should require FileName with Reader")
+
+	request = AudioRequest{Model: Whisper1, Reader: strings.NewReader("x"), FileName: &name, Preflight: true}
+	_, err = client.CreateTranscription(ctx, request)
+	checks.ErrorIs(t, err, ErrAudioPreflightReader, "CreateTranscription should reject Preflight with Reader")
+}
+
+func TestAudioIncludeLogprobs(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil || r.FormValue("include[]") != "logprobs" {
+			http.Error(w, "unexpected form", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"text":"Hi","logprobs":[{"token":"Hi","logprob":-0.01,"bytes":[72,105]}]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	data, name := []byte("ID3"), "hello.mp3"
+	request := AudioRequest{
+		Model:     GPT4oMiniTranscribe,
+		FileBytes: &data,
+		FileName:  &name,
+		Include:   []TranscriptionInclude{TranscriptionIncludeLogprobs},
+	}
+	res, err := client.CreateTranscription(ctx, request)
+	checks.NoError(t, err, "CreateTranscription error")
+	if len(res.Logprobs) != 1 || res.Logprobs[0].Token != "Hi" || res.Logprobs[0].Logprob != -0.01 ||
+		len(res.Logprobs[0].Bytes) != 2 {
+		t.Fatalf("Unexpected logprobs: %+v", res.Logprobs)
+	}
+
+	request.Model = Whisper1
+	_, err = client.CreateTranscription(ctx, request)
+	checks.ErrorIs(t, err, ErrAudioIncludeNotSupported, "whisper-1 should not support logprobs")
+
+	request.Model = GPT4oTranscribe
+	request.Format = AudioResponseFormatText
+	_, err = client.CreateTranscription(ctx, request)
+	checks.ErrorIs(t, err, ErrAudioIncludeNotSupported, "logprobs should require the json format")
+}
+
+func TestAudioDiarization(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil ||
+			r.FormValue("diarize") != "true" || r.FormValue("num_speakers") != "2" {
+			http.Error(w, "unexpected form", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"text":"Hi. Hello.","segments":[
+			{"id":0,"start":0,"end":1,"text":"Hi.","speaker":"A"},
+			{"id":1,"start":1,"end":2,"text":"Hello.","speaker":"B"}]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	data, name := []byte("ID3"), "meeting.mp3"
+	res, err := client.CreateTranscription(context.Background(), AudioRequest{
+		Model:       "diarizing-model",
+		FileBytes:   &data,
+		FileName:    &name,
+		Format:      AudioResponseFormatVerboseJSON,
+		Diarize:     true,
+		NumSpeakers: 2,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if len(res.Segments) != 2 || res.Segments[0].Speaker != "A" || res.Segments[1].Speaker != "B" {
+		t.Fatalf("Unexpected segments: %+v", res.Segments)
+	}
+}
+
+func TestAudioExtraFields(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil ||
+			r.FormValue("vad_filter") != "true" || r.FormValue("word_timestamps") != "false" {
+			http.Error(w, "unexpected form", http.StatusBadRequest)
+			return
+		}
+		fmt.Fprint(w, `{"text":"Hello."}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	data, name := []byte("ID3"), "hello.mp3"
+	res, err := client.CreateTranscription(context.Background(), AudioRequest{
+		Model:       "faster-whisper",
+		FileBytes:   &data,
+		FileName:    &name,
+		ExtraFields: map[string]string{"vad_filter": "true", "word_timestamps": "false"},
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if res.Text != "Hello." {
+		t.Fatalf("Unexpected response: %+v", res)
+	}
+}
+
+func TestAudioUploadProgress(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		fmt.Fprint(w, `{"text":"Hello."}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	var sent, total int64
+	data, name := bytes.Repeat([]byte{0}, 256<<10), "long.mp3"
+	_, err := client.CreateTranscription(context.Background(), AudioRequest{
+		Model:     Whisper1,
+		FileBytes: &data,
+		FileName:  &name,
+		OnProgress: func(bytesSent, size int64) {
+			if bytesSent < sent {
+				t.Errorf("Progress went back from %d to %d", sent, bytesSent)
+			}
+			sent, total = bytesSent, size
+		},
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if total <= int64(len(data)) || sent != total {
+		t.Fatalf("Expected the whole body of %d bytes to be reported, got %d", total, sent)
+	}
+
+	sent, total = 0, 0
+	_, err = client.CreateTranscription(context.Background(), AudioRequest{
+		Model:      Whisper1,
+		Reader:     bytes.NewReader(data),
+		FileName:   &name,
+		OnProgress: func(bytesSent, size int64) { sent, total = bytesSent, size },
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if total != -1 || sent <= int64(len(data)) {
+		t.Fatalf("Expected an unknown total and the whole body to be reported, got %d of %d", sent, total)
+	}
+}
+
+func TestAudioTextResponse(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-request-id", "req_1")
+		if r.FormValue("model") == "json-only" {
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, `{"text":"Hello."}`)
+			return
+		}
+		w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		fmt.Fprint(w, "Hello.\n")
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	data, name := []byte("ID3"), "hello.mp3"
+	for model, want := range map[string]string{Whisper1: "Hello.\n", "json-only": "Hello."} {
+		request := AudioRequest{Model: model, FileBytes: &data, FileName: &name, Format: AudioResponseFormatText}
+		if request.HasJSONResponse() || !request.HasTextResponse() {
+			t.Fatalf("Expected %q to be a text format", request.Format)
+		}
+		res, err := client.CreateTranscription(context.Background(), request)
+		checks.NoError(t, err, "CreateTranscription error")
+		if res.Text != want || res.RequestID != "req_1" {
+			t.Errorf("Unexpected %s response: %+v", model, res)
+		}
+	}
+}
+
+func TestTranslationResponseFormats(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/translations", func(w http.ResponseWriter, r *http.Request) {
+		switch AudioResponseFormat(r.FormValue("response_format")) {
+		case AudioResponseFormatVerboseJSON:
+			fmt.Fprint(w, `{"task":"translate","language":"german","duration":1,"text":"Hello.",
+				"segments":[{"id":0,"start":0,"end":1,"text":"Hello."}]}`)
+		case AudioResponseFormatSRT:
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, "1\n00:00:00,000 --> 00:00:01,000\nHello.\n\n")
+		case AudioResponseFormatVTT:
+			w.Header().Set("Content-Type", "text/plain")
+			fmt.Fprint(w, "WEBVTT\n\n00:00:00.000 --> 00:00:01.000\nHello.\n\n")
+		default:
+			fmt.Fprint(w, `{"text":"Hello."}`)
+		}
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	data, name := []byte("ID3"), "hallo.mp3"
+	request := AudioRequest{Model: Whisper1, FileBytes: &data, FileName: &name}
+
+	request.Format = AudioResponseFormatVerboseJSON
+	res, err := client.CreateTranslation(ctx, request)
+	checks.NoError(t, err, "CreateTranslation error")
+	if res.Task != "translate" || res.Language != "german" || len(res.Segments) != 1 || res.Text != "Hello." {
+		t.Errorf("Unexpected verbose_json translation: %+v", res)
+	}
+
+	for _, format := range []AudioResponseFormat{AudioResponseFormatSRT, AudioResponseFormatVTT} {
+		request.Format = format
+		res, err = client.CreateTranslation(ctx, request)
+		checks.NoError(t, err, "CreateTranslation error")
+		cues, parseErr := ParseSubtitles(res.Text, format)
+		checks.NoError(t, parseErr, "ParseSubtitles error")
+		if len(cues) != 1 || cues[0].Text != "Hello." {
+			t.Errorf("Unexpected %s translation: %q", format, res.Text)
+		}
+	}
+
+	request.Format = AudioResponseFormatJSON
+	request.Model = GPT4oTranscribe
+	_, err = client.CreateTranslation(ctx, request)
+	checks.ErrorIs(t, err, ErrAudioTranslationNotSupported, "gpt-4o-transcribe should not translate")
+
+	request.Model = Whisper1
+	request.Format = AudioResponseFormatVerboseJSON
+	request.TimestampGranularities = []TranscriptionTimestampGranularity{TranscriptionTimestampGranularityWord}
+	_, err = client.CreateTranslation(ctx, request)
+	checks.ErrorIs(t, err, ErrAudioTranslationNotSupported, "translations should not take timestamp granularities")
+}
+
+func TestAudioRetriedByDefault(t *testing.T) {
+	var calls int
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		handleAudioEndpoint(w, r)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	dir, cleanup := test.CreateTestDirectory(t)
+	defer cleanup()
+	path := filepath.Join(dir, "fake.mp3")
+	test.CreateTestFile(t, path)
+
+	_, err := client.CreateTranscription(context.Background(), AudioRequest{FilePath: path, Model: Whisper1})
+	checks.NoError(t, err, "audio request should be retried with its file")
+	if calls != 2 {
+		t.Fatalf("Expected 2 attempts, got %d", calls)
+	}
+
+	calls = 0
+	ctx := WithRequestOptions(context.Background(), WithRetry(nil))
+	_, err = client.CreateTranscription(ctx, AudioRequest{FilePath: path, Model: Whisper1})
+	if err == nil || calls != 1 {
+		t.Fatalf("Expected a single failed attempt with retries disabled, got %d attempts: %v", calls, err)
+	}
+}
+
+func TestContinuityPromptCJK(t *testing.T) {
+	// 301 three-byte runes without spaces, so 600 bytes end in the middle of a rune.
+	previous := strings.Repeat("語", 301)
+	prompt := continuityPrompt("Glossary:", previous)
+	if !utf8.ValidString(prompt) || !strings.HasPrefix(prompt, "Glossary: 語") {
+		t.Fatalf("The prompt should be cut at a rune boundary, got %q", prompt[:20])
+	}
+	if tail := strings.TrimPrefix(prompt, "Glossary: "); len(tail) > continuityPromptChars {
+		t.Fatalf("The tail should be at most %d bytes, got %d", continuityPromptChars, len(tail))
+	}
+}
@@ -0,0 +1,27 @@
+package openai
+
+import "strings"
+
+// BetaFeature is an OpenAI-Beta header value opting in to a beta API.
+type BetaFeature string
+
+const (
+	BetaRealtime BetaFeature = "realtime=v1"
+)
+
+// betaEndpoints maps the path segments of beta endpoints to the feature they require. Version
+// bumps of a beta only change this table; a single call can opt in to another version with
+// WithHeader("OpenAI-Beta", ...).
+var betaEndpoints = map[string]BetaFeature{
+	"/realtime/": BetaRealtime,
+}
+
+// betaFeatureFor returns the feature the endpoint at path requires, if it is a beta.
+func betaFeatureFor(path string) (BetaFeature, bool) {
+	for segment, feature := range betaEndpoints {
+		if strings.Contains(path, segment) {
+			return feature, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,143 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// CallInfo identifies the API call passed to CallHooks.
+type CallInfo struct {
+	Method   string
+	Path     string
+	Stream   bool
+	Metadata map[string]string
+	// Tag is the request tag attached with WithRequestTag.
+	Tag string
+	// Model is the model reported by the response. It is only set for OnComplete,
+	// and is empty for streams and responses without a model.
+	Model string
+	// BytesSent and BytesReceived count the request and response bodies of all attempts,
+	// as transferred: compressed bodies count their compressed size. They are only set for
+	// OnComplete; for streams they count the events received until the stream ended.
+	BytesSent     int64
+	BytesReceived int64
+}
+
+// CallHooks observe every phase of API calls, e.g. for APM integrations or custom accounting.
+// Hooks are called synchronously, so they should be fast, and may be called concurrently.
+type CallHooks struct {
+	// OnRequestStart is called before a request is sent.
+	OnRequestStart func(info CallInfo)
+	// OnRetry is called before a call is attempted again after err, attempt counting from 2.
+	// Hedged attempts are not retries and are not reported.
+	OnRetry func(info CallInfo, attempt int, err error)
+	// OnStreamEvent is called for every event received on a stream.
+	OnStreamEvent func(info CallInfo)
+	// OnComplete is called once a call finished. For streams this is when the stream ended,
+	// failed or was closed, err being context.Canceled for streams closed before they ended.
+	// Usage is zero when the response does not report it, as is the case for streams.
+	OnComplete func(info CallInfo, err error, usage Usage, duration time.Duration)
+}
+
+// startCall reports the start of the call made with req and returns the request to send, which
+// counts the bytes transferred, and the function reporting its end with the decoded response,
+// if any. The returned function reports only the first completion, so it can be called by both
+// Recv and Close.
+func (c *Client) startCall(
+	req *http.Request,
+	stream bool,
+) (tracked *http.Request, finish func(err error, response any)) {
+	hooks := c.config.CallHooks
+	if hooks == nil {
+		return req, func(error, any) {}
+	}
+
+	info := CallInfo{
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Stream:   stream,
+		Metadata: RequestMetadataFromContext(req.Context()),
+		Tag:      c.requestTag(req.Context()),
+	}
+	if hooks.OnRequestStart != nil {
+		hooks.OnRequestStart(info)
+	}
+
+	tracked, transfer := withTransferCounter(req)
+	start := time.Now()
+	var once sync.Once
+	return tracked, func(err error, response any) {
+		once.Do(func() {
+			if hooks.OnComplete == nil {
+				return
+			}
+			info.BytesSent = atomic.LoadInt64(&transfer.sent)
+			info.BytesReceived = atomic.LoadInt64(&transfer.received)
+			var usage Usage
+			if field, ok := responseField(response, "Usage").(Usage); ok {
+				usage = field
+			}
+			switch model := responseField(response, "Model").(type) {
+			case string:
+				info.Model = model
+			case fmt.Stringer:
+				info.Model = model.String()
+			}
+			hooks.OnComplete(info, err, usage, time.Since(start))
+		})
+	}
+}
+
+// streamEventHook returns the function reporting events of the stream made with req, or nil.
+func (c *Client) streamEventHook(req *http.Request) func() {
+	hooks := c.config.CallHooks
+	if hooks == nil || hooks.OnStreamEvent == nil {
+		return nil
+	}
+	info := CallInfo{
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Stream:   true,
+		Metadata: RequestMetadataFromContext(req.Context()),
+		Tag:      c.requestTag(req.Context()),
+	}
+	return func() { hooks.OnStreamEvent(info) }
+}
+
+// reportRetry reports that the call to urlSuffix is attempted again.
+func (c *Client) reportRetry(ctx context.Context, method, urlSuffix string, attempt int, err error) {
+	path := urlSuffix
+	if u, parseErr := url.Parse(c.fullURL(urlSuffix)); parseErr == nil {
+		path = u.Path
+	}
+	c.reportRetryPath(ctx, method, path, attempt, err)
+}
+
+// reportRetryPath reports that the call to the URL path is attempted again.
+func (c *Client) reportRetryPath(ctx context.Context, method, path string, attempt int, err error) {
+	hooks := c.config.CallHooks
+	if hooks == nil || hooks.OnRetry == nil {
+		return
+	}
+	hooks.OnRetry(CallInfo{Method: method, Path: path, Metadata: RequestMetadataFromContext(ctx), Tag: c.requestTag(ctx)},
+		attempt, err)
+}
+
+// responseField returns the named field of the response struct v points to, or nil.
+func responseField(v any, name string) any {
+	value := reflect.ValueOf(v)
+	if value.Kind() != reflect.Pointer || value.IsNil() || value.Elem().Kind() != reflect.Struct {
+		return nil
+	}
+	field := value.Elem().FieldByName(name)
+	if !field.IsValid() || !field.CanInterface() {
+		return nil
+	}
+	return field.Interface()
+}
@@ -0,0 +1,98 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+type recordedCalls struct {
+	events    []string
+	usage     Usage
+	durations []time.Duration
+}
+
+func (r *recordedCalls) hooks() *CallHooks {
+	return &CallHooks{
+		OnRequestStart: func(info CallInfo) { r.events = append(r.events, "start "+info.Path) },
+		OnRetry: func(info CallInfo, attempt int, err error) {
+			r.events = append(r.events, "retry "+info.Path)
+		},
+		OnStreamEvent: func(info CallInfo) { r.events = append(r.events, "event") },
+		OnComplete: func(info CallInfo, err error, usage Usage, duration time.Duration) {
+			status := "ok"
+			if err != nil {
+				status = "error"
+			}
+			r.events = append(r.events, "complete "+status)
+			r.usage = usage
+			r.durations = append(r.durations, duration)
+		},
+	}
+}
+
+func TestCallHooks(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		req, _ := getChatCompletionBody(r)
+		switch {
+		case req.Model == GPT4:
+			w.WriteHeader(http.StatusTooManyRequests)
+		case req.Stream:
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"choices\":[]}\n\ndata: {\"choices\":[]}\n\ndata: [DONE]\n\n"))
+		default:
+			_, _ = w.Write([]byte(`{"choices":[],"usage":{"prompt_tokens":3,"completion_tokens":5,"total_tokens":8}}`))
+		}
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	recorded := &recordedCalls{}
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.CallHooks = recorded.hooks()
+	config.FallbackChain = []FallbackLink{{Model: GPT3Dot5Turbo, On: FallbackOnRateLimit}}
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	request := ChatCompletionRequest{
+		Model:    GPT4,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hello!"}},
+	}
+	_, err := client.CreateChatCompletion(ctx, request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	expected := "start /v1/chat/completions,complete error,retry /v1/chat/completions," +
+		"start /v1/chat/completions,complete ok"
+	if strings.Join(recorded.events, ",") != expected {
+		t.Fatalf("Unexpected events: %q", recorded.events)
+	}
+	if recorded.usage.CompletionTokens != 5 || len(recorded.durations) != 2 {
+		t.Fatalf("Unexpected usage %+v or durations %v", recorded.usage, recorded.durations)
+	}
+
+	recorded.events = nil
+	request.Model = GPT3Dot5Turbo
+	stream, err := client.CreateChatCompletionStream(ctx, request)
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	if !errors.Is(err, io.EOF) {
+		t.Fatalf("Unexpected stream error: %v", err)
+	}
+	stream.Close()
+	expected = "start /v1/chat/completions,event,event,complete ok"
+	if strings.Join(recorded.events, ",") != expected {
+		t.Fatalf("Unexpected stream events: %q", recorded.events)
+	}
+}
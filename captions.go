@@ -0,0 +1,158 @@
+package openai
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Default limits of a caption cue, after common subtitling guidelines.
+const (
+	defaultCaptionMaxChars    = 42
+	defaultCaptionMaxDuration = 6 * time.Second
+)
+
+// Pauses assumed after punctuation, in characters of speech.
+const (
+	clausePauseChars   = 3
+	sentencePauseChars = 6
+)
+
+// WordTiming is the estimated time span a word of a transcript is spoken in.
+type WordTiming struct {
+	Word  string
+	Start time.Duration
+	End   time.Duration
+}
+
+// CaptionCue is a piece of transcript displayed from Start to End, such as a cue of the SRT
+// or VTT subtitles returned for AudioResponseFormatSRT and AudioResponseFormatVTT. Text may
+// span several lines.
+type CaptionCue struct {
+	// Index numbers the cue in SRT subtitles, starting at 1.
+	Index int
+	Start time.Duration
+	End   time.Duration
+	Text  string
+}
+
+// CaptionOptions limits the length of caption cues; zero values select the defaults of
+// 42 characters and 6 seconds.
+type CaptionOptions struct {
+	MaxChars    int
+	MaxDuration time.Duration
+}
+
+// EstimateWordTimings spreads the words of transcript over duration, the length of the
+// spoken audio (e.g. ProbeAudio(speech).Duration for the output of CreateSpeech). Each word
+// gets time proportional to its length, and punctuation adds a short pause after it.
+// The timings are an estimate, exact enough for subtitles but not for karaoke.
+func EstimateWordTimings(transcript string, duration time.Duration) []WordTiming {
+	words := strings.Fields(transcript)
+	if len(words) == 0 || duration <= 0 {
+		return nil
+	}
+
+	weights := make([]int, len(words))
+	pauses := make([]int, len(words))
+	var total int
+	for i, word := range words {
+		weights[i] = utf8.RuneCountInString(word)
+		if i < len(words)-1 {
+			pauses[i] = wordPause(word)
+		}
+		total += weights[i] + pauses[i]
+	}
+
+	timings := make([]WordTiming, len(words))
+	var elapsed int
+	at := func(units int) time.Duration {
+		return time.Duration(int64(duration) * int64(units) / int64(total))
+	}
+	for i, word := range words {
+		timings[i].Word = word
+		timings[i].Start = at(elapsed)
+		elapsed += weights[i]
+		timings[i].End = at(elapsed)
+		elapsed += pauses[i]
+	}
+	return timings
+}
+
+// wordPause returns the pause spoken after word, judged by its trailing punctuation.
+func wordPause(word string) int {
+	switch word[len(word)-1] {
+	case '.', '!', '?':
+		return sentencePauseChars
+	case ',', ';', ':':
+		return clausePauseChars
+	default:
+		return 0
+	}
+}
+
+// CaptionCues groups word timings into cues that fit options, starting a new cue after
+// each sentence.
+func CaptionCues(words []WordTiming, options CaptionOptions) (cues []CaptionCue) {
+	if options.MaxChars <= 0 {
+		options.MaxChars = defaultCaptionMaxChars
+	}
+	if options.MaxDuration <= 0 {
+		options.MaxDuration = defaultCaptionMaxDuration
+	}
+
+	var current *CaptionCue
+	for _, word := range words {
+		if current != nil &&
+			(utf8.RuneCountInString(current.Text)+1+utf8.RuneCountInString(word.Word) > options.MaxChars ||
+				word.End-current.Start > options.MaxDuration) {
+			current = nil
+		}
+		if current == nil {
+			cues = append(cues, CaptionCue{Index: len(cues) + 1, Start: word.Start, End: word.End, Text: word.Word})
+			current = &cues[len(cues)-1]
+		} else {
+			current.Text += " " + word.Word
+			current.End = word.End
+		}
+		if wordPause(word.Word) == sentencePauseChars {
+			current = nil
+		}
+	}
+	return
+}
+
+// FormatCaptions renders cues as AudioResponseFormatSRT or AudioResponseFormatVTT subtitles.
+// SRT cues are numbered by their Index, or by their position when it is zero; VTT cues are
+// written without identifiers.
+func FormatCaptions(cues []CaptionCue, format AudioResponseFormat) (string, error) {
+	var b strings.Builder
+	switch format {
+	case AudioResponseFormatSRT:
+		for i, cue := range cues {
+			index := cue.Index
+			if index == 0 {
+				index = i + 1
+			}
+			fmt.Fprintf(&b, "%d\n%s --> %s\n%s\n\n", index,
+				captionTimestamp(cue.Start, ','), captionTimestamp(cue.End, ','), cue.Text)
+		}
+	case AudioResponseFormatVTT:
+		b.WriteString("WEBVTT\n\n")
+		for _, cue := range cues {
+			fmt.Fprintf(&b, "%s --> %s\n%s\n\n",
+				captionTimestamp(cue.Start, '.'), captionTimestamp(cue.End, '.'), cue.Text)
+		}
+	default:
+		return "", fmt.Errorf("%w: captions can be formatted as %q or %q, not %q",
+			ErrAudioResponseFormatNotSupported, AudioResponseFormatSRT, AudioResponseFormatVTT, format)
+	}
+	return b.String(), nil
+}
+
+// captionTimestamp formats d as hh:mm:ss followed by sep and milliseconds.
+func captionTimestamp(d time.Duration, sep byte) string {
+	ms := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d%c%03d", ms/3600000, ms/60000%60, ms/1000%60, sep, ms%1000)
+}
@@ -0,0 +1,74 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"testing"
+	"time"
+)
+
+func TestEstimateWordTimings(t *testing.T) {
+	// 2+4 characters, a 6 character sentence pause, then 4 characters: 16 units of 100ms.
+	timings := EstimateWordTimings("Hi you. Next", 1600*time.Millisecond)
+	expected := []WordTiming{
+		{Word: "Hi", Start: 0, End: 200 * time.Millisecond},
+		{Word: "you.", Start: 200 * time.Millisecond, End: 600 * time.Millisecond},
+		{Word: "Next", Start: 1200 * time.Millisecond, End: 1600 * time.Millisecond},
+	}
+	if len(timings) != len(expected) {
+		t.Fatalf("Expected %d timings, got %+v", len(expected), timings)
+	}
+	for i := range expected {
+		if timings[i] != expected[i] {
+			t.Errorf("Timing %d: expected %+v, got %+v", i, expected[i], timings[i])
+		}
+	}
+
+	if EstimateWordTimings("   ", time.Second) != nil || EstimateWordTimings("Hi", 0) != nil {
+		t.Error("Expected no timings without words or duration")
+	}
+}
+
+func TestCaptionCues(t *testing.T) {
+	words := EstimateWordTimings("One two three. Four five six seven", 10*time.Second)
+	cues := CaptionCues(words, CaptionOptions{MaxChars: 15})
+	texts := []string{"One two three.", "Four five six", "seven"}
+	if len(cues) != len(texts) {
+		t.Fatalf("Expected %d cues, got %+v", len(texts), cues)
+	}
+	for i, text := range texts {
+		if cues[i].Text != text {
+			t.Errorf("Cue %d: expected %q, got %q", i, text, cues[i].Text)
+		}
+	}
+	if cues[0].End >= cues[1].Start || cues[2].End != 10*time.Second {
+		t.Errorf("Unexpected cue times: %+v", cues)
+	}
+
+	cues = CaptionCues(words, CaptionOptions{MaxDuration: 2 * time.Second})
+	for _, cue := range cues {
+		if cue.End-cue.Start > 2*time.Second {
+			t.Errorf("Cue %+v exceeds the maximum duration", cue)
+		}
+	}
+}
+
+func TestFormatCaptions(t *testing.T) {
+	cues := []CaptionCue{{Start: 1500 * time.Millisecond, End: 61*time.Minute + 2*time.Second, Text: "Hello."}}
+
+	srt, err := FormatCaptions(cues, AudioResponseFormatSRT)
+	checks.NoError(t, err, "FormatCaptions error")
+	if srt != "1\n00:00:01,500 --> 01:01:02,000\nHello.\n\n" {
+		t.Errorf("Unexpected SRT: %q", srt)
+	}
+
+	vtt, err := FormatCaptions(cues, AudioResponseFormatVTT)
+	checks.NoError(t, err, "FormatCaptions error")
+	if vtt != "WEBVTT\n\n00:00:01.500 --> 01:01:02.000\nHello.\n\n" {
+		t.Errorf("Unexpected VTT: %q", vtt)
+	}
+
+	_, err = FormatCaptions(cues, AudioResponseFormatJSON)
+	checks.ErrorIs(t, err, ErrAudioResponseFormatNotSupported, "FormatCaptions should reject JSON")
+}
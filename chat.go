@@ -1,7 +1,9 @@
 package openai
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"net/http"
 )
@@ -11,22 +13,105 @@ const (
 	ChatMessageRoleSystem    = "system"
 	ChatMessageRoleUser      = "user"
 	ChatMessageRoleAssistant = "assistant"
+	ChatMessageRoleTool      = "tool"
+	// Deprecated: ChatMessageRoleFunction answers FunctionCall, use ChatMessageRoleTool instead.
+	ChatMessageRoleFunction = "function"
 )
 
 var (
 	ErrChatCompletionInvalidModel       = errors.New("this model is not supported with this method, please use CreateCompletion client method instead") //nolint:lll
 	ErrChatCompletionStreamNotSupported = errors.New("streaming is not supported with this method, please use CreateChatCompletionStream")              //nolint:lll
+	ErrContentFieldsMisused             = errors.New("can't use both Content and MultiContent properties simultaneously")
 )
 
+// ChatMessagePartType is the type of a part of the content of a message.
+type ChatMessagePartType string
+
+const (
+	ChatMessagePartTypeText       ChatMessagePartType = "text"
+	ChatMessagePartTypeInputAudio ChatMessagePartType = "input_audio"
+	ChatMessagePartTypeImageURL   ChatMessagePartType = "image_url"
+)
+
+// ChatMessagePart is a part of the content of a message, see ChatCompletionMessage.MultiContent.
+type ChatMessagePart struct {
+	Type       ChatMessagePartType    `json:"type,omitempty"`
+	Text       string                 `json:"text,omitempty"`
+	InputAudio *ChatMessageInputAudio `json:"input_audio,omitempty"`
+	ImageURL   *ChatMessageImageURL   `json:"image_url,omitempty"`
+}
+
+// ChatMessageImageURL is the image of a part, see ImageURLPart and ImageFilePart.
+type ChatMessageImageURL struct {
+	// URL is the URL of the image or the image itself as a data URL.
+	URL    string         `json:"url"`
+	Detail ImageURLDetail `json:"detail,omitempty"`
+}
+
 type ChatCompletionMessage struct {
 	Role    string `json:"role"`
 	Content string `json:"content"`
+	// MultiContent replaces Content with a list of parts, e.g. text, images and audio. Only one of
+	// Content and MultiContent can be set.
+	MultiContent []ChatMessagePart `json:"-"`
 
 	// This property isn't in the official documentation, but it's in
 	// the documentation for the official library for python:
 	// - https://github.com/openai/openai-python/blob/main/chatml.md
 	// - https://github.com/openai/openai-cookbook/blob/main/examples/How_to_count_tokens_with_tiktoken.ipynb
 	Name string `json:"name,omitempty"`
+
+	// Audio is the audio reply of an assistant message to a request with the audio modality.
+	// Send it back with only its ID set to refer to the reply in later turns.
+	Audio *ChatCompletionAudioResponse `json:"audio,omitempty"`
+
+	// ToolCalls are the tools an assistant message calls.
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// ToolCallID is the ID of the call a ChatMessageRoleTool message answers.
+	ToolCallID string `json:"tool_call_id,omitempty"`
+	// Deprecated: FunctionCall is only set for requests with Functions, use ToolCalls instead.
+	FunctionCall *FunctionCall `json:"function_call,omitempty"`
+
+	// Refusal is the explanation of an assistant declining a request with a strict ResponseFormat.
+	Refusal string `json:"refusal,omitempty"`
+}
+
+// chatCompletionMessage has the fields of ChatCompletionMessage without its JSON methods.
+type chatCompletionMessage ChatCompletionMessage
+
+// MarshalJSON implements json.Marshaler, writing MultiContent as the content array.
+func (m ChatCompletionMessage) MarshalJSON() ([]byte, error) {
+	if len(m.MultiContent) == 0 {
+		return json.Marshal(chatCompletionMessage(m))
+	}
+	if m.Content != "" {
+		return nil, ErrContentFieldsMisused
+	}
+	return json.Marshal(struct {
+		chatCompletionMessage
+		Content []ChatMessagePart `json:"content"`
+	}{chatCompletionMessage(m), m.MultiContent})
+}
+
+// UnmarshalJSON implements json.Unmarshaler, reading a content array into MultiContent.
+func (m *ChatCompletionMessage) UnmarshalJSON(data []byte) error {
+	var message struct {
+		chatCompletionMessage
+		Content json.RawMessage `json:"content"`
+	}
+	if err := json.Unmarshal(data, &message); err != nil {
+		return err
+	}
+	*m = ChatCompletionMessage(message.chatCompletionMessage)
+	content := bytes.TrimSpace(message.Content)
+	switch {
+	case len(content) == 0 || string(content) == "null":
+		return nil
+	case content[0] == '[':
+		return json.Unmarshal(content, &m.MultiContent)
+	default:
+		return json.Unmarshal(content, &m.Content)
+	}
 }
 
 // ChatCompletionRequest represents a request structure for chat completion API.
@@ -38,11 +123,44 @@ type ChatCompletionRequest struct {
 	TopP             float32                 `json:"top_p,omitempty"`
 	N                int                     `json:"n,omitempty"`
 	Stream           bool                    `json:"stream,omitempty"`
-	Stop             []string                `json:"stop,omitempty"`
+	Stop             StopSequences           `json:"stop,omitempty"`
 	PresencePenalty  float32                 `json:"presence_penalty,omitempty"`
 	FrequencyPenalty float32                 `json:"frequency_penalty,omitempty"`
 	LogitBias        map[string]int          `json:"logit_bias,omitempty"`
 	User             string                  `json:"user,omitempty"`
+	// MaxCompletionTokens replaces MaxTokens for reasoning models, as it also bounds reasoning tokens.
+	MaxCompletionTokens int `json:"max_completion_tokens,omitempty"`
+	// ReasoningEffort is only supported by reasoning models.
+	ReasoningEffort ReasoningEffort `json:"reasoning_effort,omitempty"`
+	// Verbosity is only supported by the gpt-5 family.
+	Verbosity Verbosity `json:"verbosity,omitempty"`
+	// SafetyIdentifier replaces User for the gpt-4o, gpt-4.1, reasoning and gpt-5 families.
+	// Either can be set: the client sends the one the model family understands.
+	SafetyIdentifier string `json:"safety_identifier,omitempty"`
+	// Modalities are the output types, e.g. text and audio; Audio configures the audio output.
+	Modalities []ChatCompletionModality `json:"modalities,omitempty"`
+	Audio      *ChatCompletionAudio     `json:"audio,omitempty"`
+	// ResponseFormat constrains the content of replies, e.g. to JSON following a schema.
+	ResponseFormat *ChatCompletionResponseFormat `json:"response_format,omitempty"`
+	// Tools are the tools the model may call.
+	Tools []Tool `json:"tools,omitempty"`
+	// ToolChoice is ToolChoiceNone, ToolChoiceAuto, ToolChoiceRequired or a ToolChoice.
+	ToolChoice any `json:"tool_choice,omitempty"`
+	// ParallelToolCalls disables calling several tools at once when set to false.
+	ParallelToolCalls *bool `json:"parallel_tool_calls,omitempty"`
+	// Deprecated: Functions are only understood by older servers and proxies, use Tools instead.
+	Functions []FunctionDefinition `json:"functions,omitempty"`
+	// Deprecated: FunctionCall is "none", "auto" or a FunctionCall naming one of Functions,
+	// use ToolChoice instead.
+	FunctionCall any `json:"function_call,omitempty"`
+}
+
+// completionTokenLimit returns the completion token limit set on the request, if any.
+func (r ChatCompletionRequest) completionTokenLimit() int {
+	if r.MaxCompletionTokens != 0 {
+		return r.MaxCompletionTokens
+	}
+	return r.MaxTokens
 }
 
 type ChatCompletionChoice struct {
@@ -59,12 +177,51 @@ type ChatCompletionResponse struct {
 	Model   string                 `json:"model"`
 	Choices []ChatCompletionChoice `json:"choices"`
 	Usage   Usage                  `json:"usage"`
+
+	// Fallbacks lists the attempts abandoned for ClientConfig.FallbackChain before this
+	// response was served, empty when the request model served it.
+	Fallbacks []FallbackAttempt `json:"-"`
+
+	ResponseMeta `json:"-"`
 }
 
 // CreateChatCompletion — API call to Create a completion for the chat message.
 func (c *Client) CreateChatCompletion(
 	ctx context.Context,
 	request ChatCompletionRequest,
+	opts ...RequestOption,
+) (response ChatCompletionResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
+	response, err = c.createChatCompletion(ctx, request)
+	if len(c.config.FallbackChain) > 0 {
+		response, err = c.fallBack(ctx, request, response, err)
+	}
+	return
+}
+
+// prepareChatRequest migrates, validates and adjusts request before it is sent, the same
+// way for plain and streamed chat completions.
+func (c *Client) prepareChatRequest(request *ChatCompletionRequest) error {
+	c.warnIfDeprecated(request.Model)
+	c.migrateSafetyIdentifier(request)
+	if err := validateSamplingParams(request, c.config.SamplingParamsPolicy); err != nil {
+		return err
+	}
+	if err := validateReasoningOptions(*request, c.config.SamplingParamsPolicy); err != nil {
+		return err
+	}
+	if err := request.Stop.validate(request.Model); err != nil {
+		return err
+	}
+	if err := request.ResponseFormat.validate(); err != nil {
+		return err
+	}
+	return c.applyMaxTokensHeadroom(request)
+}
+
+func (c *Client) createChatCompletion(
+	ctx context.Context,
+	request ChatCompletionRequest,
 ) (response ChatCompletionResponse, err error) {
 	if request.Stream {
 		err = ErrChatCompletionStreamNotSupported
@@ -77,11 +234,25 @@ func (c *Client) CreateChatCompletion(
 		return
 	}
 
+	if err = c.prepareChatRequest(&request); err != nil {
+		return
+	}
+
+	estimated, err := c.acquireTokens(ctx, estimateChatTokens(request.Messages), request.completionTokenLimit(), request.N)
+	if err != nil {
+		return
+	}
+
 	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL(urlSuffix), request)
 	if err != nil {
+		c.settleTokens(estimated, Usage{}, err)
 		return
 	}
 
 	err = c.sendRequest(req, &response)
+	c.settleTokens(estimated, response.Usage, err)
+	if err == nil {
+		err = c.validateChatCompletionResponse(response)
+	}
 	return
 }
@@ -0,0 +1,67 @@
+package openai
+
+import (
+	"encoding/base64"
+)
+
+// GPT4oAudioPreview accepts and generates audio in chat completions.
+const GPT4oAudioPreview = "gpt-4o-audio-preview"
+
+// ChatCompletionModality is an output type of a chat completion.
+type ChatCompletionModality string
+
+const (
+	ChatCompletionModalityText  ChatCompletionModality = "text"
+	ChatCompletionModalityAudio ChatCompletionModality = "audio"
+)
+
+// ChatAudioFormat is the encoding of audio in chat completions.
+type ChatAudioFormat string
+
+const (
+	ChatAudioFormatWAV   ChatAudioFormat = "wav"
+	ChatAudioFormatMP3   ChatAudioFormat = "mp3"
+	ChatAudioFormatFLAC  ChatAudioFormat = "flac"
+	ChatAudioFormatOpus  ChatAudioFormat = "opus"
+	ChatAudioFormatPCM16 ChatAudioFormat = "pcm16"
+)
+
+// ChatMessageInputAudio is the audio of a ChatMessagePartTypeInputAudio part; input audio is
+// either ChatAudioFormatWAV or ChatAudioFormatMP3.
+type ChatMessageInputAudio struct {
+	// Data is base64 encoded.
+	Data   string          `json:"data"`
+	Format ChatAudioFormat `json:"format"`
+}
+
+// ChatCompletionAudio configures the audio output of a request with ChatCompletionModalityAudio.
+type ChatCompletionAudio struct {
+	Voice  SpeechVoice     `json:"voice"`
+	Format ChatAudioFormat `json:"format"`
+}
+
+// ChatCompletionAudioResponse is the audio reply of a chat completion.
+type ChatCompletionAudioResponse struct {
+	ID string `json:"id"`
+	// Data is the base64 encoded audio in the requested format.
+	Data string `json:"data,omitempty"`
+	// ExpiresAt is the Unix time after which the reply can no longer be referred to by ID.
+	ExpiresAt  int64  `json:"expires_at,omitempty"`
+	Transcript string `json:"transcript,omitempty"`
+}
+
+// InputAudioPart returns a part of a message with the audio data, e.g. a WAV or MP3 file.
+func InputAudioPart(data []byte, format ChatAudioFormat) ChatMessagePart {
+	return ChatMessagePart{
+		Type: ChatMessagePartTypeInputAudio,
+		InputAudio: &ChatMessageInputAudio{
+			Data:   base64.StdEncoding.EncodeToString(data),
+			Format: format,
+		},
+	}
+}
+
+// Decode returns the audio of the reply.
+func (a ChatCompletionAudioResponse) Decode() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(a.Data)
+}
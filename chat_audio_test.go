@@ -0,0 +1,83 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestChatCompletionAudio(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		const want = `{"model":"gpt-4o-audio-preview","messages":[{"role":"user","content":[` +
+			`{"type":"text","text":"What is said?"},{"type":"input_audio","input_audio":{"data":"UklGRg==","format":"wav"}}` +
+			`]}],"modalities":["text","audio"],"audio":{"voice":"alloy","format":"mp3"}}`
+		if string(body) != want {
+			t.Errorf("Unexpected request body:\n%s", body)
+		}
+		_, _ = w.Write([]byte(`{"id":"chatcmpl-1","model":"gpt-4o-audio-preview","choices":[{"index":0,
+			"message":{"role":"assistant","content":null,"audio":{"id":"audio_1","data":"SUQz",
+				"expires_at":1700000000,"transcript":"Hello."}},"finish_reason":"stop"}]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	res, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{
+		Model: GPT4oAudioPreview,
+		Messages: []ChatCompletionMessage{{
+			Role: ChatMessageRoleUser,
+			MultiContent: []ChatMessagePart{
+				{Type: ChatMessagePartTypeText, Text: "What is said?"},
+				InputAudioPart([]byte("RIFF"), ChatAudioFormatWAV),
+			},
+		}},
+		Modalities: []ChatCompletionModality{ChatCompletionModalityText, ChatCompletionModalityAudio},
+		Audio:      &ChatCompletionAudio{Voice: VoiceAlloy, Format: ChatAudioFormatMP3},
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+
+	audio := res.Choices[0].Message.Audio
+	if audio == nil || audio.ID != "audio_1" || audio.Transcript != "Hello." {
+		t.Fatalf("Unexpected audio reply: %+v", audio)
+	}
+	data, err := audio.Decode()
+	checks.NoError(t, err, "Decode error")
+	if string(data) != "ID3" {
+		t.Fatalf("Unexpected audio data %q", data)
+	}
+}
+
+func TestChatCompletionMessageMultiContent(t *testing.T) {
+	_, err := json.Marshal(ChatCompletionMessage{
+		Role:         ChatMessageRoleUser,
+		Content:      "Hi",
+		MultiContent: []ChatMessagePart{{Type: ChatMessagePartTypeText, Text: "Hi"}},
+	})
+	checks.ErrorIs(t, err, ErrContentFieldsMisused, "Content and MultiContent should be exclusive")
+
+	var message ChatCompletionMessage
+	err = json.Unmarshal([]byte(`{"role":"user","content":[{"type":"text","text":"Hi"}],"name":"ann"}`), &message)
+	checks.NoError(t, err, "Unmarshal error")
+	if message.Content != "" || len(message.MultiContent) != 1 || message.MultiContent[0].Text != "Hi" ||
+		message.Name != "ann" {
+		t.Fatalf("Unexpected message: %+v", message)
+	}
+
+	err = json.Unmarshal([]byte(`{"role":"user","content":"Hello"}`), &message)
+	checks.NoError(t, err, "Unmarshal error")
+	if message.Content != "Hello" || message.MultiContent != nil {
+		t.Fatalf("Unexpected message: %+v", message)
+	}
+}
@@ -0,0 +1,26 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/openaitest"
+
+	"testing"
+)
+
+func TestChatJSONGolden(t *testing.T) {
+	cases := []struct {
+		fixture string
+		value   any
+	}{
+		{openaitest.ChatCompletionRequest, &ChatCompletionRequest{}},
+		{openaitest.ChatCompletionResponse, &ChatCompletionResponse{}},
+		{openaitest.ChatCompletionStreamResponse, &ChatCompletionStreamResponse{}},
+	}
+	for _, c := range cases {
+		t.Run(c.fixture, func(t *testing.T) {
+			if err := openaitest.RoundTrip(openaitest.Fixture(c.fixture), c.value); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
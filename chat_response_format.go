@@ -0,0 +1,55 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// ChatCompletionResponseFormatType is the format of the content of chat completion replies.
+type ChatCompletionResponseFormatType string
+
+const (
+	ChatCompletionResponseFormatTypeText       ChatCompletionResponseFormatType = "text"
+	ChatCompletionResponseFormatTypeJSONObject ChatCompletionResponseFormatType = "json_object"
+	ChatCompletionResponseFormatTypeJSONSchema ChatCompletionResponseFormatType = "json_schema"
+)
+
+var ErrResponseFormatInvalid = errors.New("response format is invalid")
+
+// responseFormatNamePattern is the pattern the API requires of the names of JSON schemas.
+var responseFormatNamePattern = regexp.MustCompile(`^[a-zA-Z0-9_-]{1,64}$`)
+
+// ChatCompletionResponseFormat constrains the content of replies, see ChatCompletionRequest.ResponseFormat.
+type ChatCompletionResponseFormat struct {
+	Type ChatCompletionResponseFormatType `json:"type"`
+	// JSONSchema is required by ChatCompletionResponseFormatTypeJSONSchema.
+	JSONSchema *ChatCompletionResponseFormatJSONSchema `json:"json_schema,omitempty"`
+}
+
+// ChatCompletionResponseFormatJSONSchema is the JSON Schema replies must follow.
+type ChatCompletionResponseFormatJSONSchema struct {
+	// Name identifies the schema, up to 64 letters, digits, underscores and dashes.
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Schema is the JSON Schema, e.g. a jsonschema.Definition or a json.RawMessage.
+	Schema any `json:"schema"`
+	// Strict guarantees replies follow Schema, which must then be a strict schema: every object
+	// requires all of its properties and disallows additional ones. Refusals are reported in
+	// ChatCompletionMessage.Refusal instead of the content.
+	Strict bool `json:"strict,omitempty"`
+}
+
+func (f *ChatCompletionResponseFormat) validate() error {
+	if f == nil || f.Type != ChatCompletionResponseFormatTypeJSONSchema {
+		return nil
+	}
+	switch {
+	case f.JSONSchema == nil || f.JSONSchema.Schema == nil:
+		return fmt.Errorf("%w: json_schema requires a schema", ErrResponseFormatInvalid)
+	case !responseFormatNamePattern.MatchString(f.JSONSchema.Name):
+		return fmt.Errorf("%w: schema name %q must be 1 to 64 letters, digits, underscores or dashes",
+			ErrResponseFormatInvalid, f.JSONSchema.Name)
+	}
+	return nil
+}
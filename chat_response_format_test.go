@@ -0,0 +1,62 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/jsonschema"
+
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestChatCompletionResponseFormatJSONSchema(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		want := `"response_format":{"type":"json_schema","json_schema":{"name":"city","schema":{"type":"object",` +
+			`"properties":{"name":{"type":"string"}},"required":["name"],"additionalProperties":false},"strict":true}}`
+		if !strings.Contains(string(body), want) {
+			t.Errorf("Request %s does not contain %s", body, want)
+		}
+		_, _ = w.Write([]byte(`{"id":"1","created":1,"choices":[{"finish_reason":"stop","message":` +
+			`{"role":"assistant","content":null,"refusal":"I can't help with that."}}],"usage":{"total_tokens":1}}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	request := ChatCompletionRequest{
+		Model:    GPT4oMini,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Name a city."}},
+		ResponseFormat: &ChatCompletionResponseFormat{
+			Type: ChatCompletionResponseFormatTypeJSONSchema,
+			JSONSchema: &ChatCompletionResponseFormatJSONSchema{
+				Name: "city",
+				Schema: jsonschema.StrictObject("", map[string]jsonschema.Definition{
+					"name": {Type: jsonschema.String},
+				}),
+				Strict: true,
+			},
+		},
+	}
+	res, err := client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if message := res.Choices[0].Message; message.Refusal != "I can't help with that." || message.Content != "" {
+		t.Fatalf("Unexpected message: %+v", message)
+	}
+
+	request.ResponseFormat.JSONSchema.Name = "a city"
+	_, err = client.CreateChatCompletion(context.Background(), request)
+	checks.ErrorIs(t, err, ErrResponseFormatInvalid, "CreateChatCompletion should reject invalid schema names")
+	request.ResponseFormat.JSONSchema = nil
+	_, err = client.CreateChatCompletionStream(context.Background(), request)
+	checks.ErrorIs(t, err, ErrResponseFormatInvalid, "CreateChatCompletionStream should require a schema")
+}
@@ -7,8 +7,12 @@ import (
 )
 
 type ChatCompletionStreamChoiceDelta struct {
-	Content string `json:"content,omitempty"`
-	Role    string `json:"role,omitempty"`
+	Content   string     `json:"content,omitempty"`
+	Role      string     `json:"role,omitempty"`
+	ToolCalls []ToolCall `json:"tool_calls,omitempty"`
+	// Deprecated: FunctionCall is only set for requests with Functions, use ToolCalls instead.
+	FunctionCall *FunctionCall `json:"function_call,omitempty"`
+	Refusal      string        `json:"refusal,omitempty"`
 }
 
 type ChatCompletionStreamChoice struct {
@@ -38,25 +42,45 @@ type ChatCompletionStream struct {
 func (c *Client) CreateChatCompletionStream(
 	ctx context.Context,
 	request ChatCompletionRequest,
+	opts ...RequestOption,
 ) (stream *ChatCompletionStream, err error) {
+	ctx = withCallOptions(ctx, opts)
 	urlSuffix := "/chat/completions"
 	if !checkEndpointSupportsModel(urlSuffix, request.Model) {
 		err = ErrChatCompletionInvalidModel
 		return
 	}
 
+	if err = c.prepareChatRequest(&request); err != nil {
+		return
+	}
+
+	// Streams do not report usage, so the projected tokens are kept.
+	promptTokens := estimateChatTokens(request.Messages)
+	if _, err = c.acquireTokens(ctx, promptTokens, request.completionTokenLimit(), request.N); err != nil {
+		return
+	}
+
 	request.Stream = true
+	ctx, cancel := callContext(ctx)
 	req, err := c.newStreamRequest(ctx, "POST", urlSuffix, request)
 	if err != nil {
+		cancel()
 		return
 	}
 
-	resp, err := c.config.HTTPClient.Do(req) //nolint:bodyclose // body is closed in stream.Close()
+	req, finishCall := c.startCall(req, true)
+	resp, err := c.do(req) //nolint:bodyclose // body is closed in stream.Close()
 	if err != nil {
+		cancel()
+		finishCall(err, nil)
 		return
 	}
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
-		return nil, c.handleErrorResp(resp)
+		err = c.handleErrorResp(resp)
+		cancel()
+		finishCall(err, nil)
+		return nil, err
 	}
 
 	stream = &ChatCompletionStream{
@@ -65,7 +89,11 @@ func (c *Client) CreateChatCompletionStream(
 			reader:             bufio.NewReader(resp.Body),
 			response:           resp,
 			errAccumulator:     newErrorAccumulator(),
-			unmarshaler:        &jsonUnmarshaler{},
+			unmarshaler:        c.unmarshaler(),
+			onEvent:            c.streamEventHook(req),
+			watch:              c.newStreamWatch(req),
+			finishCall:         finishCall,
+			cancel:             cancel,
 		},
 	}
 	return
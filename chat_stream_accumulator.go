@@ -0,0 +1,92 @@
+package openai
+
+import (
+	"sync"
+)
+
+// maxStreamChoices bounds the choices of a stream, as the API bounds N, so that a bogus index
+// cannot make Add allocate without limit.
+const maxStreamChoices = 128
+
+// ChatCompletionStreamSnapshot is the cumulative state of a chat completion stream.
+type ChatCompletionStreamSnapshot struct {
+	ChatCompletionResponse
+	// LastEventIndex is the zero-based index of the last accumulated event, or -1 before the first one.
+	LastEventIndex int `json:"last_event_index"`
+}
+
+// ChatCompletionStreamAccumulator merges stream chunks into the message built so far.
+// Snapshots can be taken at any time, e.g. to resume rendering for a reconnecting
+// browser client without restarting the generation. It is safe for concurrent use.
+type ChatCompletionStreamAccumulator struct {
+	mu       sync.Mutex
+	snapshot ChatCompletionStreamSnapshot
+}
+
+// NewChatCompletionStreamAccumulator creates an empty accumulator.
+func NewChatCompletionStreamAccumulator() *ChatCompletionStreamAccumulator {
+	return &ChatCompletionStreamAccumulator{
+		snapshot: ChatCompletionStreamSnapshot{LastEventIndex: -1},
+	}
+}
+
+// Add merges a chunk received from ChatCompletionStream.Recv. Choices with a negative index,
+// or one beyond maxStreamChoices, are skipped.
+func (a *ChatCompletionStreamAccumulator) Add(chunk ChatCompletionStreamResponse) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	s := &a.snapshot
+	s.LastEventIndex++
+	if chunk.ID != "" {
+		s.ID = chunk.ID
+	}
+	if chunk.Object != "" {
+		s.Object = chunk.Object
+	}
+	if chunk.Created != 0 {
+		s.Created = chunk.Created
+	}
+	if chunk.Model != "" {
+		s.Model = chunk.Model
+	}
+
+	for _, delta := range chunk.Choices {
+		if delta.Index < 0 || delta.Index >= maxStreamChoices {
+			continue
+		}
+		for len(s.Choices) <= delta.Index {
+			s.Choices = append(s.Choices, ChatCompletionChoice{Index: len(s.Choices)})
+		}
+		choice := &s.Choices[delta.Index]
+		if delta.Delta.Role != "" {
+			choice.Message.Role = delta.Delta.Role
+		}
+		choice.Message.Content += delta.Delta.Content
+		choice.Message.Refusal += delta.Delta.Refusal
+		choice.Message.ToolCalls = mergeToolCallDeltas(choice.Message.ToolCalls, delta.Delta.ToolCalls)
+		choice.Message.FunctionCall = mergeFunctionCallDelta(choice.Message.FunctionCall, delta.Delta.FunctionCall)
+		if delta.FinishReason != "" {
+			choice.FinishReason = delta.FinishReason
+		}
+	}
+}
+
+// Snapshot returns a copy of the state accumulated so far.
+func (a *ChatCompletionStreamAccumulator) Snapshot() ChatCompletionStreamSnapshot {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	snapshot := a.snapshot
+	snapshot.Choices = make([]ChatCompletionChoice, len(a.snapshot.Choices))
+	copy(snapshot.Choices, a.snapshot.Choices)
+	for i := range snapshot.Choices {
+		message := &snapshot.Choices[i].Message
+		message.ToolCalls = append([]ToolCall(nil), message.ToolCalls...)
+		if message.FunctionCall != nil {
+			call := *message.FunctionCall
+			message.FunctionCall = &call
+		}
+	}
+	return snapshot
+}
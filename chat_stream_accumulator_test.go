@@ -0,0 +1,59 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+
+	"testing"
+)
+
+func TestChatCompletionStreamAccumulator(t *testing.T) {
+	acc := NewChatCompletionStreamAccumulator()
+	if snapshot := acc.Snapshot(); snapshot.LastEventIndex != -1 || len(snapshot.Choices) != 0 {
+		t.Fatalf("Unexpected empty snapshot: %+v", snapshot)
+	}
+
+	chunks := []ChatCompletionStreamResponse{
+		{
+			ID:      "chatcmpl-1",
+			Created: 1683503651,
+			Model:   GPT3Dot5Turbo,
+			Choices: []ChatCompletionStreamChoice{{Index: 0, Delta: ChatCompletionStreamChoiceDelta{Role: "assistant"}}},
+		},
+		{
+			ID:      "chatcmpl-1",
+			Choices: []ChatCompletionStreamChoice{{Index: 0, Delta: ChatCompletionStreamChoiceDelta{Content: "Hello"}}},
+		},
+	}
+	for _, chunk := range chunks {
+		acc.Add(chunk)
+	}
+
+	checkpoint := acc.Snapshot()
+	acc.Add(ChatCompletionStreamResponse{
+		Choices: []ChatCompletionStreamChoice{
+			{Index: 0, Delta: ChatCompletionStreamChoiceDelta{Content: ", world"}, FinishReason: "stop"},
+		},
+	})
+
+	if checkpoint.LastEventIndex != 1 || checkpoint.Choices[0].Message.Content != "Hello" {
+		t.Fatalf("Snapshot should not change after later events: %+v", checkpoint)
+	}
+
+	final := acc.Snapshot()
+	if final.LastEventIndex != 2 || final.ID != "chatcmpl-1" || final.Model != GPT3Dot5Turbo {
+		t.Fatalf("Unexpected final snapshot: %+v", final)
+	}
+	choice := final.Choices[0]
+	if choice.Message.Role != ChatMessageRoleAssistant || choice.Message.Content != "Hello, world" ||
+		choice.FinishReason != "stop" {
+		t.Fatalf("Unexpected final choice: %+v", choice)
+	}
+
+	acc.Add(ChatCompletionStreamResponse{Choices: []ChatCompletionStreamChoice{
+		{Index: -1, Delta: ChatCompletionStreamChoiceDelta{Content: "negative"}},
+		{Index: 1 << 30, Delta: ChatCompletionStreamChoiceDelta{Content: "huge"}},
+	}})
+	if choices := acc.Snapshot().Choices; len(choices) != 1 || choices[0].Message.Content != "Hello, world" {
+		t.Fatalf("Choices with invalid indexes should be skipped, got %+v", choices)
+	}
+}
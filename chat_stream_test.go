@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestChatCompletionsStreamWrongModel(t *testing.T) {
@@ -140,6 +141,11 @@ func TestCreateChatCompletionStream(t *testing.T) {
 	if !errors.Is(streamErr, io.EOF) {
 		t.Errorf("stream.Recv() did not return EOF when the stream is finished: %v", streamErr)
 	}
+
+	stream.Close()
+	if stream.Canceled() {
+		t.Errorf("A finished stream should not be canceled")
+	}
 }
 
 func TestCreateChatCompletionStreamError(t *testing.T) {
@@ -283,3 +289,70 @@ func compareChatStreamResponseChoices(c1, c2 ChatCompletionStreamChoice) bool {
 	}
 	return true
 }
+
+func TestChatCompletionStreamCloseCancelsGeneration(t *testing.T) {
+	disconnected := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {\"choices\":[]}\n\n"))
+		w.(http.Flusher).Flush()
+		// Keep generating until the client goes away.
+		<-r.Context().Done()
+		close(disconnected)
+	}))
+	defer server.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = server.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{
+		Model:    GPT3Dot5Turbo,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hello!"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream returned error")
+	_, err = stream.Recv()
+	checks.NoError(t, err, "stream.Recv() failed")
+
+	stream.Close()
+	if !stream.Canceled() {
+		t.Fatal("A stream closed before its end should be canceled")
+	}
+	select {
+	case <-disconnected:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Closing the stream did not close the connection")
+	}
+}
+
+func TestChatCompletionStreamCloseAfterError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		_, _ = w.Write([]byte("data: {not json}\n\n"))
+	}))
+	defer server.Close()
+
+	var completions []error
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = server.URL + "/v1"
+	config.CallHooks = &CallHooks{OnComplete: func(_ CallInfo, err error, _ Usage, _ time.Duration) {
+		completions = append(completions, err)
+	}}
+	client := NewClientWithConfig(config)
+
+	stream, err := client.CreateChatCompletionStream(context.Background(), ChatCompletionRequest{
+		Model:    GPT3Dot5Turbo,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hello!"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream returned error")
+	_, err = stream.Recv()
+	checks.HasError(t, err, "stream.Recv() should fail on invalid JSON")
+
+	stream.Close()
+	if stream.Canceled() {
+		t.Fatal("A stream ended by an error should not be canceled by Close")
+	}
+	if len(completions) != 1 || completions[0] == nil || errors.Is(completions[0], context.Canceled) {
+		t.Fatalf("Expected a single completion with the stream error, got %v", completions)
+	}
+}
@@ -0,0 +1,68 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+var (
+	ErrToolCallUnknown          = errors.New("tool call names no function of the request")
+	ErrToolCallArgumentsInvalid = errors.New("tool call arguments do not match the function parameters")
+)
+
+// ValidateToolCall checks the arguments of call, returned by the model, against the Parameters
+// of the function of tools it names, before the call is dispatched. Parameters may be any of
+// the forms FunctionDefinition accepts, e.g. a jsonschema.Definition or a json.RawMessage;
+// functions without Parameters accept any JSON object. The error wraps ErrToolCallUnknown or
+// ErrToolCallArgumentsInvalid, and can be sent back with NewToolCallErrorMessage.
+func ValidateToolCall(tools []Tool, call ToolCall) error {
+	var function *FunctionDefinition
+	for _, tool := range tools {
+		if tool.Function != nil && tool.Function.Name == call.Function.Name {
+			function = tool.Function
+			break
+		}
+	}
+	if function == nil {
+		return fmt.Errorf("%w: %s", ErrToolCallUnknown, call.Function.Name)
+	}
+
+	arguments := call.Function.Arguments
+	if arguments == "" {
+		arguments = "{}"
+	}
+	schema := jsonschema.Definition{Type: jsonschema.Object}
+	if function.Parameters != nil {
+		data, err := json.Marshal(function.Parameters)
+		if err != nil {
+			return fmt.Errorf("encoding the parameters of %s: %w", function.Name, err)
+		}
+		if err = json.Unmarshal(data, &schema); err != nil {
+			return fmt.Errorf("decoding the parameters of %s: %w", function.Name, err)
+		}
+	}
+	if err := schema.ValidateJSON([]byte(arguments)); err != nil {
+		return fmt.Errorf("%w: %s: %s", ErrToolCallArgumentsInvalid, function.Name, err.Error())
+	}
+	return nil
+}
+
+// toolCallError is the content of the message NewToolCallErrorMessage returns.
+type toolCallError struct {
+	Error string `json:"error"`
+}
+
+// NewToolCallErrorMessage returns the tool message answering call with err, e.g. the error of
+// ValidateToolCall, so the model can correct the call in its next turn instead of the
+// conversation failing.
+func NewToolCallErrorMessage(call ToolCall, err error) ChatCompletionMessage {
+	content, _ := json.Marshal(toolCallError{Error: err.Error()})
+	return ChatCompletionMessage{
+		Role:       ChatMessageRoleTool,
+		Content:    string(content),
+		ToolCallID: call.ID,
+	}
+}
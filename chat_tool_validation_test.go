@@ -0,0 +1,57 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/jsonschema"
+
+	"encoding/json"
+	"testing"
+)
+
+func TestValidateToolCall(t *testing.T) {
+	tools := []Tool{
+		{Type: ToolTypeFunction, Function: &FunctionDefinition{
+			Name: "get_weather",
+			Parameters: jsonschema.StrictObject("", map[string]jsonschema.Definition{
+				"city": {Type: jsonschema.String},
+				"unit": jsonschema.Enum("", "celsius", "fahrenheit"),
+			}),
+		}},
+		{Type: ToolTypeFunction, Function: &FunctionDefinition{
+			Name:       "get_time",
+			Parameters: json.RawMessage(`{"type":"object","properties":{"zone":{"type":"string"}},"required":["zone"]}`),
+		}},
+		{Type: ToolTypeFunction, Function: &FunctionDefinition{Name: "ping"}},
+	}
+	call := func(name, arguments string) ToolCall {
+		return ToolCall{ID: "call_1", Type: ToolTypeFunction, Function: FunctionCall{Name: name, Arguments: arguments}}
+	}
+
+	for _, valid := range []ToolCall{
+		call("get_weather", `{"city":"Paris","unit":"celsius"}`),
+		call("get_time", `{"zone":"UTC"}`),
+		call("ping", ""),
+	} {
+		checks.NoError(t, ValidateToolCall(tools, valid), "ValidateToolCall error for", valid.Function.Arguments)
+	}
+
+	for _, invalid := range []ToolCall{
+		call("get_weather", `{"city":"Paris","unit":"kelvin"}`),
+		call("get_weather", `{"city":"Paris"`),
+		call("get_time", `{"zone":1}`),
+	} {
+		err := ValidateToolCall(tools, invalid)
+		checks.ErrorIs(t, err, ErrToolCallArgumentsInvalid, "ValidateToolCall should reject", invalid.Function.Arguments)
+	}
+	checks.ErrorIs(t, ValidateToolCall(tools, call("get_news", "{}")), ErrToolCallUnknown,
+		"ValidateToolCall should reject unknown functions")
+
+	err := ValidateToolCall(tools, call("get_weather", `{"city":"Paris","unit":"kelvin"}`))
+	message := NewToolCallErrorMessage(call("get_weather", ""), err)
+	var content struct{ Error string }
+	if message.Role != ChatMessageRoleTool || message.ToolCallID != "call_1" ||
+		json.Unmarshal([]byte(message.Content), &content) != nil || content.Error != err.Error() {
+		t.Fatalf("Unexpected error message: %+v", message)
+	}
+}
@@ -0,0 +1,111 @@
+package openai
+
+// Finish reasons of chat completion choices that stopped to call tools.
+const (
+	FinishReasonToolCalls = "tool_calls"
+	// Deprecated: FinishReasonFunctionCall is only returned for requests with Functions.
+	FinishReasonFunctionCall = "function_call"
+)
+
+// ToolType is the type of a tool the model may call.
+type ToolType string
+
+const (
+	ToolTypeFunction ToolType = "function"
+)
+
+// Values of ChatCompletionRequest.ToolChoice other than a ToolChoice.
+const (
+	ToolChoiceNone     = "none"
+	ToolChoiceAuto     = "auto"
+	ToolChoiceRequired = "required"
+)
+
+// FunctionDefinition describes a function the model may call.
+type FunctionDefinition struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	// Strict makes the model follow Parameters exactly, which must then be a strict schema.
+	Strict bool `json:"strict,omitempty"`
+	// Parameters is the JSON Schema of the arguments, e.g. a jsonschema.Definition or
+	// a json.RawMessage. Functions without parameters can leave it nil.
+	Parameters any `json:"parameters,omitempty"`
+}
+
+// Tool is a tool the model may call, see ChatCompletionRequest.Tools.
+type Tool struct {
+	Type     ToolType            `json:"type"`
+	Function *FunctionDefinition `json:"function,omitempty"`
+}
+
+// ToolChoice forces the model to call a specific tool.
+type ToolChoice struct {
+	Type     ToolType     `json:"type"`
+	Function ToolFunction `json:"function"`
+}
+
+// ToolFunction names the function of a ToolChoice.
+type ToolFunction struct {
+	Name string `json:"name"`
+}
+
+// FunctionCall is the name and JSON encoded arguments of a call to a function.
+// In stream deltas both are fragments to concatenate.
+type FunctionCall struct {
+	Name      string `json:"name,omitempty"`
+	Arguments string `json:"arguments,omitempty"`
+}
+
+// ToolCall is a call to a tool requested by the model. Answer it with a message of
+// ChatMessageRoleTool with ToolCallID set to ID.
+type ToolCall struct {
+	// Index is only set in stream deltas, where it tells the calls the fragments belong to apart.
+	Index    *int         `json:"index,omitempty"`
+	ID       string       `json:"id,omitempty"`
+	Type     ToolType     `json:"type"`
+	Function FunctionCall `json:"function"`
+}
+
+// maxStreamToolCalls bounds the tool calls of a streamed message, so that a bogus index
+// cannot make mergeToolCallDeltas allocate without limit.
+const maxStreamToolCalls = 128
+
+// mergeToolCallDeltas appends the stream fragments of deltas to calls. Deltas with a negative
+// index, or one beyond maxStreamToolCalls, are skipped.
+func mergeToolCallDeltas(calls []ToolCall, deltas []ToolCall) []ToolCall {
+	for _, delta := range deltas {
+		index := len(calls)
+		if delta.Index != nil {
+			index = *delta.Index
+		}
+		if index < 0 || index >= maxStreamToolCalls {
+			continue
+		}
+		for len(calls) <= index {
+			calls = append(calls, ToolCall{})
+		}
+		call := &calls[index]
+		if delta.ID != "" {
+			call.ID = delta.ID
+		}
+		if delta.Type != "" {
+			call.Type = delta.Type
+		}
+		call.Function.Name += delta.Function.Name
+		call.Function.Arguments += delta.Function.Arguments
+	}
+	return calls
+}
+
+// mergeFunctionCallDelta appends the stream fragment delta to call.
+func mergeFunctionCallDelta(call, delta *FunctionCall) *FunctionCall {
+	if delta == nil {
+		return call
+	}
+	if call == nil {
+		call = &FunctionCall{}
+	}
+	call.Name += delta.Name
+	call.Arguments += delta.Arguments
+	return call
+}
@@ -0,0 +1,113 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+	"github.com/sashabaranov/go-openai/jsonschema"
+
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestChatCompletionToolCalls(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		for _, want := range []string{
+			`"tools":[{"type":"function","function":{"name":"get_weather","parameters":{"type":"object",`,
+			`"tool_choice":{"type":"function","function":{"name":"get_weather"}}`,
+			`"parallel_tool_calls":false`,
+			`{"role":"tool","content":"sunny","tool_call_id":"call_0"}`,
+		} {
+			if !strings.Contains(string(body), want) {
+				t.Errorf("Request %s does not contain %s", body, want)
+			}
+		}
+		_, _ = w.Write([]byte(`{"id":"chatcmpl-1","created":1,"choices":[{"index":0,"finish_reason":"tool_calls",` +
+			`"message":{"role":"assistant","content":null,"tool_calls":[{"id":"call_1","type":"function",` +
+			`"function":{"name":"get_weather","arguments":"{\"city\":\"Paris\"}"}}]}}],"usage":{"total_tokens":1}}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	parallel := false
+	res, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{
+		Model: GPT4,
+		Messages: []ChatCompletionMessage{
+			{Role: ChatMessageRoleUser, Content: "Weather in Paris?"},
+			{Role: ChatMessageRoleTool, Content: "sunny", ToolCallID: "call_0"},
+		},
+		Tools: []Tool{{Type: ToolTypeFunction, Function: &FunctionDefinition{
+			Name: "get_weather",
+			Parameters: jsonschema.StrictObject("", map[string]jsonschema.Definition{
+				"city": {Type: jsonschema.String},
+			}),
+		}}},
+		ToolChoice:        ToolChoice{Type: ToolTypeFunction, Function: ToolFunction{Name: "get_weather"}},
+		ParallelToolCalls: &parallel,
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	choice := res.Choices[0]
+	if choice.FinishReason != FinishReasonToolCalls || len(choice.Message.ToolCalls) != 1 {
+		t.Fatalf("Unexpected choice: %+v", choice)
+	}
+	call := choice.Message.ToolCalls[0]
+	var args struct{ City string }
+	if call.ID != "call_1" || call.Function.Name != "get_weather" ||
+		json.Unmarshal([]byte(call.Function.Arguments), &args) != nil || args.City != "Paris" {
+		t.Fatalf("Unexpected tool call: %+v", call)
+	}
+}
+
+func TestChatCompletionStreamAccumulatorToolCalls(t *testing.T) {
+	first, second := 0, 1
+	acc := NewChatCompletionStreamAccumulator()
+	for _, delta := range []ChatCompletionStreamChoiceDelta{
+		{Role: ChatMessageRoleAssistant, ToolCalls: []ToolCall{
+			{Index: &first, ID: "call_1", Type: ToolTypeFunction, Function: FunctionCall{Name: "get_weather"}},
+		}},
+		{ToolCalls: []ToolCall{{Index: &first, Function: FunctionCall{Arguments: `{"city":`}}}},
+		{ToolCalls: []ToolCall{
+			{Index: &first, Function: FunctionCall{Arguments: `"Paris"}`}},
+			{Index: &second, ID: "call_2", Type: ToolTypeFunction, Function: FunctionCall{Name: "get_time"}},
+		}},
+		{FunctionCall: &FunctionCall{Name: "legacy", Arguments: "{}"}},
+	} {
+		acc.Add(ChatCompletionStreamResponse{Choices: []ChatCompletionStreamChoice{{Delta: delta}}})
+	}
+
+	snapshot := acc.Snapshot()
+	message := snapshot.Choices[0].Message
+	if len(message.ToolCalls) != 2 || message.ToolCalls[0].ID != "call_1" ||
+		message.ToolCalls[0].Function.Arguments != `{"city":"Paris"}` || message.ToolCalls[1].Function.Name != "get_time" {
+		t.Fatalf("Unexpected tool calls: %+v", message.ToolCalls)
+	}
+	if message.FunctionCall == nil || message.FunctionCall.Name != "legacy" {
+		t.Fatalf("Unexpected function call: %+v", message.FunctionCall)
+	}
+
+	acc.Add(ChatCompletionStreamResponse{Choices: []ChatCompletionStreamChoice{{Delta: ChatCompletionStreamChoiceDelta{
+		ToolCalls: []ToolCall{{Index: &second, Function: FunctionCall{Arguments: "{}"}}},
+	}}}})
+	if snapshot.Choices[0].Message.ToolCalls[1].Function.Arguments != "" {
+		t.Fatal("Snapshot should not change after later events")
+	}
+
+	negative, huge := -1, 1<<30
+	acc.Add(ChatCompletionStreamResponse{Choices: []ChatCompletionStreamChoice{{Delta: ChatCompletionStreamChoiceDelta{
+		ToolCalls: []ToolCall{{Index: &negative, ID: "call_3"}, {Index: &huge, ID: "call_4"}},
+	}}}})
+	if calls := acc.Snapshot().Choices[0].Message.ToolCalls; len(calls) != 2 {
+		t.Fatalf("Deltas with invalid indexes should be skipped, got %+v", calls)
+	}
+}
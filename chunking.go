@@ -0,0 +1,217 @@
+package openai
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// TextChunk is a chunk of a text. Text is always Source[Start:End] of the chunked text,
+// so chunks can be mapped back to the original, e.g. to cite or highlight them.
+type TextChunk struct {
+	Text  string
+	Start int
+	End   int
+	// Tokens is the estimated token count of Text.
+	Tokens int
+}
+
+// textSpan is a unit packed into chunks: a word, a sentence or a markdown block.
+type textSpan struct {
+	start, end int
+	tokens     int
+	// breakBefore starts a new chunk at this span, e.g. at a markdown heading.
+	breakBefore bool
+}
+
+// ChunkByTokens splits text at word boundaries into chunks of at most maxTokens estimated tokens,
+// consecutive chunks sharing about overlap tokens. A single word longer than maxTokens is
+// a chunk of its own.
+func ChunkByTokens(text string, maxTokens, overlap int) []TextChunk {
+	return packSpans(text, wordSpans(text, 0, len(text)), maxTokens, overlap, nil)
+}
+
+// ChunkBySentences packs whole sentences into chunks of at most maxTokens estimated tokens,
+// consecutive chunks sharing the trailing sentences worth up to overlap tokens. Sentences
+// longer than maxTokens are split with ChunkByTokens.
+func ChunkBySentences(text string, maxTokens, overlap int) []TextChunk {
+	return packSpans(text, sentenceSpans(text, 0, len(text)), maxTokens, overlap, func(start, end int) []TextChunk {
+		return packSpans(text, wordSpans(text, start, end), maxTokens, overlap, nil)
+	})
+}
+
+// ChunkMarkdown packs the blocks of a markdown document (paragraphs, lists, tables and fenced
+// code blocks) into chunks of at most maxTokens estimated tokens. Every heading starts a new
+// chunk, so chunks do not mix sections, and code blocks are only split when longer than
+// maxTokens on their own. Other blocks longer than maxTokens are split with ChunkBySentences.
+func ChunkMarkdown(text string, maxTokens int) []TextChunk {
+	return packSpans(text, markdownSpans(text), maxTokens, 0, func(start, end int) []TextChunk {
+		return packSpans(text, sentenceSpans(text, start, end), maxTokens, 0, func(start, end int) []TextChunk {
+			return packSpans(text, wordSpans(text, start, end), maxTokens, 0, nil)
+		})
+	})
+}
+
+// packSpans greedily packs consecutive spans into chunks. Spans over maxTokens are handed
+// to splitLarge, or become chunks of their own if it is nil.
+func packSpans(
+	text string,
+	spans []textSpan,
+	maxTokens, overlap int,
+	splitLarge func(start, end int) []TextChunk,
+) (chunks []TextChunk) {
+	if maxTokens <= 0 {
+		maxTokens = 1
+	}
+	if overlap >= maxTokens {
+		overlap = maxTokens / 2
+	}
+
+	for i := 0; i < len(spans); {
+		if spans[i].tokens > maxTokens {
+			if splitLarge != nil {
+				chunks = append(chunks, splitLarge(spans[i].start, spans[i].end)...)
+			} else {
+				chunks = append(chunks, newTextChunk(text, spans[i].start, spans[i].end, spans[i].tokens))
+			}
+			i++
+			continue
+		}
+
+		j, tokens := i, 0
+		for j < len(spans) && (j == i || !spans[j].breakBefore) && tokens+spans[j].tokens <= maxTokens {
+			tokens += spans[j].tokens
+			j++
+		}
+		chunks = append(chunks, newTextChunk(text, spans[i].start, spans[j-1].end, tokens))
+		if j == len(spans) {
+			break
+		}
+
+		// Step back over the spans that make up the overlap, always moving forward
+		// and never across a break.
+		next, overlapTokens := j, 0
+		for !spans[j].breakBefore && next > i+1 && overlapTokens+spans[next-1].tokens <= overlap {
+			overlapTokens += spans[next-1].tokens
+			next--
+		}
+		i = next
+	}
+	return
+}
+
+func newTextChunk(text string, start, end, tokens int) TextChunk {
+	return TextChunk{Text: text[start:end], Start: start, End: end, Tokens: tokens}
+}
+
+// wordSpans returns the whitespace-separated words of text[start:end].
+func wordSpans(text string, start, end int) (spans []textSpan) {
+	wordStart := -1
+	for i, r := range text[start:end] {
+		i += start
+		if unicode.IsSpace(r) {
+			if wordStart >= 0 {
+				spans = append(spans, textSpan{start: wordStart, end: i, tokens: estimateTokens(text[wordStart:i])})
+				wordStart = -1
+			}
+		} else if wordStart < 0 {
+			wordStart = i
+		}
+	}
+	if wordStart >= 0 {
+		spans = append(spans, textSpan{start: wordStart, end: end, tokens: estimateTokens(text[wordStart:end])})
+	}
+	return
+}
+
+// sentenceSpans returns the sentences of text[start:end]. A sentence ends at '.', '!' or '?'
+// followed by whitespace, or at a blank line.
+func sentenceSpans(text string, start, end int) (spans []textSpan) {
+	add := func(from, to int) {
+		segment := text[from:to]
+		trimmed := strings.TrimLeftFunc(segment, unicode.IsSpace)
+		from += len(segment) - len(trimmed)
+		to = from + len(strings.TrimRightFunc(trimmed, unicode.IsSpace))
+		if from < to {
+			spans = append(spans, textSpan{start: from, end: to, tokens: estimateTokens(text[from:to])})
+		}
+	}
+
+	sentenceStart := start
+	for i := start; i < end; {
+		r, size := utf8.DecodeRuneInString(text[i:end])
+		next := i + size
+		switch {
+		case (r == '.' || r == '!' || r == '?') && (next == end || isSpaceAt(text, next, end)):
+			add(sentenceStart, next)
+			sentenceStart = next
+		case r == '\n' && strings.HasPrefix(strings.TrimLeft(text[next:end], " \t"), "\n"):
+			add(sentenceStart, i)
+			sentenceStart = next
+		}
+		i = next
+	}
+	add(sentenceStart, end)
+	return
+}
+
+func isSpaceAt(text string, i, end int) bool {
+	r, _ := utf8.DecodeRuneInString(text[i:end])
+	return unicode.IsSpace(r)
+}
+
+// markdownSpans returns the blocks of a markdown document: runs of non-blank lines, fenced
+// code blocks (blank lines included) and headings, which break chunks.
+func markdownSpans(text string) (spans []textSpan) {
+	blockStart, inFence, heading := -1, false, false
+	flush := func(end int) {
+		if blockStart < 0 {
+			return
+		}
+		block := strings.TrimRightFunc(text[blockStart:end], unicode.IsSpace)
+		if block != "" {
+			spans = append(spans, textSpan{
+				start:       blockStart,
+				end:         blockStart + len(block),
+				tokens:      estimateTokens(block),
+				breakBefore: heading,
+			})
+		}
+		blockStart, heading = -1, false
+	}
+
+	for lineStart := 0; lineStart < len(text); {
+		lineEnd := strings.IndexByte(text[lineStart:], '\n')
+		if lineEnd < 0 {
+			lineEnd = len(text)
+		} else {
+			lineEnd += lineStart + 1
+		}
+		line := strings.TrimSpace(text[lineStart:lineEnd])
+
+		switch {
+		case strings.HasPrefix(line, "```") || strings.HasPrefix(line, "~~~"):
+			if !inFence {
+				flush(lineStart)
+				blockStart = lineStart
+			}
+			inFence = !inFence
+			if !inFence {
+				flush(lineEnd)
+			}
+		case inFence:
+		case line == "":
+			flush(lineStart)
+		case strings.HasPrefix(line, "#"):
+			flush(lineStart)
+			blockStart, heading = lineStart, true
+		default:
+			if blockStart < 0 {
+				blockStart = lineStart
+			}
+		}
+		lineStart = lineEnd
+	}
+	flush(len(text))
+	return
+}
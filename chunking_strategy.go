@@ -0,0 +1,96 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Limits of static chunking strategies accepted by the API.
+const (
+	MinChunkSizeTokens = 100
+	MaxChunkSizeTokens = 4096
+)
+
+var (
+	ErrChunkingStrategyInvalid = errors.New("invalid chunking strategy")
+)
+
+// ChunkingStrategyType is how files added to a vector store are chunked.
+type ChunkingStrategyType string
+
+const (
+	// ChunkingStrategyTypeAuto lets the API choose, currently 800 tokens with an overlap of 400.
+	ChunkingStrategyTypeAuto   ChunkingStrategyType = "auto"
+	ChunkingStrategyTypeStatic ChunkingStrategyType = "static"
+)
+
+// ChunkingStrategy is the chunking_strategy object of the vector store API; Static is only
+// set for ChunkingStrategyTypeStatic.
+type ChunkingStrategy struct {
+	Type   ChunkingStrategyType    `json:"type"`
+	Static *StaticChunkingStrategy `json:"static,omitempty"`
+}
+
+// StaticChunkingStrategy chunks files into chunks of MaxChunkSizeTokens, consecutive chunks
+// sharing ChunkOverlapTokens.
+type StaticChunkingStrategy struct {
+	MaxChunkSizeTokens int `json:"max_chunk_size_tokens"`
+	ChunkOverlapTokens int `json:"chunk_overlap_tokens"`
+}
+
+// Presets of static chunking strategies for common kinds of content.
+var (
+	// ChunkingPresetShortQA suits FAQs and knowledge base articles, where answers are short
+	// and precise retrieval matters more than context.
+	ChunkingPresetShortQA = StaticChunkingStrategy{MaxChunkSizeTokens: 256, ChunkOverlapTokens: 64}
+	// ChunkingPresetLongDocument suits reports, books and contracts, keeping enough context
+	// around each passage for the model to reason about it.
+	ChunkingPresetLongDocument = StaticChunkingStrategy{MaxChunkSizeTokens: 1200, ChunkOverlapTokens: 300}
+	// ChunkingPresetCode suits source code, where a chunk should hold a function or two and
+	// a large overlap keeps declarations next to their uses.
+	ChunkingPresetCode = StaticChunkingStrategy{MaxChunkSizeTokens: 512, ChunkOverlapTokens: 128}
+)
+
+// Strategy returns s as a chunking_strategy object.
+func (s StaticChunkingStrategy) Strategy() ChunkingStrategy {
+	return ChunkingStrategy{Type: ChunkingStrategyTypeStatic, Static: &s}
+}
+
+// Validate checks s against the limits of the API: chunks of MinChunkSizeTokens to
+// MaxChunkSizeTokens, overlapping by at most half their size.
+func (s StaticChunkingStrategy) Validate() error {
+	if s.MaxChunkSizeTokens < MinChunkSizeTokens || s.MaxChunkSizeTokens > MaxChunkSizeTokens {
+		return fmt.Errorf("%w: max_chunk_size_tokens is %d, it must be between %d and %d",
+			ErrChunkingStrategyInvalid, s.MaxChunkSizeTokens, MinChunkSizeTokens, MaxChunkSizeTokens)
+	}
+	if s.ChunkOverlapTokens < 0 || s.ChunkOverlapTokens > s.MaxChunkSizeTokens/2 {
+		return fmt.Errorf("%w: chunk_overlap_tokens is %d, it must be between 0 and %d",
+			ErrChunkingStrategyInvalid, s.ChunkOverlapTokens, s.MaxChunkSizeTokens/2)
+	}
+	return nil
+}
+
+// Chunk splits text locally like s, e.g. to preview the chunks or to embed them with
+// RunEmbeddingPipeline. Token counts are estimated, so chunks may differ slightly from
+// those of the API.
+func (s StaticChunkingStrategy) Chunk(text string) []TextChunk {
+	return ChunkByTokens(text, s.MaxChunkSizeTokens, s.ChunkOverlapTokens)
+}
+
+// Validate checks that s is a known type, with valid static options for static chunking.
+func (s ChunkingStrategy) Validate() error {
+	switch s.Type {
+	case ChunkingStrategyTypeAuto:
+		if s.Static != nil {
+			return fmt.Errorf("%w: auto chunking does not take static options", ErrChunkingStrategyInvalid)
+		}
+		return nil
+	case ChunkingStrategyTypeStatic:
+		if s.Static == nil {
+			return fmt.Errorf("%w: static chunking requires static options", ErrChunkingStrategyInvalid)
+		}
+		return s.Static.Validate()
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrChunkingStrategyInvalid, s.Type)
+	}
+}
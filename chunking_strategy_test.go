@@ -0,0 +1,51 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestChunkingPresets(t *testing.T) {
+	for _, preset := range []StaticChunkingStrategy{
+		ChunkingPresetShortQA, ChunkingPresetLongDocument, ChunkingPresetCode,
+	} {
+		checks.NoError(t, preset.Strategy().Validate(), "presets should be valid")
+	}
+
+	data, err := json.Marshal(ChunkingPresetCode.Strategy())
+	checks.NoError(t, err, "Marshal error")
+	expected := `{"type":"static","static":{"max_chunk_size_tokens":512,"chunk_overlap_tokens":128}}`
+	if string(data) != expected {
+		t.Errorf("Expected %s, got %s", expected, data)
+	}
+
+	text := strings.Repeat("word ", 2000)
+	for _, chunk := range ChunkingPresetShortQA.Chunk(text) {
+		if chunk.Tokens > ChunkingPresetShortQA.MaxChunkSizeTokens {
+			t.Fatalf("Chunk of %d tokens exceeds the preset", chunk.Tokens)
+		}
+	}
+}
+
+func TestChunkingStrategyValidation(t *testing.T) {
+	invalid := []ChunkingStrategy{
+		{Type: "semantic"},
+		{Type: ChunkingStrategyTypeStatic},
+		{Type: ChunkingStrategyTypeAuto, Static: &StaticChunkingStrategy{}},
+		StaticChunkingStrategy{MaxChunkSizeTokens: 99}.Strategy(),
+		StaticChunkingStrategy{MaxChunkSizeTokens: 4097}.Strategy(),
+		StaticChunkingStrategy{MaxChunkSizeTokens: 800, ChunkOverlapTokens: 401}.Strategy(),
+		StaticChunkingStrategy{MaxChunkSizeTokens: 800, ChunkOverlapTokens: -1}.Strategy(),
+	}
+	for _, strategy := range invalid {
+		checks.ErrorIs(t, strategy.Validate(), ErrChunkingStrategyInvalid, "strategy should be invalid")
+	}
+
+	checks.NoError(t, ChunkingStrategy{Type: ChunkingStrategyTypeAuto}.Validate(), "auto should be valid")
+	checks.NoError(t, StaticChunkingStrategy{MaxChunkSizeTokens: 4096, ChunkOverlapTokens: 2048}.Strategy().Validate(),
+		"limits should be valid")
+}
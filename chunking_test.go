@@ -0,0 +1,61 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+
+	"testing"
+)
+
+func chunkTexts(t *testing.T, source string, chunks []TextChunk) []string {
+	t.Helper()
+	texts := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		if source[chunk.Start:chunk.End] != chunk.Text {
+			t.Fatalf("Chunk %d does not match its offsets: %q", i, chunk.Text)
+		}
+		texts[i] = chunk.Text
+	}
+	return texts
+}
+
+func checkChunks(t *testing.T, actual []string, expected ...string) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Fatalf("Expected chunks %q, got %q", expected, actual)
+	}
+	for i := range expected {
+		if actual[i] != expected[i] {
+			t.Fatalf("Expected chunks %q, got %q", expected, actual)
+		}
+	}
+}
+
+func TestChunkByTokens(t *testing.T) {
+	text := "w1  w2\nw3 w4 w5"
+	chunks := ChunkByTokens(text, 2, 1)
+	checkChunks(t, chunkTexts(t, text, chunks), "w1  w2", "w2\nw3", "w3 w4", "w4 w5")
+	if chunks[1].Start != 4 || chunks[1].Tokens != 2 {
+		t.Fatalf("Unexpected chunk: %+v", chunks[1])
+	}
+}
+
+func TestChunkBySentences(t *testing.T) {
+	text := "One two. Three four five! Six?"
+	checkChunks(t, chunkTexts(t, text, ChunkBySentences(text, 7, 0)), "One two. Three four five!", "Six?")
+	checkChunks(t, chunkTexts(t, text, ChunkBySentences(text, 7, 5)), "One two. Three four five!", "Three four five! Six?")
+
+	text = "Short.\n\nThis sentence is far too long"
+	checkChunks(t, chunkTexts(t, text, ChunkBySentences(text, 3, 0)), "Short.", "This sentence", "is far too", "long")
+}
+
+func TestChunkMarkdown(t *testing.T) {
+	text := "# Title\n\nIntro text.\n\n## Code\n\n```go\nfunc a() {\n\n}\n```\n\nTail.\n"
+	checkChunks(t, chunkTexts(t, text, ChunkMarkdown(text, 100)),
+		"# Title\n\nIntro text.",
+		"## Code\n\n```go\nfunc a() {\n\n}\n```\n\nTail.",
+	)
+
+	text = "# Title\n\nFirst sentence here. Second sentence here."
+	checkChunks(t, chunkTexts(t, text, ChunkMarkdown(text, 6)),
+		"# Title", "First sentence here.", "Second sentence here.")
+}
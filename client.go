@@ -7,14 +7,24 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // Client is OpenAI GPT-3 API client.
+//
+// A Client is safe for concurrent use by multiple goroutines, including the limiter, hedging,
+// archiving and hook state it is configured with, and should be shared rather than created
+// per request. The streams it returns are not: Recv must not be called concurrently,
+// while Close may be called from any goroutine.
 type Client struct {
 	config ClientConfig
 
 	requestBuilder    requestBuilder
 	createFormBuilder func(io.Writer) formBuilder
+
+	hedger       *hedger
+	archiveQueue *archiveQueue
+	requestTags  map[string]bool
 }
 
 // NewClient creates new OpenAI API client.
@@ -25,13 +35,32 @@ func NewClient(authToken string) *Client {
 
 // NewClientWithConfig creates new OpenAI API client for specified config.
 func NewClientWithConfig(config ClientConfig) *Client {
-	return &Client{
+	// Copy the slices of config, so callers changing theirs cannot race with in-flight calls.
+	config.FallbackChain = append([]FallbackLink(nil), config.FallbackChain...)
+
+	client := &Client{
 		config:         config,
 		requestBuilder: newRequestBuilder(),
 		createFormBuilder: func(body io.Writer) formBuilder {
 			return newFormBuilder(body)
 		},
 	}
+	if config.JSONCodec != nil {
+		client.requestBuilder = &httpRequestBuilder{marshaller: codecJSON{config.JSONCodec}}
+	}
+	if config.Hedging != nil {
+		client.hedger = newHedger(*config.Hedging)
+	}
+	if config.Archive != nil {
+		client.startArchive()
+	}
+	if len(config.RequestTags) > 0 {
+		client.requestTags = make(map[string]bool, len(config.RequestTags))
+		for _, tag := range config.RequestTags {
+			client.requestTags[tag] = true
+		}
+	}
+	return client
 }
 
 // NewOrgClient creates new OpenAI API client for specified Organization ID.
@@ -45,13 +74,7 @@ func NewOrgClient(authToken, org string) *Client {
 
 func (c *Client) sendRequest(req *http.Request, v any) error {
 	req.Header.Set("Accept", "application/json; charset=utf-8")
-	// Azure API Key authentication
-	if c.config.APIType == APITypeAzure {
-		req.Header.Set(AzureAPIKeyHeader, c.config.authToken)
-	} else {
-		// OpenAI or Azure AD authentication
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.config.authToken))
-	}
+	c.setCommonHeaders(req)
 
 	// Check whether Content-Type is already set, Upload Files API requires
 	// Content-Type == multipart/form-data
@@ -60,22 +83,56 @@ func (c *Client) sendRequest(req *http.Request, v any) error {
 		req.Header.Set("Content-Type", "application/json; charset=utf-8")
 	}
 
-	if len(c.config.OrgID) > 0 {
-		req.Header.Set("OpenAI-Organization", c.config.OrgID)
-	}
-
-	res, err := c.config.HTTPClient.Do(req)
+	ctx, cancel := callContext(req.Context())
+	defer cancel()
+	req, finishCall := c.startCall(req.WithContext(ctx), false)
+	res, failed, err := c.doWithRetries(req)
 	if err != nil {
+		err = retryExhausted(failed, nil, err)
+		finishCall(err, nil)
 		return err
 	}
 
 	defer res.Body.Close()
 
+	finishArchive := c.captureForArchive(req, res)
+
 	if res.StatusCode < http.StatusOK || res.StatusCode >= http.StatusBadRequest {
-		return c.handleErrorResp(res)
+		err = retryExhausted(failed, res, c.handleErrorResp(res))
+	} else {
+		err = c.decodeResponse(res.Body, v)
+		if meta, ok := v.(responseMetaSetter); ok && err == nil {
+			meta.setResponseMeta(res.Header)
+		}
 	}
+	finishArchive(err)
+	finishCall(err, v)
+	return err
+}
+
+func (c *Client) do(req *http.Request) (res *http.Response, err error) {
+	if req.Header.Get("Accept-Encoding") == "" {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+	sent, err := c.compressRequest(req)
+	if err != nil {
+		return
+	}
+	sent, countResponse := countTransfer(sent)
 
-	return decodeResponse(res.Body, v)
+	start := time.Now()
+	if c.hedger != nil && isHedgeable(sent) {
+		res, err = c.doHedged(sent)
+	} else {
+		res, err = c.config.HTTPClient.Do(sent) //nolint:bodyclose // closed by the caller
+	}
+	if err == nil {
+		countResponse(res)
+		decompressResponse(res)
+	}
+	c.checkLatencyBudget(req, res, err, time.Since(start))
+	c.runStatusHooks(req, res, err)
+	return
 }
 
 func decodeResponse(body io.Reader, v any) error {
@@ -86,6 +143,13 @@ func decodeResponse(body io.Reader, v any) error {
 	if result, ok := v.(*string); ok {
 		return decodeString(body, result)
 	}
+	if result, ok := v.(*[]byte); ok {
+		return decodeBytes(body, result)
+	}
+	if result, ok := v.(io.Writer); ok {
+		_, err := io.Copy(result, body)
+		return err
+	}
 	return json.NewDecoder(body).Decode(v)
 }
 
@@ -98,6 +162,11 @@ func decodeString(body io.Reader, output *string) error {
 	return nil
 }
 
+func decodeBytes(body io.Reader, output *[]byte) (err error) {
+	*output, err = io.ReadAll(body)
+	return
+}
+
 func (c *Client) fullURL(suffix string) string {
 	// /openai/deployments/{engine}/chat/completions?api-version={api_version}
 	if c.config.APIType == APITypeAzure || c.config.APIType == APITypeAzureAD {
@@ -130,7 +199,13 @@ func (c *Client) newStreamRequest(
 	req.Header.Set("Accept", "text/event-stream")
 	req.Header.Set("Cache-Control", "no-cache")
 	req.Header.Set("Connection", "keep-alive")
+	c.setCommonHeaders(req)
+	return req, nil
+}
 
+// setCommonHeaders sets the authentication, organization, project, beta, metadata and
+// WithHeader headers.
+func (c *Client) setCommonHeaders(req *http.Request) {
 	// https://learn.microsoft.com/en-us/azure/cognitive-services/openai/reference#authentication
 	// Azure API Key authentication
 	if c.config.APIType == APITypeAzure {
@@ -142,7 +217,20 @@ func (c *Client) newStreamRequest(
 	if c.config.OrgID != "" {
 		req.Header.Set("OpenAI-Organization", c.config.OrgID)
 	}
-	return req, nil
+	if c.config.ProjectID != "" {
+		req.Header.Set("OpenAI-Project", c.config.ProjectID)
+	}
+	if feature, ok := betaFeatureFor(req.URL.Path); ok {
+		req.Header.Set("OpenAI-Beta", string(feature))
+	}
+	if c.config.MetadataHeaderPrefix != "" {
+		for key, value := range RequestMetadataFromContext(req.Context()) {
+			req.Header.Set(c.config.MetadataHeaderPrefix+key, value)
+		}
+	}
+	for key, values := range requestOptionsFromContext(req.Context()).header {
+		req.Header[key] = values
+	}
 }
 
 func (c *Client) handleErrorResp(resp *http.Response) error {
@@ -0,0 +1,151 @@
+package openai
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// TenantConfigFunc returns the client configuration for a tenant, e.g. by looking up the
+// customer's own API key, organization and project in a database or secret store.
+type TenantConfigFunc func(tenant string) (ClientConfig, error)
+
+// ClientPoolConfig configures the limits and accounting a ClientPool adds to every tenant client.
+type ClientPoolConfig struct {
+	// TokensPerMinute gives every tenant its own TokenLimiter with this budget, unless the
+	// configuration of the tenant sets one. Tenants are not limited when zero.
+	TokensPerMinute int
+	// WaitForTokens queues the calls of a tenant over its budget instead of rejecting them,
+	// see NewTokenLimiter.
+	WaitForTokens bool
+	// Usage records the usage of every call, grouped by tenant, when set.
+	Usage *UsageReporter
+}
+
+// ClientPool lazily creates and caches one Client per tenant, so SaaS products in which
+// each customer brings their own key can share a single entry point.
+// ClientPool is safe for concurrent use.
+type ClientPool struct {
+	configFor TenantConfigFunc
+	config    ClientPoolConfig
+
+	mu      sync.Mutex
+	clients map[string]*poolEntry
+}
+
+// poolEntry is the client of a tenant, available once ready is closed. Its creation failed
+// when err is set.
+type poolEntry struct {
+	ready  chan struct{}
+	client *Client
+	err    error
+}
+
+// NewClientPool creates a pool that builds tenant clients with configFor.
+func NewClientPool(configFor TenantConfigFunc) *ClientPool {
+	return NewClientPoolWithConfig(configFor, ClientPoolConfig{})
+}
+
+// NewClientPoolWithConfig creates a pool that builds tenant clients with configFor, adding the
+// per-tenant limits and usage accounting of config.
+func NewClientPoolWithConfig(configFor TenantConfigFunc, config ClientPoolConfig) *ClientPool {
+	return &ClientPool{
+		configFor: configFor,
+		config:    config,
+		clients:   make(map[string]*poolEntry),
+	}
+}
+
+// Client returns the client of tenant, creating it on first use. The configuration is looked
+// up without holding the pool's lock, so a slow lookup does not block other tenants; concurrent
+// calls for a tenant without a client wait for a single lookup.
+func (p *ClientPool) Client(tenant string) (*Client, error) {
+	p.mu.Lock()
+	entry, ok := p.clients[tenant]
+	if !ok {
+		entry = &poolEntry{ready: make(chan struct{})}
+		p.clients[tenant] = entry
+	}
+	p.mu.Unlock()
+	if ok {
+		<-entry.ready
+		return entry.client, entry.err
+	}
+
+	config, err := p.configFor(tenant)
+	if err == nil {
+		entry.client = NewClientWithConfig(p.tenantConfig(tenant, config))
+	} else {
+		// Failed lookups are not cached, so the next call tries again.
+		entry.err = err
+		p.mu.Lock()
+		if p.clients[tenant] == entry {
+			delete(p.clients, tenant)
+		}
+		p.mu.Unlock()
+	}
+	close(entry.ready)
+	return entry.client, entry.err
+}
+
+// tenantConfig adds the limiter and usage accounting of the pool to the config of tenant.
+func (p *ClientPool) tenantConfig(tenant string, config ClientConfig) ClientConfig {
+	if config.TokenLimiter == nil && p.config.TokensPerMinute > 0 {
+		config.TokenLimiter = NewTokenLimiter(p.config.TokensPerMinute, p.config.WaitForTokens)
+	}
+	if usage := p.config.Usage; usage != nil {
+		var hooks CallHooks
+		if config.CallHooks != nil {
+			hooks = *config.CallHooks
+		}
+		onComplete := hooks.OnComplete
+		hooks.OnComplete = func(info CallInfo, err error, callUsage Usage, duration time.Duration) {
+			if err == nil {
+				key := usageKey{group: tenant, tag: info.Tag, model: info.Model}
+				usage.record(key, callUsage, info.BytesSent, info.BytesReceived)
+			}
+			if onComplete != nil {
+				onComplete(info, err, callUsage, duration)
+			}
+		}
+		config.CallHooks = &hooks
+	}
+	return config
+}
+
+// Remove drops the cached client of tenant, e.g. after its key was rotated, and closes its
+// archive, see Client.CloseArchive. The next call to Client builds a fresh one.
+func (p *ClientPool) Remove(tenant string) {
+	p.mu.Lock()
+	entry, ok := p.clients[tenant]
+	delete(p.clients, tenant)
+	p.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	<-entry.ready
+	if entry.client != nil {
+		_ = entry.client.CloseArchive(context.Background())
+	}
+}
+
+// Tenants returns the sorted list of tenants with a cached client.
+func (p *ClientPool) Tenants() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	tenants := make([]string, 0, len(p.clients))
+	for tenant, entry := range p.clients {
+		select {
+		case <-entry.ready:
+			if entry.client != nil {
+				tenants = append(tenants, tenant)
+			}
+		default:
+		}
+	}
+	sort.Strings(tenants)
+	return tenants
+}
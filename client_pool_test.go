@@ -0,0 +1,193 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientPool(t *testing.T) {
+	var projects []string
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		projects = append(projects, r.Header.Get("OpenAI-Project"))
+		fmt.Fprintln(w, `{"data":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	errUnknownTenant := errors.New("unknown tenant")
+	var built int
+	pool := NewClientPool(func(tenant string) (ClientConfig, error) {
+		if tenant == "" {
+			return ClientConfig{}, errUnknownTenant
+		}
+		built++
+		config := DefaultConfig(test.GetTestToken())
+		config.BaseURL = ts.URL + "/v1"
+		config.ProjectID = "proj_" + tenant
+		return config, nil
+	})
+
+	ctx := context.Background()
+	for _, tenant := range []string{"acme", "globex", "acme"} {
+		client, err := pool.Client(tenant)
+		checks.NoError(t, err, "ClientPool.Client error")
+		_, err = client.ListModels(ctx)
+		checks.NoError(t, err, "ListModels error")
+	}
+
+	if built != 2 {
+		t.Fatalf("Expected one client per tenant, built %d", built)
+	}
+	if fmt.Sprint(projects) != "[proj_acme proj_globex proj_acme]" {
+		t.Fatalf("Unexpected project headers: %v", projects)
+	}
+	if fmt.Sprint(pool.Tenants()) != "[acme globex]" {
+		t.Fatalf("Unexpected tenants: %v", pool.Tenants())
+	}
+
+	pool.Remove("acme")
+	_, err := pool.Client("acme")
+	checks.NoError(t, err, "ClientPool.Client error")
+	if built != 3 {
+		t.Fatalf("Removed tenant client should be rebuilt, built %d", built)
+	}
+
+	_, err = pool.Client("")
+	checks.ErrorIs(t, err, errUnknownTenant, "ClientPool.Client should return config errors")
+}
+
+func TestClientPoolLimitsAndUsage(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"id":"1","model":"gpt-4","choices":[],"usage":{"prompt_tokens":10,"total_tokens":10}}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var snapshots []UsageSnapshot
+	usage := NewUsageReporter(UsageReporterConfig{
+		OnSnapshot: func(snapshot UsageSnapshot) { snapshots = append(snapshots, snapshot) },
+	})
+	var pool *ClientPool
+	pool = NewClientPoolWithConfig(func(tenant string) (ClientConfig, error) {
+		// The lookup runs without the pool's lock, so it may use the pool.
+		_ = pool.Tenants()
+		config := DefaultConfig(test.GetTestToken())
+		config.BaseURL = ts.URL + "/v1"
+		return config, nil
+	}, ClientPoolConfig{TokensPerMinute: 500, Usage: usage})
+
+	ctx := context.Background()
+	request := ChatCompletionRequest{
+		Model:    GPT4,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hi"}},
+	}
+	acme, err := pool.Client("acme")
+	checks.NoError(t, err, "ClientPool.Client error")
+	_, err = acme.CreateChatCompletion(ctx, request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+
+	// Each tenant has its own budget, which acme exceeds with a large request.
+	large := request
+	large.Messages = []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: strings.Repeat("a", 4000)}}
+	_, err = acme.CreateChatCompletion(ctx, large)
+	var budgetErr *TokenBudgetError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("Expected acme to exceed its budget, got %v", err)
+	}
+	globex, err := pool.Client("globex")
+	checks.NoError(t, err, "ClientPool.Client error")
+	_, err = globex.CreateChatCompletion(ctx, request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+
+	usage.Flush()
+	if len(snapshots) != 1 || len(snapshots[0].Entries) != 2 {
+		t.Fatalf("Expected usage of both tenants, got %+v", snapshots)
+	}
+	for i, tenant := range []string{"acme", "globex"} {
+		if entry := snapshots[0].Entries[i]; entry.Group != tenant || entry.Requests != 1 || entry.Usage.TotalTokens != 10 {
+			t.Errorf("Unexpected usage entry %+v", entry)
+		}
+	}
+}
+
+func TestClientPoolConcurrentCreation(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/moderations", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"id":"modr-1","results":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var (
+		mu      sync.Mutex
+		lookups int
+		errs    []error
+	)
+	release := make(chan struct{})
+	pool := NewClientPool(func(tenant string) (ClientConfig, error) {
+		mu.Lock()
+		lookups++
+		mu.Unlock()
+		<-release
+		config := DefaultConfig(test.GetTestToken())
+		config.BaseURL = ts.URL + "/v1"
+		config.Archive = &ArchiveConfig{
+			Archiver: make(channelArchiver, 10),
+			OnError: func(err error) {
+				mu.Lock()
+				defer mu.Unlock()
+				errs = append(errs, err)
+			},
+		}
+		return config, nil
+	})
+
+	var wg sync.WaitGroup
+	clients := make([]*Client, 5)
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var err error
+			clients[i], err = pool.Client("acme")
+			checks.NoError(t, err, "ClientPool.Client error")
+		}(i)
+	}
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if lookups != 1 {
+		t.Fatalf("Concurrent calls should share one lookup, got %d", lookups)
+	}
+	for _, client := range clients {
+		if client != clients[0] {
+			t.Fatal("Concurrent calls should return the same client")
+		}
+	}
+
+	// Removing the tenant closes the archive of its client.
+	pool.Remove("acme")
+	_, err := clients[0].Moderations(context.Background(), ModerationRequest{Input: "hi"})
+	checks.NoError(t, err, "Moderations error")
+	mu.Lock()
+	defer mu.Unlock()
+	if len(errs) != 1 || !errors.Is(errs[0], ErrArchiveClosed) {
+		t.Fatalf("Expected the archive of the removed client to be closed, got %v", errs)
+	}
+}
@@ -0,0 +1,94 @@
+package openai
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+var (
+	ErrClientProfileNotFound   = errors.New("client profile not found")
+	ErrClientProfileMissingKey = errors.New("API key environment variable of client profile is not set")
+)
+
+// ClientProfile is a named client configuration, e.g. for the dev, staging or prod environment.
+// Keys are not stored in profiles: APIKeyEnv names the environment variable holding the key.
+type ClientProfile struct {
+	BaseURL    string  `json:"base_url,omitempty"`
+	APIKeyEnv  string  `json:"api_key_env"`
+	OrgID      string  `json:"org_id,omitempty"`
+	ProjectID  string  `json:"project_id,omitempty"`
+	APIType    APIType `json:"api_type,omitempty"`
+	APIVersion string  `json:"api_version,omitempty"`
+	Engine     string  `json:"engine,omitempty"`
+	// Timeout is the timeout of the HTTP client as parsed by time.ParseDuration, e.g. "30s".
+	Timeout string `json:"timeout,omitempty"`
+}
+
+// ClientProfiles maps profile names to profiles.
+type ClientProfiles map[string]ClientProfile
+
+// LoadClientProfiles reads profiles from a JSON file mapping profile names to profiles.
+func LoadClientProfiles(path string) (profiles ClientProfiles, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(data, &profiles); err != nil {
+		err = fmt.Errorf("parsing client profiles %s: %w", path, err)
+	}
+	return
+}
+
+// Config returns the client configuration of the profile name. Its signature matches
+// TenantConfigFunc, so profiles can back a ClientPool keyed by profile name.
+func (p ClientProfiles) Config(name string) (config ClientConfig, err error) {
+	profile, ok := p[name]
+	if !ok {
+		err = fmt.Errorf("%w: %s", ErrClientProfileNotFound, name)
+		return
+	}
+
+	key := os.Getenv(profile.APIKeyEnv)
+	if key == "" {
+		err = fmt.Errorf("%w: %s needs %s", ErrClientProfileMissingKey, name, profile.APIKeyEnv)
+		return
+	}
+
+	if profile.APIType == APITypeAzure || profile.APIType == APITypeAzureAD {
+		config = DefaultAzureConfig(key, profile.BaseURL, profile.Engine)
+		config.APIType = profile.APIType
+	} else {
+		config = DefaultConfig(key)
+	}
+	if profile.BaseURL != "" {
+		config.BaseURL = profile.BaseURL
+	}
+	if profile.APIVersion != "" {
+		config.APIVersion = profile.APIVersion
+	}
+	config.OrgID = profile.OrgID
+	config.ProjectID = profile.ProjectID
+
+	if profile.Timeout != "" {
+		var timeout time.Duration
+		if timeout, err = time.ParseDuration(profile.Timeout); err != nil {
+			err = fmt.Errorf("client profile %s: invalid timeout: %w", name, err)
+			return
+		}
+		config.HTTPClient = &http.Client{Timeout: timeout}
+	}
+	return
+}
+
+// NewClient creates a client from the profile name.
+func (p ClientProfiles) NewClient(name string) (*Client, error) {
+	config, err := p.Config(name)
+	if err != nil {
+		return nil, err
+	}
+	return NewClientWithConfig(config), nil
+}
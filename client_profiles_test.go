@@ -0,0 +1,55 @@
+package openai //nolint:testpackage // testing private field
+
+import (
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestClientProfiles(t *testing.T) {
+	dir, cleanup := test.CreateTestDirectory(t)
+	defer cleanup()
+
+	path := filepath.Join(dir, "profiles.json")
+	err := os.WriteFile(path, []byte(`{
+		"dev": {"base_url": "http://localhost:8080/v1", "api_key_env": "TEST_DEV_OPENAI_KEY", "timeout": "5s"},
+		"prod": {"api_key_env": "TEST_PROD_OPENAI_KEY", "org_id": "org-1", "project_id": "proj-1"},
+		"azure": {"base_url": "https://example.openai.azure.com", "api_key_env": "TEST_DEV_OPENAI_KEY",
+			"api_type": "AZURE", "engine": "gpt-35", "api_version": "2024-02-01"}
+	}`), 0o600)
+	checks.NoError(t, err, "WriteFile error")
+	t.Setenv("TEST_DEV_OPENAI_KEY", "dev-key")
+
+	profiles, err := LoadClientProfiles(path)
+	checks.NoError(t, err, "LoadClientProfiles error")
+
+	client, err := profiles.NewClient("dev")
+	checks.NoError(t, err, "NewClient error")
+	if client.config.authToken != "dev-key" || client.config.BaseURL != "http://localhost:8080/v1" ||
+		client.config.HTTPClient.Timeout != 5*time.Second {
+		t.Fatalf("Unexpected dev config: %+v", client.config)
+	}
+
+	azure, err := profiles.Config("azure")
+	checks.NoError(t, err, "Config error")
+	if azure.APIType != APITypeAzure || azure.Engine != "gpt-35" || azure.APIVersion != "2024-02-01" {
+		t.Fatalf("Unexpected azure config: %+v", azure)
+	}
+
+	_, err = profiles.NewClient("prod")
+	checks.ErrorIs(t, err, ErrClientProfileMissingKey, "prod should need its key")
+	_, err = profiles.NewClient("qa")
+	checks.ErrorIs(t, err, ErrClientProfileNotFound, "qa is not a profile")
+
+	t.Setenv("TEST_PROD_OPENAI_KEY", "prod-key")
+	pool := NewClientPool(profiles.Config)
+	prod, err := pool.Client("prod")
+	checks.NoError(t, err, "ClientPool error")
+	if prod.config.OrgID != "org-1" || prod.config.ProjectID != "proj-1" || prod.config.BaseURL != openaiAPIURLv1 {
+		t.Fatalf("Unexpected prod config: %+v", prod.config)
+	}
+}
@@ -0,0 +1,48 @@
+package openai
+
+import "io"
+
+// JSONCodec encodes request bodies and decodes response bodies and stream events, e.g. with
+// goccy/go-json or sonic instead of encoding/json. It must honor the json.Marshaler and
+// json.Unmarshaler methods of the types of this package.
+type JSONCodec interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// codecJSON adapts a JSONCodec to the marshaller and unmarshaler interfaces.
+type codecJSON struct {
+	codec JSONCodec
+}
+
+func (c codecJSON) marshal(value any) ([]byte, error) {
+	return c.codec.Marshal(value)
+}
+
+func (c codecJSON) unmarshal(data []byte, v any) error {
+	return c.codec.Unmarshal(data, v)
+}
+
+// unmarshaler returns the unmarshaler of stream events, see ClientConfig.JSONCodec.
+func (c *Client) unmarshaler() unmarshaler {
+	if c.config.JSONCodec != nil {
+		return codecJSON{c.config.JSONCodec}
+	}
+	return &jsonUnmarshaler{}
+}
+
+// decodeResponse decodes body into v like the decodeResponse function, with ClientConfig.JSONCodec for JSON.
+func (c *Client) decodeResponse(body io.Reader, v any) error {
+	switch v.(type) {
+	case *string, *[]byte, io.Writer:
+		return decodeResponse(body, v)
+	}
+	if c.config.JSONCodec == nil || v == nil {
+		return decodeResponse(body, v)
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return err
+	}
+	return c.config.JSONCodec.Unmarshal(data, v)
+}
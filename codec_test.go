@@ -0,0 +1,90 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+)
+
+// countingCodec is encoding/json counting its calls.
+type countingCodec struct {
+	marshals, unmarshals int32
+}
+
+func (c *countingCodec) Marshal(v any) ([]byte, error) {
+	atomic.AddInt32(&c.marshals, 1)
+	return json.Marshal(v)
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v any) error {
+	atomic.AddInt32(&c.unmarshals, 1)
+	return json.Unmarshal(data, v)
+}
+
+func TestJSONCodec(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		req, err := getChatCompletionBody(r)
+		if err != nil {
+			http.Error(w, "invalid request", http.StatusBadRequest)
+			return
+		}
+		if req.Stream {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\n\n" +
+				"data: [DONE]\n\n"))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"1","created":1,"choices":[{"message":{"role":"assistant","content":"Hi"}}],` +
+			`"usage":{"total_tokens":1}}`))
+	})
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"bad request"}}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	codec := &countingCodec{}
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.JSONCodec = codec
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	request := ChatCompletionRequest{
+		Model:    GPT4,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hi"}},
+	}
+	res, err := client.CreateChatCompletion(ctx, request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if res.Choices[0].Message.Content != "Hi" || codec.marshals != 1 || codec.unmarshals != 1 {
+		t.Fatalf("Expected the codec to encode and decode the call, got %d marshals and %d unmarshals: %+v",
+			codec.marshals, codec.unmarshals, res)
+	}
+
+	request.Stream = true
+	stream, err := client.CreateChatCompletionStream(ctx, request)
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+	chunk, err := stream.Recv()
+	checks.NoError(t, err, "Recv error")
+	if chunk.Choices[0].Delta.Content != "Hi" || codec.marshals != 2 || codec.unmarshals != 2 {
+		t.Fatalf("Expected the codec to decode stream events, got %d marshals and %d unmarshals",
+			codec.marshals, codec.unmarshals)
+	}
+
+	_, err = client.ListModels(ctx)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Message != "bad request" || codec.unmarshals != 2 {
+		t.Fatalf("Expected the error to be decoded with encoding/json, got %v", err)
+	}
+}
@@ -17,6 +17,16 @@ var (
 // GPT3 Models are designed for text-based tasks. For code-specific
 // tasks, please refer to the Codex series of models.
 const (
+	O1Mini                  = "o1-mini"
+	O1                      = "o1"
+	O3Mini                  = "o3-mini"
+	O3                      = "o3"
+	O4Mini                  = "o4-mini"
+	GPT5                    = "gpt-5"
+	GPT5Mini                = "gpt-5-mini"
+	GPT5Nano                = "gpt-5-nano"
+	GPT4o                   = "gpt-4o"
+	GPT4oMini               = "gpt-4o-mini"
 	GPT432K0314             = "gpt-4-32k-0314"
 	GPT432K                 = "gpt-4-32k"
 	GPT40314                = "gpt-4-0314"
@@ -48,6 +58,16 @@ const (
 
 var disabledModelsForEndpoints = map[string]map[string]bool{
 	"/completions": {
+		O1Mini:            true,
+		O1:                true,
+		O3Mini:            true,
+		O3:                true,
+		O4Mini:            true,
+		GPT5:              true,
+		GPT5Mini:          true,
+		GPT5Nano:          true,
+		GPT4o:             true,
+		GPT4oMini:         true,
 		GPT3Dot5Turbo:     true,
 		GPT3Dot5Turbo0301: true,
 		GPT4:              true,
@@ -96,7 +116,7 @@ type CompletionRequest struct {
 	Stream           bool           `json:"stream,omitempty"`
 	LogProbs         int            `json:"logprobs,omitempty"`
 	Echo             bool           `json:"echo,omitempty"`
-	Stop             []string       `json:"stop,omitempty"`
+	Stop             StopSequences  `json:"stop,omitempty"`
 	PresencePenalty  float32        `json:"presence_penalty,omitempty"`
 	FrequencyPenalty float32        `json:"frequency_penalty,omitempty"`
 	BestOf           int            `json:"best_of,omitempty"`
@@ -128,6 +148,8 @@ type CompletionResponse struct {
 	Model   string             `json:"model"`
 	Choices []CompletionChoice `json:"choices"`
 	Usage   Usage              `json:"usage"`
+
+	ResponseMeta `json:"-"`
 }
 
 // CreateCompletion — API call to create a completion. This is the main endpoint of the API. Returns new text as well
@@ -138,7 +160,9 @@ type CompletionResponse struct {
 func (c *Client) CreateCompletion(
 	ctx context.Context,
 	request CompletionRequest,
+	opts ...RequestOption,
 ) (response CompletionResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
 	if request.Stream {
 		err = ErrCompletionStreamNotSupported
 		return
@@ -150,16 +174,29 @@ func (c *Client) CreateCompletion(
 		return
 	}
 
+	c.warnIfDeprecated(request.Model)
+
 	if !checkPromptType(request.Prompt) {
 		err = ErrCompletionRequestPromptTypeNotSupported
 		return
 	}
 
+	if err = request.Stop.validate(request.Model); err != nil {
+		return
+	}
+
+	estimated, err := c.acquireTokens(ctx, estimatePromptTokens(request.Prompt), request.MaxTokens, request.N)
+	if err != nil {
+		return
+	}
+
 	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL(urlSuffix), request)
 	if err != nil {
+		c.settleTokens(estimated, Usage{}, err)
 		return
 	}
 
 	err = c.sendRequest(req, &response)
+	c.settleTokens(estimated, response.Usage, err)
 	return
 }
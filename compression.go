@@ -0,0 +1,87 @@
+package openai
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// compressRequest returns a copy of req with its JSON body gzipped when the body is
+// at least CompressRequestsOver bytes long. Otherwise req itself is returned.
+// The original request is left untouched so archiving still sees the plain body.
+func (c *Client) compressRequest(req *http.Request) (*http.Request, error) {
+	if c.config.CompressRequestsOver <= 0 || req.GetBody == nil ||
+		req.Header.Get("Content-Encoding") != "" ||
+		!strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return req, nil
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	plain, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	if len(plain) < c.config.CompressRequestsOver {
+		return req, nil
+	}
+
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err = gz.Write(plain); err != nil {
+		return nil, err
+	}
+	if err = gz.Close(); err != nil {
+		return nil, err
+	}
+
+	compressedReq, err := http.NewRequestWithContext(req.Context(), req.Method, req.URL.String(), &compressed)
+	if err != nil {
+		return nil, err
+	}
+	compressedReq.Header = req.Header.Clone()
+	compressedReq.Header.Set("Content-Encoding", "gzip")
+	return compressedReq, nil
+}
+
+// decompressResponse replaces the body of a gzip encoded response with a decompressing reader.
+// Setting Accept-Encoding explicitly turns off the transparent decompression of http.Transport,
+// so this is what keeps gzip transparent for callers.
+func decompressResponse(res *http.Response) {
+	if !strings.EqualFold(res.Header.Get("Content-Encoding"), "gzip") {
+		return
+	}
+	res.Header.Del("Content-Encoding")
+	res.Header.Del("Content-Length")
+	res.ContentLength = -1
+	res.Uncompressed = true
+	res.Body = &gzipResponseBody{body: res.Body}
+}
+
+// gzipResponseBody creates the gzip reader on the first read, so empty bodies
+// (e.g. HEAD responses) do not fail with a missing gzip header.
+type gzipResponseBody struct {
+	body   io.ReadCloser
+	reader *gzip.Reader
+	err    error
+}
+
+func (b *gzipResponseBody) Read(p []byte) (n int, err error) {
+	if b.reader == nil && b.err == nil {
+		b.reader, b.err = gzip.NewReader(b.body)
+	}
+	if b.err != nil {
+		return 0, b.err
+	}
+	return b.reader.Read(p)
+}
+
+func (b *gzipResponseBody) Close() error {
+	return b.body.Close()
+}
@@ -0,0 +1,67 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGzipRequestsAndResponses(t *testing.T) {
+	var encodings []string
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		encodings = append(encodings, r.Header.Get("Content-Encoding"))
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				http.Error(w, "invalid gzip body", http.StatusBadRequest)
+				return
+			}
+			body = gz
+		}
+		var req EmbeddingRequest
+		if err := json.NewDecoder(body).Decode(&req); err != nil {
+			http.Error(w, "could not read request", http.StatusBadRequest)
+			return
+		}
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			http.Error(w, "gzip not accepted", http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		gz := gzip.NewWriter(w)
+		defer gz.Close()
+		res := EmbeddingResponse{Model: req.Model, Data: []Embedding{{Embedding: []float32{1}}}}
+		_ = json.NewEncoder(gz).Encode(res)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.CompressRequestsOver = 1024
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	for _, input := range []string{"short", strings.Repeat("long input ", 200)} {
+		res, err := client.CreateEmbeddings(ctx, EmbeddingRequest{Input: []string{input}, Model: AdaEmbeddingV2})
+		checks.NoError(t, err, "CreateEmbeddings error")
+		if len(res.Data) != 1 || res.Model != AdaEmbeddingV2 {
+			t.Fatalf("Unexpected response: %+v", res)
+		}
+	}
+	if len(encodings) != 2 || encodings[0] != "" || encodings[1] != "gzip" {
+		t.Fatalf("Only the long request should be compressed, got encodings %q", encodings)
+	}
+}
@@ -0,0 +1,109 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type discardArchiver struct{}
+
+func (discardArchiver) Archive(context.Context, ArchiveRecord) error { return nil }
+
+// TestSharedClientConcurrentUse exercises one client with every stateful option enabled from
+// many goroutines. Run it with -race.
+func TestSharedClientConcurrentUse(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		req, _ := getChatCompletionBody(r)
+		if req.Stream {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte("data: {\"choices\":[]}\n\ndata: [DONE]\n\n"))
+			return
+		}
+		res := ChatCompletionResponse{ID: "1", Created: 1, Model: req.Model, Usage: Usage{TotalTokens: 2}}
+		resBytes, _ := json.Marshal(res)
+		_, _ = w.Write(resBytes)
+	})
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"index":0,"embedding":[1]}]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var completed int64
+	reporter := NewUsageReporter(UsageReporterConfig{Interval: time.Minute, GroupBy: "worker"})
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.TokenLimiter = NewTokenLimiter(1_000_000_000, true)
+	config.Hedging = &HedgeConfig{Quantile: 0.9, Delay: time.Millisecond}
+	config.Archive = &ArchiveConfig{Archiver: discardArchiver{}}
+	config.MetadataHeaderPrefix = "X-App-"
+	config.CallHooks = &CallHooks{
+		OnComplete: func(info CallInfo, err error, usage Usage, duration time.Duration) {
+			atomic.AddInt64(&completed, 1)
+			reporter.OnComplete(info, err, usage, duration)
+		},
+	}
+	client := NewClientWithConfig(config)
+
+	const workers = 32
+	errs := make(chan error, workers)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			ctx := WithRequestMetadata(context.Background(), map[string]string{"worker": fmt.Sprint(worker % 4)})
+			errs <- useSharedClient(ctx, client)
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	if atomic.LoadInt64(&completed) != workers*3 {
+		t.Fatalf("Expected %d completed calls, got %d", workers*3, completed)
+	}
+}
+
+func useSharedClient(ctx context.Context, client *Client) error {
+	request := ChatCompletionRequest{
+		Model:    GPT3Dot5Turbo,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hello!"}},
+	}
+	if _, err := client.CreateChatCompletion(ctx, request); err != nil {
+		return fmt.Errorf("CreateChatCompletion: %w", err)
+	}
+
+	stream, err := client.CreateChatCompletionStream(ctx, request)
+	if err != nil {
+		return fmt.Errorf("CreateChatCompletionStream: %w", err)
+	}
+	defer stream.Close()
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	if !errors.Is(err, io.EOF) {
+		return fmt.Errorf("stream.Recv: %w", err)
+	}
+
+	if _, err = client.CreateEmbeddings(ctx, EmbeddingRequest{Input: []string{"x"}, Model: AdaEmbeddingV2}); err != nil {
+		return fmt.Errorf("CreateEmbeddings: %w", err)
+	}
+	return nil
+}
@@ -28,6 +28,7 @@ type ClientConfig struct {
 
 	BaseURL    string
 	OrgID      string
+	ProjectID  string
 	APIType    APIType
 	APIVersion string // required when APIType is APITypeAzure or APITypeAzureAD
 	Engine     string // required when APIType is APITypeAzure or APITypeAzureAD
@@ -35,6 +36,83 @@ type ClientConfig struct {
 	HTTPClient *http.Client
 
 	EmptyMessagesLimit uint
+
+	// SamplingParamsPolicy decides how chat requests with sampling parameters, reasoning
+	// efforts or verbosities unsupported by the target model are handled. Defaults to
+	// SamplingParamsError.
+	SamplingParamsPolicy SamplingParamsPolicy
+
+	// Hedging enables hedged requests for idempotent calls when set.
+	Hedging *HedgeConfig
+
+	// TokenLimiter admits chat and completion requests against a tokens-per-minute budget when set.
+	TokenLimiter *TokenLimiter
+
+	// MetadataHeaderPrefix enables sending the request metadata attached with WithRequestMetadata
+	// as outbound headers, e.g. "X-App-" sends the "tenant" key as the X-App-Tenant header.
+	MetadataHeaderPrefix string
+
+	// Archive enables asynchronous archiving of request/response pairs when set. Call
+	// Client.CloseArchive before exiting, so the queued records are not lost.
+	Archive *ArchiveConfig
+
+	// OnDeprecatedModel is called before a request to a deprecated model is sent,
+	// so migrations can happen before the model is shut down.
+	OnDeprecatedModel func(deprecation ModelDeprecation)
+
+	// OnDeprecatedParameter is called before a request using a deprecated parameter is sent
+	// to a model that supports its replacement, e.g. user instead of safety_identifier.
+	OnDeprecatedParameter func(deprecation ParameterDeprecation)
+
+	// AutoMaxTokens sets the completion token limit of chat requests that do not set one
+	// to the room left in the model's context window by the estimated prompt,
+	// minus MaxTokensReserve tokens.
+	AutoMaxTokens    bool
+	MaxTokensReserve int
+
+	// StatusHooks are called for rate limited, failed and timed out requests when set.
+	StatusHooks *StatusHooks
+
+	// CompressRequestsOver gzips JSON request bodies of at least this many bytes when greater than zero.
+	// Only enable it for endpoints or gateways that accept Content-Encoding: gzip.
+	CompressRequestsOver int
+
+	// OnLatencyBudgetExceeded is called for calls that take longer than the budget attached
+	// to their context with WithLatencyBudget.
+	OnLatencyBudgetExceeded func(event LatencyBudgetEvent)
+
+	// FallbackChain is tried in order when a chat completion fails in a way a link falls back on.
+	FallbackChain []FallbackLink
+
+	// CallHooks observe the start, retries, stream events and completion of calls when set.
+	CallHooks *CallHooks
+
+	// StreamStall reports streams receiving nothing for longer than its threshold when set.
+	StreamStall *StreamStallConfig
+
+	// ResponseValidationPolicy decides how chat completion responses missing the id, created
+	// timestamp or usage are handled. Defaults to ResponseValidationAccept.
+	ResponseValidationPolicy ResponseValidationPolicy
+	// OnResponseWarning receives the problems found under ResponseValidationWarn.
+	OnResponseWarning func(err error)
+
+	// Retry enables retrying failed calls when set.
+	Retry *RetryConfig
+
+	// ValidateFileUploads checks files with ValidateFileUpload before CreateFile uploads them.
+	ValidateFileUploads bool
+
+	// SpeechCache caches the audio generated by CreateSpeech when set.
+	SpeechCache *SpeechCacheConfig
+
+	// JSONCodec replaces encoding/json for request bodies, responses and stream events when set.
+	// Error responses are always decoded with encoding/json.
+	JSONCodec JSONCodec
+
+	// RequestTags declares the tags attached with WithRequestTag. Other tags are reported as
+	// OtherRequestTag, so a typo or a tag built from user input cannot blow up the cardinality
+	// of metrics. Any tag is reported as is when empty.
+	RequestTags []string
 }
 
 func DefaultConfig(authToken string) ClientConfig {
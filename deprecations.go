@@ -0,0 +1,76 @@
+package openai
+
+// ModelDeprecation describes a model OpenAI has deprecated, see
+// https://platform.openai.com/docs/deprecations.
+type ModelDeprecation struct {
+	Model string
+	// ShutdownDate is the date (YYYY-MM-DD) after which requests to the model fail.
+	ShutdownDate string
+	// Replacement is the recommended model to migrate to.
+	Replacement string
+}
+
+const (
+	legacyModelsShutdown      = "2024-01-04"
+	gpt3Dot5Turbo0301Shutdown = "2024-09-13"
+	gpt40314Shutdown          = "2024-06-13"
+	o1MiniShutdown            = "2025-10-27"
+)
+
+type deprecationGroup struct {
+	shutdownDate string
+	replacement  string
+	models       []string
+}
+
+var modelDeprecations = indexDeprecations([]deprecationGroup{
+	{legacyModelsShutdown, "gpt-3.5-turbo-instruct", []string{
+		GPT3TextDavinci003, GPT3TextDavinci002, GPT3TextDavinci001, GPT3TextCurie001,
+		GPT3DavinciInstructBeta, GPT3CurieInstructBeta,
+		CodexCodeDavinci002, CodexCodeCushman001, CodexCodeDavinci001,
+		"text-davinci-edit-001", "code-davinci-edit-001",
+	}},
+	{legacyModelsShutdown, "babbage-002", []string{GPT3TextBabbage001, GPT3TextAda001, GPT3Ada, GPT3Babbage}},
+	{legacyModelsShutdown, "davinci-002", []string{GPT3Davinci, GPT3Curie}},
+	{legacyModelsShutdown, AdaEmbeddingV2.String(), []string{
+		AdaSimilarity.String(), BabbageSimilarity.String(), CurieSimilarity.String(), DavinciSimilarity.String(),
+		AdaSearchDocument.String(), AdaSearchQuery.String(), BabbageSearchDocument.String(),
+		BabbageSearchQuery.String(), CurieSearchDocument.String(), CurieSearchQuery.String(),
+		DavinciSearchDocument.String(), DavinciSearchQuery.String(), AdaCodeSearchCode.String(),
+		AdaCodeSearchText.String(), BabbageCodeSearchCode.String(), BabbageCodeSearchText.String(),
+	}},
+	{gpt3Dot5Turbo0301Shutdown, GPT3Dot5Turbo, []string{GPT3Dot5Turbo0301}},
+	{gpt40314Shutdown, GPT4, []string{GPT40314}},
+	{gpt40314Shutdown, GPT432K, []string{GPT432K0314}},
+	{o1MiniShutdown, O4Mini, []string{O1Mini}},
+})
+
+func indexDeprecations(groups []deprecationGroup) map[string]ModelDeprecation {
+	deprecations := make(map[string]ModelDeprecation)
+	for _, group := range groups {
+		for _, model := range group.models {
+			deprecations[model] = ModelDeprecation{
+				Model:        model,
+				ShutdownDate: group.shutdownDate,
+				Replacement:  group.replacement,
+			}
+		}
+	}
+	return deprecations
+}
+
+// LookupModelDeprecation returns the deprecation notice of model, if it is deprecated.
+func LookupModelDeprecation(model string) (ModelDeprecation, bool) {
+	deprecation, ok := modelDeprecations[model]
+	return deprecation, ok
+}
+
+// warnIfDeprecated calls ClientConfig.OnDeprecatedModel when model is deprecated.
+func (c *Client) warnIfDeprecated(model string) {
+	if c.config.OnDeprecatedModel == nil {
+		return
+	}
+	if deprecation, ok := LookupModelDeprecation(model); ok {
+		c.config.OnDeprecatedModel(deprecation)
+	}
+}
@@ -0,0 +1,47 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+
+	"context"
+	"testing"
+)
+
+func TestLookupModelDeprecation(t *testing.T) {
+	deprecation, ok := LookupModelDeprecation(GPT3TextDavinci003)
+	if !ok || deprecation.Replacement != "gpt-3.5-turbo-instruct" || deprecation.ShutdownDate != "2024-01-04" {
+		t.Fatalf("Unexpected deprecation: %+v", deprecation)
+	}
+
+	deprecation, ok = LookupModelDeprecation(AdaSimilarity.String())
+	if !ok || deprecation.Replacement != AdaEmbeddingV2.String() {
+		t.Fatalf("Unexpected deprecation: %+v", deprecation)
+	}
+
+	if _, ok = LookupModelDeprecation(GPT4); ok {
+		t.Fatal("gpt-4 should not be deprecated")
+	}
+}
+
+func TestDeprecatedModelWarning(t *testing.T) {
+	var warnings []ModelDeprecation
+	config := DefaultConfig("whatever")
+	config.BaseURL = "http://localhost/v1"
+	config.OnDeprecatedModel = func(deprecation ModelDeprecation) {
+		warnings = append(warnings, deprecation)
+	}
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	_, _ = client.CreateChatCompletion(ctx, ChatCompletionRequest{Model: GPT3Dot5Turbo0301})
+	_, _ = client.CreateChatCompletion(ctx, ChatCompletionRequest{Model: GPT3Dot5Turbo})
+	_, _ = client.CreateCompletion(ctx, CompletionRequest{Model: GPT3TextDavinci002, Prompt: "Hello"})
+	_, _ = client.CreateEmbeddings(ctx, EmbeddingRequest{Model: CurieSearchQuery})
+
+	if len(warnings) != 3 {
+		t.Fatalf("Expected 3 warnings, got %+v", warnings)
+	}
+	if warnings[0].Model != GPT3Dot5Turbo0301 || warnings[0].Replacement != GPT3Dot5Turbo {
+		t.Fatalf("Unexpected warning: %+v", warnings[0])
+	}
+}
@@ -27,10 +27,21 @@ type EditsResponse struct {
 	Created int64         `json:"created"`
 	Usage   Usage         `json:"usage"`
 	Choices []EditsChoice `json:"choices"`
+
+	ResponseMeta `json:"-"`
 }
 
 // Perform an API call to the Edits endpoint.
-func (c *Client) Edits(ctx context.Context, request EditsRequest) (response EditsResponse, err error) {
+func (c *Client) Edits(
+	ctx context.Context,
+	request EditsRequest,
+	opts ...RequestOption,
+) (response EditsResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
+	if request.Model != nil {
+		c.warnIfDeprecated(*request.Model)
+	}
+
 	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL("/edits"), request)
 	if err != nil {
 		return
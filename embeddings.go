@@ -111,6 +111,8 @@ type EmbeddingResponse struct {
 	Data   []Embedding    `json:"data"`
 	Model  EmbeddingModel `json:"model"`
 	Usage  Usage          `json:"usage"`
+
+	ResponseMeta `json:"-"`
 }
 
 // EmbeddingRequest is the input to a Create embeddings request.
@@ -131,7 +133,14 @@ type EmbeddingRequest struct {
 
 // CreateEmbeddings returns an EmbeddingResponse which will contain an Embedding for every item in |request.Input|.
 // https://beta.openai.com/docs/api-reference/embeddings/create
-func (c *Client) CreateEmbeddings(ctx context.Context, request EmbeddingRequest) (resp EmbeddingResponse, err error) {
+func (c *Client) CreateEmbeddings(
+	ctx context.Context,
+	request EmbeddingRequest,
+	opts ...RequestOption,
+) (resp EmbeddingResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
+	c.warnIfDeprecated(request.Model.String())
+
 	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL("/embeddings"), request)
 	if err != nil {
 		return
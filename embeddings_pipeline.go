@@ -0,0 +1,222 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultPipelineBatchSize   = 100
+	defaultPipelineConcurrency = 4
+	defaultPipelineRetryDelay  = time.Second
+)
+
+// EmbeddingDocument is a document ingested by RunEmbeddingPipeline.
+type EmbeddingDocument struct {
+	ID       string
+	Text     string
+	Metadata map[string]string
+}
+
+// EmbeddedChunk is a chunk of a document together with its embedding.
+type EmbeddedChunk struct {
+	DocumentID string
+	// Index is the position of the chunk within its document.
+	Index     int
+	Text      string
+	Embedding []float32
+	Metadata  map[string]string
+}
+
+// EmbeddingSink stores embedded chunks, e.g. in a vector database.
+// Upsert may be called concurrently and must be idempotent, since failed batches are retried.
+type EmbeddingSink interface {
+	Upsert(ctx context.Context, chunks []EmbeddedChunk) error
+}
+
+// EmbeddingPipelineProgress reports how far a pipeline run has got.
+type EmbeddingPipelineProgress struct {
+	TotalChunks int
+	DoneChunks  int
+	Usage       Usage
+}
+
+// EmbeddingPipelineConfig configures RunEmbeddingPipeline.
+type EmbeddingPipelineConfig struct {
+	Model EmbeddingModel
+	// ChunkTokens is the maximum size of a chunk, defaults to the input limit of the model.
+	ChunkTokens   int
+	OverlapTokens int
+	// BatchSize is the number of chunks per embeddings request, defaults to 100.
+	BatchSize int
+	// Concurrency is the number of batches in flight, defaults to 4.
+	Concurrency int
+	// MaxAttempts is how many times a batch is tried before the run fails, defaults to 1.
+	MaxAttempts int
+	// RetryDelay is the delay before the first retry of a batch, doubled for every further retry.
+	// Defaults to one second.
+	RetryDelay time.Duration
+	// OnProgress is called after every stored batch. Calls are serialized.
+	OnProgress func(progress EmbeddingPipelineProgress)
+}
+
+// RunEmbeddingPipeline chunks documents, embeds the chunks in concurrent batches and upserts
+// every embedded batch into sink. The first batch failing all its attempts cancels the run
+// and its error is returned; batches already stored stay stored.
+func (c *Client) RunEmbeddingPipeline(
+	ctx context.Context,
+	documents []EmbeddingDocument,
+	sink EmbeddingSink,
+	config EmbeddingPipelineConfig,
+	opts ...RequestOption,
+) error {
+	ctx = withCallOptions(ctx, opts)
+	batches := pipelineBatches(documents, config)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+		progress EmbeddingPipelineProgress
+	)
+	for _, batch := range batches {
+		progress.TotalChunks += len(batch)
+	}
+
+	work := make(chan []EmbeddedChunk)
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultPipelineConcurrency
+	}
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range work {
+				usage, err := c.embedBatchWithRetries(ctx, batch, sink, config)
+
+				mu.Lock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+				} else {
+					progress.DoneChunks += len(batch)
+					progress.Usage.PromptTokens += usage.PromptTokens
+					progress.Usage.TotalTokens += usage.TotalTokens
+					if config.OnProgress != nil {
+						config.OnProgress(progress)
+					}
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, batch := range batches {
+		select {
+		case work <- batch:
+		case <-ctx.Done():
+		}
+	}
+	close(work)
+	wg.Wait()
+
+	if firstErr == nil {
+		firstErr = ctx.Err()
+	}
+	return firstErr
+}
+
+// pipelineBatches splits the documents into chunks and groups the chunks into request batches.
+func pipelineBatches(documents []EmbeddingDocument, config EmbeddingPipelineConfig) (batches [][]EmbeddedChunk) {
+	chunkTokens := config.ChunkTokens
+	if chunkTokens <= 0 {
+		chunkTokens = embeddingTokenLimit(config.Model)
+	}
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultPipelineBatchSize
+	}
+
+	var batch []EmbeddedChunk
+	for _, document := range documents {
+		for i, text := range splitByTokens(document.Text, chunkTokens, config.OverlapTokens) {
+			batch = append(batch, EmbeddedChunk{
+				DocumentID: document.ID,
+				Index:      i,
+				Text:       text,
+				Metadata:   document.Metadata,
+			})
+			if len(batch) == batchSize {
+				batches = append(batches, batch)
+				batch = nil
+			}
+		}
+	}
+	if len(batch) > 0 {
+		batches = append(batches, batch)
+	}
+	return
+}
+
+func (c *Client) embedBatchWithRetries(
+	ctx context.Context,
+	batch []EmbeddedChunk,
+	sink EmbeddingSink,
+	config EmbeddingPipelineConfig,
+) (usage Usage, err error) {
+	delay := config.RetryDelay
+	if delay <= 0 {
+		delay = defaultPipelineRetryDelay
+	}
+	for attempt := 1; ; attempt++ {
+		usage, err = c.embedBatch(ctx, batch, sink, config.Model)
+		if err == nil || attempt >= config.MaxAttempts {
+			return
+		}
+
+		select {
+		case <-time.After(delay):
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		}
+		delay *= 2
+		c.reportRetry(ctx, http.MethodPost, "/embeddings", attempt+1, err)
+	}
+}
+
+func (c *Client) embedBatch(
+	ctx context.Context,
+	batch []EmbeddedChunk,
+	sink EmbeddingSink,
+	model EmbeddingModel,
+) (usage Usage, err error) {
+	request := EmbeddingRequest{Model: model, Input: make([]string, len(batch))}
+	for i, chunk := range batch {
+		request.Input[i] = chunk.Text
+	}
+	resp, err := c.CreateEmbeddings(ctx, request)
+	if err != nil {
+		return
+	}
+
+	vectors, err := embeddingVectors(resp, len(batch))
+	if err != nil {
+		return
+	}
+	embedded := make([]EmbeddedChunk, len(batch))
+	copy(embedded, batch)
+	for i, vector := range vectors {
+		embedded[i].Embedding = vector
+	}
+	err = sink.Upsert(ctx, embedded)
+	usage = resp.Usage
+	return
+}
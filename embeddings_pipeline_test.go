@@ -0,0 +1,114 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+var errTestSinkFailed = errors.New("sink failed")
+
+type memorySink struct {
+	mu       sync.Mutex
+	failures int
+	chunks   map[string]EmbeddedChunk
+}
+
+func (s *memorySink) Upsert(_ context.Context, chunks []EmbeddedChunk) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.failures > 0 {
+		s.failures--
+		return errTestSinkFailed
+	}
+	for _, chunk := range chunks {
+		s.chunks[chunk.DocumentID+"/"+chunk.Text] = chunk
+	}
+	return nil
+}
+
+func TestRunEmbeddingPipeline(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		var req EmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "could not read request", http.StatusBadRequest)
+			return
+		}
+		res := EmbeddingResponse{Model: req.Model, Usage: Usage{PromptTokens: len(req.Input), TotalTokens: len(req.Input)}}
+		for i, input := range req.Input {
+			if input != "drop" {
+				res.Data = append(res.Data, Embedding{Index: i, Embedding: []float32{float32(len(input))}})
+			}
+		}
+		resBytes, _ := json.Marshal(res)
+		_, _ = w.Write(resBytes)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	documents := []EmbeddingDocument{
+		{ID: "a", Text: "w1 w2 w3 w4 w5 w6", Metadata: map[string]string{"source": "a.txt"}},
+		{ID: "b", Text: "short"},
+	}
+	sink := &memorySink{failures: 1, chunks: map[string]EmbeddedChunk{}}
+	var reports []EmbeddingPipelineProgress
+	err := client.RunEmbeddingPipeline(context.Background(), documents, sink, EmbeddingPipelineConfig{
+		Model:       AdaEmbeddingV2,
+		ChunkTokens: 2,
+		BatchSize:   2,
+		Concurrency: 2,
+		MaxAttempts: 2,
+		RetryDelay:  time.Millisecond,
+		OnProgress:  func(progress EmbeddingPipelineProgress) { reports = append(reports, progress) },
+	})
+	checks.NoError(t, err, "RunEmbeddingPipeline error")
+
+	keys := make([]string, 0, len(sink.chunks))
+	for key := range sink.chunks {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	if len(keys) != 4 || keys[0] != "a/w1 w2" || keys[3] != "b/short" {
+		t.Fatalf("Unexpected stored chunks: %q", keys)
+	}
+	if chunk := sink.chunks["a/w5 w6"]; chunk.Index != 2 || chunk.Metadata["source"] != "a.txt" ||
+		len(chunk.Embedding) != 1 || chunk.Embedding[0] != 5 {
+		t.Fatalf("Unexpected chunk: %+v", chunk)
+	}
+	last := reports[len(reports)-1]
+	if len(reports) != 2 || last.TotalChunks != 4 || last.DoneChunks != 4 || last.Usage.PromptTokens != 4 {
+		t.Fatalf("Unexpected progress reports: %+v", reports)
+	}
+
+	sink = &memorySink{failures: 10, chunks: map[string]EmbeddedChunk{}}
+	err = client.RunEmbeddingPipeline(context.Background(), documents, sink, EmbeddingPipelineConfig{
+		Model:       AdaEmbeddingV2,
+		MaxAttempts: 2,
+		RetryDelay:  time.Millisecond,
+	})
+	checks.ErrorIs(t, err, errTestSinkFailed, "RunEmbeddingPipeline should return the sink error")
+
+	sink = &memorySink{chunks: map[string]EmbeddedChunk{}}
+	err = client.RunEmbeddingPipeline(context.Background(), []EmbeddingDocument{
+		{ID: "a", Text: "kept"}, {ID: "b", Text: "drop"},
+	}, sink, EmbeddingPipelineConfig{Model: AdaEmbeddingV2})
+	checks.HasError(t, err, "RunEmbeddingPipeline should fail a batch with missing vectors")
+	if len(sink.chunks) != 0 {
+		t.Fatalf("Chunks without vectors should not be stored: %+v", sink.chunks)
+	}
+}
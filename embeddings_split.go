@@ -0,0 +1,212 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+const (
+	adaEmbeddingV2TokenLimit  = 8191
+	legacyEmbeddingTokenLimit = 2046
+	// The embeddings endpoint accepts at most 2048 inputs and 300k tokens per request.
+	defaultSplitBatchSize   = 2048
+	defaultSplitBatchTokens = 300000
+)
+
+// EmbeddingSplitConfig configures CreateEmbeddingsWithSplitting.
+type EmbeddingSplitConfig struct {
+	// MaxTokens is the maximum size of a chunk, defaults to the input limit of the model.
+	MaxTokens int
+	// OverlapTokens is how many tokens consecutive chunks share, to keep context across the cut.
+	OverlapTokens int
+	// BatchSize is the maximum number of chunks per embeddings request, defaults to 2048.
+	BatchSize int
+	// BatchTokens is the maximum number of estimated tokens per embeddings request, defaults
+	// to 300000.
+	BatchTokens int
+}
+
+// SplitEmbedding holds the embeddings of one input of a split request.
+type SplitEmbedding struct {
+	Index int `json:"index"`
+	// Chunks has one vector per chunk, in input order; inputs within the limit have a single chunk.
+	Chunks [][]float32 `json:"chunks"`
+	// Embedding is the mean of the chunk vectors weighted by their token counts, normalized to unit length.
+	Embedding []float32 `json:"embedding"`
+}
+
+// SplitEmbeddingResponse is the response of CreateEmbeddingsWithSplitting.
+type SplitEmbeddingResponse struct {
+	Data  []SplitEmbedding `json:"data"`
+	Model EmbeddingModel   `json:"model"`
+	Usage Usage            `json:"usage"`
+}
+
+// CreateEmbeddingsWithSplitting embeds request.Input like CreateEmbeddings, but inputs exceeding the
+// token limit of the model are split into overlapping chunks instead of failing the whole call.
+// The chunks are sent in as many sequential requests as the batch limits of config require.
+func (c *Client) CreateEmbeddingsWithSplitting(
+	ctx context.Context,
+	request EmbeddingRequest,
+	config EmbeddingSplitConfig,
+	opts ...RequestOption,
+) (response SplitEmbeddingResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
+	maxTokens := config.MaxTokens
+	if maxTokens <= 0 {
+		maxTokens = embeddingTokenLimit(request.Model)
+	}
+
+	var (
+		chunks      []string
+		chunkTokens []int
+		owners      []int
+	)
+	for i, input := range request.Input {
+		for _, chunk := range splitByTokens(input, maxTokens, config.OverlapTokens) {
+			chunks = append(chunks, chunk)
+			chunkTokens = append(chunkTokens, estimateTokens(chunk))
+			owners = append(owners, i)
+		}
+	}
+
+	response.Data = make([]SplitEmbedding, len(request.Input))
+	for i := range response.Data {
+		response.Data[i].Index = i
+	}
+	vectors := make([][]float32, len(chunks))
+	for _, batch := range splitBatches(chunkTokens, config) {
+		chunkRequest := request
+		chunkRequest.Input = chunks[batch.start:batch.end]
+		var resp EmbeddingResponse
+		if resp, err = c.CreateEmbeddings(ctx, chunkRequest); err != nil {
+			response = SplitEmbeddingResponse{}
+			return
+		}
+		response.Model = resp.Model
+		response.Usage.PromptTokens += resp.Usage.PromptTokens
+		response.Usage.CompletionTokens += resp.Usage.CompletionTokens
+		response.Usage.TotalTokens += resp.Usage.TotalTokens
+
+		var batchVectors [][]float32
+		if batchVectors, err = embeddingVectors(resp, batch.end-batch.start); err != nil {
+			response = SplitEmbeddingResponse{}
+			return
+		}
+		copy(vectors[batch.start:batch.end], batchVectors)
+	}
+
+	weights := make([][]int, len(request.Input))
+	for i, vector := range vectors {
+		owner := owners[i]
+		response.Data[owner].Chunks = append(response.Data[owner].Chunks, vector)
+		weights[owner] = append(weights[owner], chunkTokens[i])
+	}
+	for i := range response.Data {
+		response.Data[i].Embedding = meanPool(response.Data[i].Chunks, weights[i])
+	}
+	return
+}
+
+// embeddingVectors returns the vectors of resp in the order of the n inputs they were requested
+// for, failing when the response does not have exactly one vector per input.
+func embeddingVectors(resp EmbeddingResponse, n int) ([][]float32, error) {
+	if len(resp.Data) != n {
+		return nil, fmt.Errorf("embeddings returned %d vectors for %d inputs", len(resp.Data), n)
+	}
+	vectors, seen := make([][]float32, n), make([]bool, n)
+	for _, embedding := range resp.Data {
+		if embedding.Index < 0 || embedding.Index >= n || seen[embedding.Index] {
+			return nil, fmt.Errorf("embeddings returned an unexpected index %d for %d inputs", embedding.Index, n)
+		}
+		vectors[embedding.Index], seen[embedding.Index] = embedding.Embedding, true
+	}
+	return vectors, nil
+}
+
+// splitBatch is the range of chunks sent in one request.
+type splitBatch struct {
+	start, end int
+}
+
+// splitBatches groups the chunks, given their estimated tokens, into batches within the limits
+// of config. A chunk larger than the token limit gets a batch of its own.
+func splitBatches(chunkTokens []int, config EmbeddingSplitConfig) (batches []splitBatch) {
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultSplitBatchSize
+	}
+	batchTokens := config.BatchTokens
+	if batchTokens <= 0 {
+		batchTokens = defaultSplitBatchTokens
+	}
+
+	batch, tokens := splitBatch{}, 0
+	for i, chunk := range chunkTokens {
+		if batch.end > batch.start && (batch.end-batch.start == batchSize || tokens+chunk > batchTokens) {
+			batches = append(batches, batch)
+			batch, tokens = splitBatch{start: i, end: i}, 0
+		}
+		batch.end++
+		tokens += chunk
+	}
+	if batch.end > batch.start {
+		batches = append(batches, batch)
+	}
+	return
+}
+
+func embeddingTokenLimit(model EmbeddingModel) int {
+	if model == AdaEmbeddingV2 {
+		return adaEmbeddingV2TokenLimit
+	}
+	return legacyEmbeddingTokenLimit
+}
+
+// splitByTokens splits text at word boundaries into chunks of at most maxTokens estimated tokens,
+// consecutive chunks sharing about overlap tokens. Text within the limit is returned as is.
+func splitByTokens(text string, maxTokens, overlap int) []string {
+	if estimateTokens(text) <= maxTokens {
+		return []string{text}
+	}
+
+	chunks := ChunkByTokens(text, maxTokens, overlap)
+	texts := make([]string, len(chunks))
+	for i, chunk := range chunks {
+		texts[i] = chunk.Text
+	}
+	return texts
+}
+
+// meanPool returns the weighted mean of vectors normalized to unit length.
+func meanPool(vectors [][]float32, weights []int) []float32 {
+	if len(vectors) == 1 {
+		return vectors[0]
+	}
+	if len(vectors) == 0 {
+		return nil
+	}
+
+	sum := make([]float64, len(vectors[0]))
+	for i, vector := range vectors {
+		for j, value := range vector {
+			if j < len(sum) {
+				sum[j] += float64(value) * float64(weights[i])
+			}
+		}
+	}
+	var norm float64
+	for _, value := range sum {
+		norm += value * value
+	}
+	norm = math.Sqrt(norm)
+
+	pooled := make([]float32, len(sum))
+	for j, value := range sum {
+		if norm > 0 {
+			pooled[j] = float32(value / norm)
+		}
+	}
+	return pooled
+}
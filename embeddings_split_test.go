@@ -0,0 +1,121 @@
+package openai //nolint:testpackage // testing private field
+
+import (
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestSplitByTokens(t *testing.T) {
+	if chunks := splitByTokens("short text", 10, 2); len(chunks) != 1 || chunks[0] != "short text" {
+		t.Fatalf("Text within the limit should not be split: %q", chunks)
+	}
+
+	// Every word is estimated at 1 token.
+	text := "w1 w2 w3 w4 w5 w6 w7"
+	chunks := splitByTokens(text, 3, 1)
+	expected := []string{"w1 w2 w3", "w3 w4 w5", "w5 w6 w7"}
+	if strings.Join(chunks, "|") != strings.Join(expected, "|") {
+		t.Fatalf("Unexpected chunks: %q", chunks)
+	}
+
+	// Overlaps as large as the chunk must not loop forever.
+	if chunks = splitByTokens(text, 2, 10); len(chunks) != 6 {
+		t.Fatalf("Unexpected chunks: %q", chunks)
+	}
+}
+
+func TestMeanPool(t *testing.T) {
+	pooled := meanPool([][]float32{{1, 0}, {0, 1}}, []int{3, 1})
+	norm := math.Sqrt(10)
+	if math.Abs(float64(pooled[0])-3/norm) > 1e-6 || math.Abs(float64(pooled[1])-1/norm) > 1e-6 {
+		t.Fatalf("Unexpected pooled vector: %v", pooled)
+	}
+}
+
+func TestSplitBatches(t *testing.T) {
+	batches := splitBatches([]int{1, 2, 5, 1, 1, 1}, EmbeddingSplitConfig{BatchSize: 2, BatchTokens: 4})
+	expected := []splitBatch{{0, 2}, {2, 3}, {3, 5}, {5, 6}}
+	if fmt.Sprint(batches) != fmt.Sprint(expected) {
+		t.Fatalf("Unexpected batches %v, expected %v", batches, expected)
+	}
+	if batches = splitBatches(nil, EmbeddingSplitConfig{}); len(batches) != 0 {
+		t.Fatalf("Expected no batches without chunks, got %v", batches)
+	}
+}
+
+func TestCreateEmbeddingsWithSplitting(t *testing.T) {
+	var requests int
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		var req EmbeddingRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "could not read request", http.StatusBadRequest)
+			return
+		}
+		res := EmbeddingResponse{Model: req.Model, Usage: Usage{PromptTokens: len(req.Input)}}
+		// The vectors are returned in reverse order, and none for inputs to drop.
+		for i := len(req.Input) - 1; i >= 0; i-- {
+			vector := []float32{0, 1}
+			if strings.Contains(req.Input[i], "alpha") {
+				vector = []float32{1, 0}
+			}
+			if req.Input[i] != "drop" {
+				res.Data = append(res.Data, Embedding{Index: i, Embedding: vector})
+			}
+		}
+		resBytes, _ := json.Marshal(res)
+		_, _ = w.Write(resBytes)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	resp, err := client.CreateEmbeddingsWithSplitting(context.Background(), EmbeddingRequest{
+		Model: AdaEmbeddingV2,
+		Input: []string{"alpha", "alpha1 alpha2 beta3 beta4"},
+	}, EmbeddingSplitConfig{MaxTokens: 4})
+	checks.NoError(t, err, "CreateEmbeddingsWithSplitting error")
+
+	if len(resp.Data) != 2 || resp.Usage.PromptTokens != 3 {
+		t.Fatalf("Unexpected response: %+v", resp)
+	}
+	if len(resp.Data[0].Chunks) != 1 || resp.Data[0].Embedding[0] != 1 {
+		t.Fatalf("Unsplit input should keep its vector: %+v", resp.Data[0])
+	}
+	split := resp.Data[1]
+	if len(split.Chunks) != 2 || split.Embedding[0] != split.Embedding[1] {
+		t.Fatalf("Split input should be mean-pooled: %+v", split)
+	}
+	if split.Chunks[0][0] != 1 || split.Chunks[1][0] != 0 {
+		t.Fatalf("Chunks should be in input order: %+v", split.Chunks)
+	}
+
+	requests = 0
+	batched, err := client.CreateEmbeddingsWithSplitting(context.Background(), EmbeddingRequest{
+		Model: AdaEmbeddingV2,
+		Input: []string{"alpha", "alpha1 alpha2 beta3 beta4"},
+	}, EmbeddingSplitConfig{MaxTokens: 4, BatchSize: 2})
+	checks.NoError(t, err, "CreateEmbeddingsWithSplitting error")
+	if requests != 2 || batched.Usage.PromptTokens != 3 || fmt.Sprint(batched.Data) != fmt.Sprint(resp.Data) {
+		t.Fatalf("Expected the chunks in 2 requests with the same result, got %d: %+v", requests, batched)
+	}
+
+	_, err = client.CreateEmbeddingsWithSplitting(context.Background(), EmbeddingRequest{
+		Model: AdaEmbeddingV2,
+		Input: []string{"alpha", "drop"},
+	}, EmbeddingSplitConfig{})
+	checks.HasError(t, err, "CreateEmbeddingsWithSplitting should fail when an input gets no vector")
+}
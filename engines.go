@@ -12,16 +12,21 @@ type Engine struct {
 	Object string `json:"object"`
 	Owner  string `json:"owner"`
 	Ready  bool   `json:"ready"`
+
+	ResponseMeta `json:"-"`
 }
 
 // EnginesList is a list of engines.
 type EnginesList struct {
 	Engines []Engine `json:"data"`
+
+	ResponseMeta `json:"-"`
 }
 
 // ListEngines Lists the currently available engines, and provides basic
 // information about each option such as the owner and availability.
-func (c *Client) ListEngines(ctx context.Context) (engines EnginesList, err error) {
+func (c *Client) ListEngines(ctx context.Context, opts ...RequestOption) (engines EnginesList, err error) {
+	ctx = withCallOptions(ctx, opts)
 	req, err := c.requestBuilder.build(ctx, http.MethodGet, c.fullURL("/engines"), nil)
 	if err != nil {
 		return
@@ -36,7 +41,9 @@ func (c *Client) ListEngines(ctx context.Context) (engines EnginesList, err erro
 func (c *Client) GetEngine(
 	ctx context.Context,
 	engineID string,
+	opts ...RequestOption,
 ) (engine Engine, err error) {
+	ctx = withCallOptions(ctx, opts)
 	urlSuffix := fmt.Sprintf("/engines/%s", engineID)
 	req, err := c.requestBuilder.build(ctx, http.MethodGet, c.fullURL(urlSuffix), nil)
 	if err != nil {
@@ -0,0 +1,113 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// FallbackCondition is a set of failure classes a FallbackLink is tried on.
+type FallbackCondition uint
+
+const (
+	// FallbackOnRateLimit falls back on 429 responses.
+	FallbackOnRateLimit FallbackCondition = 1 << iota
+	// FallbackOnServerError falls back on 5xx responses.
+	FallbackOnServerError
+	// FallbackOnContentFilter falls back on content_filter errors and on responses
+	// with a choice finished by the content filter.
+	FallbackOnContentFilter
+	// FallbackOnTimeout falls back on timeouts. The context is shared by the whole chain,
+	// so this only helps with timeouts of the HTTP client, not with context deadlines.
+	FallbackOnTimeout
+)
+
+const contentFilterReason = "content_filter"
+
+// FallbackLink is a step of a fallback chain.
+type FallbackLink struct {
+	// Model replaces the model of the request, empty keeps it.
+	Model string
+	// Client sends the request, e.g. to another provider or deployment; nil uses the same client.
+	// The fallback chain of Client itself is not applied.
+	Client *Client
+	// On is the set of failures of the previous attempt this link is tried on.
+	On FallbackCondition
+}
+
+// FallbackAttempt is an attempt abandoned by a fallback chain.
+type FallbackAttempt struct {
+	Model  string
+	Reason FallbackCondition
+	// Err is the error of the attempt, nil for content filtered responses.
+	Err error
+}
+
+// fallBack walks c.config.FallbackChain while the previous attempt failed in a way the next link
+// falls back on. The result of the last attempt is returned.
+func (c *Client) fallBack(
+	ctx context.Context,
+	request ChatCompletionRequest,
+	response ChatCompletionResponse,
+	err error,
+) (ChatCompletionResponse, error) {
+	var attempts []FallbackAttempt
+	model := request.Model
+	for _, link := range c.config.FallbackChain {
+		reason := fallbackReason(response, err)
+		if reason&link.On == 0 {
+			break
+		}
+		attempts = append(attempts, FallbackAttempt{Model: model, Reason: reason, Err: err})
+
+		client := c
+		if link.Client != nil {
+			client = link.Client
+		}
+		if link.Model != "" {
+			request.Model = link.Model
+		}
+		model = request.Model
+		c.reportRetry(ctx, http.MethodPost, "/chat/completions", len(attempts)+1, err)
+		response, err = client.createChatCompletion(ctx, request)
+	}
+	response.Fallbacks = attempts
+	return response, err
+}
+
+// fallbackReason classifies the result of an attempt, returning zero for results to keep.
+func fallbackReason(response ChatCompletionResponse, err error) FallbackCondition {
+	if err == nil {
+		for _, choice := range response.Choices {
+			if choice.FinishReason == contentFilterReason {
+				return FallbackOnContentFilter
+			}
+		}
+		return 0
+	}
+
+	var (
+		apiErr *APIError
+		reqErr *RequestError
+	)
+	statusCode := 0
+	switch {
+	case errors.As(err, &apiErr):
+		if code, ok := apiErr.Code.(string); ok && code == contentFilterReason {
+			return FallbackOnContentFilter
+		}
+		statusCode = apiErr.HTTPStatusCode
+	case errors.As(err, &reqErr):
+		statusCode = reqErr.HTTPStatusCode
+	case isTimeout(err):
+		return FallbackOnTimeout
+	}
+
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		return FallbackOnRateLimit
+	case statusCode >= http.StatusInternalServerError:
+		return FallbackOnServerError
+	}
+	return 0
+}
@@ -0,0 +1,85 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestFallbackChain(t *testing.T) {
+	var models []string
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		req, _ := getChatCompletionBody(r)
+		models = append(models, req.Model)
+		switch req.Model {
+		case GPT4:
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"message":"Rate limit reached","type":"requests"}}`))
+		case GPT3Dot5Turbo:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			finishReason := "stop"
+			if req.Model == GPT3Dot5Turbo0301 {
+				finishReason = "content_filter"
+			}
+			res := ChatCompletionResponse{
+				Model:   req.Model,
+				Choices: []ChatCompletionChoice{{FinishReason: finishReason}},
+			}
+			resBytes, _ := json.Marshal(res)
+			_, _ = w.Write(resBytes)
+		}
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	provider := NewClientWithConfig(config)
+	config.FallbackChain = []FallbackLink{
+		{Model: GPT3Dot5Turbo, On: FallbackOnRateLimit},
+		{Model: GPT3Dot5Turbo0301, On: FallbackOnServerError},
+		{Model: GPT432K, Client: provider, On: FallbackOnContentFilter},
+	}
+	client := NewClientWithConfig(config)
+
+	request := ChatCompletionRequest{
+		Model:    GPT4,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hello!"}},
+	}
+	response, err := client.CreateChatCompletion(context.Background(), request)
+	checks.NoError(t, err, "CreateChatCompletion should be served by the last link")
+	if response.Model != GPT432K || len(models) != 4 {
+		t.Fatalf("Unexpected model %s after trying %v", response.Model, models)
+	}
+	reasons := []FallbackCondition{FallbackOnRateLimit, FallbackOnServerError, FallbackOnContentFilter}
+	if len(response.Fallbacks) != len(reasons) {
+		t.Fatalf("Unexpected fallbacks: %+v", response.Fallbacks)
+	}
+	for i, attempt := range response.Fallbacks {
+		if attempt.Model != models[i] || attempt.Reason != reasons[i] {
+			t.Fatalf("Unexpected fallback %d: %+v", i, attempt)
+		}
+	}
+	var apiErr *APIError
+	if !errors.As(response.Fallbacks[0].Err, &apiErr) || apiErr.HTTPStatusCode != http.StatusTooManyRequests {
+		t.Fatalf("The rate limit error should be reported: %v", response.Fallbacks[0].Err)
+	}
+
+	// Failures no link falls back on are returned as is.
+	models = nil
+	request.Model = GPT3Dot5Turbo
+	_, err = client.CreateChatCompletion(context.Background(), request)
+	checks.HasError(t, err, "CreateChatCompletion should fail")
+	if len(models) != 1 {
+		t.Fatalf("A 503 should not trigger the rate limit link: %v", models)
+	}
+}
@@ -0,0 +1,164 @@
+package openai
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Purposes of uploaded files.
+const (
+	FilePurposeFineTune   = "fine-tune"
+	FilePurposeBatch      = "batch"
+	FilePurposeAssistants = "assistants"
+	FilePurposeVision     = "vision"
+	FilePurposeUserData   = "user_data"
+)
+
+// Upload limits of the files API.
+const (
+	FileUploadLimit      = 512 << 20
+	BatchFileUploadLimit = 200 << 20
+	batchMaxRequests     = 50000
+	// jsonlMaxLineSize bounds the lines read while validating JSONL files.
+	jsonlMaxLineSize = 64 << 20
+)
+
+var ErrFileUploadInvalid = errors.New("file is not valid for its purpose")
+
+// filePurposeExtensions lists the extensions accepted for purposes that restrict them.
+var filePurposeExtensions = map[string][]string{
+	FilePurposeFineTune: {".jsonl"},
+	FilePurposeBatch:    {".jsonl"},
+	FilePurposeVision:   {".gif", ".jpeg", ".jpg", ".png", ".webp"},
+	FilePurposeAssistants: {
+		".c", ".cpp", ".cs", ".css", ".csv", ".doc", ".docx", ".gif", ".go", ".html", ".java", ".jpeg",
+		".jpg", ".js", ".json", ".md", ".pdf", ".php", ".pkl", ".png", ".pptx", ".py", ".rb", ".sh",
+		".tar", ".tex", ".ts", ".txt", ".webp", ".xlsx", ".xml", ".zip",
+	},
+}
+
+// ValidateFileUpload checks the file of request against the limits of its purpose before it
+// is uploaded: the size, the extension and, for fine-tune and batch files, that every line of
+// the JSONL file has the expected shape. Files of other purposes are only checked for size.
+// CreateFile validates the uploads when ClientConfig.ValidateFileUploads is set.
+func ValidateFileUpload(request FileRequest) error {
+	info, err := os.Stat(request.FilePath)
+	if err != nil {
+		return err
+	}
+	name := filepath.Base(request.FilePath)
+	limit := int64(FileUploadLimit)
+	if request.Purpose == FilePurposeBatch {
+		limit = BatchFileUploadLimit
+	}
+	if info.Size() > limit {
+		return fmt.Errorf("%w: %s is %.1f MiB, %s files are limited to %d MiB",
+			ErrFileUploadInvalid, name, float64(info.Size())/(1<<20), request.Purpose, limit>>20)
+	}
+
+	if extensions, ok := filePurposeExtensions[request.Purpose]; ok {
+		ext := strings.ToLower(filepath.Ext(name))
+		if !containsString(extensions, ext) {
+			return fmt.Errorf("%w: %s files must be one of %s, not %s",
+				ErrFileUploadInvalid, request.Purpose, strings.Join(extensions, ", "), name)
+		}
+	}
+
+	switch request.Purpose {
+	case FilePurposeFineTune:
+		return validateJSONL(request.FilePath, 0, validateFineTuneLine)
+	case FilePurposeBatch:
+		return validateJSONL(request.FilePath, batchMaxRequests, validateBatchLine)
+	}
+	return nil
+}
+
+// validateJSONL checks every non-empty line of the file at path with validateLine, and that
+// there are at most maxLines of them if maxLines is positive.
+func validateJSONL(path string, maxLines int, validateLine func(line map[string]json.RawMessage) error) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	name := filepath.Base(path)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64<<10), jsonlMaxLineSize)
+	lines := 0
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		if lines++; maxLines > 0 && lines > maxLines {
+			return fmt.Errorf("%w: %s has more than %d requests", ErrFileUploadInvalid, name, maxLines)
+		}
+		var line map[string]json.RawMessage
+		if err = json.Unmarshal([]byte(text), &line); err != nil {
+			return fmt.Errorf("%w: %s line %d is not a JSON object: %s", ErrFileUploadInvalid, name, number, err.Error())
+		}
+		if err = validateLine(line); err != nil {
+			return fmt.Errorf("%w: %s line %d %s", ErrFileUploadInvalid, name, number, err.Error())
+		}
+	}
+	if err = scanner.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: reading %s: %s", ErrFileUploadInvalid, name, err.Error())
+	}
+	if lines == 0 {
+		return fmt.Errorf("%w: %s is empty", ErrFileUploadInvalid, name)
+	}
+	return nil
+}
+
+// validateFineTuneLine checks the lines in a documented fine-tune format: chat examples with
+// messages, preference examples with input, preferred_output and non_preferred_output, and
+// prompt/completion pairs. Lines in other shapes are left for the API to judge.
+func validateFineTuneLine(line map[string]json.RawMessage) error {
+	if messages, ok := line["messages"]; ok {
+		var parsed []ChatCompletionMessage
+		if err := json.Unmarshal(messages, &parsed); err != nil || len(parsed) == 0 {
+			return errors.New("must have a non-empty messages array")
+		}
+		return nil
+	}
+	if _, ok := line["input"]; ok {
+		for _, key := range []string{"preferred_output", "non_preferred_output"} {
+			if _, ok = line[key]; !ok {
+				return fmt.Errorf("has a preference input without %s", key)
+			}
+		}
+		return nil
+	}
+	if _, ok := line["prompt"]; ok {
+		if _, ok = line["completion"]; !ok {
+			return errors.New("has a prompt without a completion")
+		}
+	}
+	return nil
+}
+
+// validateBatchLine accepts requests with a custom_id, method, url and body.
+func validateBatchLine(line map[string]json.RawMessage) error {
+	for _, key := range []string{"custom_id", "method", "url", "body"} {
+		if _, ok := line[key]; !ok {
+			return fmt.Errorf("is missing %s", key)
+		}
+	}
+	return nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
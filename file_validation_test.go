@@ -0,0 +1,153 @@
+package openai_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+)
+
+func writeUploadFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	err := os.WriteFile(path, []byte(content), 0o600)
+	checks.NoError(t, err, "WriteFile error")
+	return path
+}
+
+func TestValidateFileUpload(t *testing.T) {
+	cases := []struct {
+		name    string
+		file    string
+		content string
+		purpose string
+		invalid string
+	}{
+		{
+			name:    "fine-tune chat",
+			file:    "train.jsonl",
+			content: `{"messages":[{"role":"user","content":"hi"},{"role":"assistant","content":"hello"}]}` + "\n",
+			purpose: FilePurposeFineTune,
+		},
+		{
+			name:    "fine-tune wrong extension",
+			file:    "train.txt",
+			content: `{"prompt":"a","completion":"b"}`,
+			purpose: FilePurposeFineTune,
+			invalid: "must be one of .jsonl",
+		},
+		{
+			name:    "fine-tune bad line",
+			file:    "train.jsonl",
+			content: `{"prompt":"a","completion":"b"}` + "\n\n" + `{"prompt":"a"}` + "\n",
+			purpose: FilePurposeFineTune,
+			invalid: "line 3 has a prompt without a completion",
+		},
+		{
+			name: "fine-tune preference",
+			file: "train.jsonl",
+			content: `{"input":{"messages":[{"role":"user","content":"hi"}]},` +
+				`"preferred_output":[{"role":"assistant","content":"hello"}],` +
+				`"non_preferred_output":[{"role":"assistant","content":"go away"}]}` + "\n",
+			purpose: FilePurposeFineTune,
+		},
+		{
+			name:    "fine-tune preference without rejected output",
+			file:    "train.jsonl",
+			content: `{"input":{"messages":[]},"preferred_output":[]}`,
+			purpose: FilePurposeFineTune,
+			invalid: "line 1 has a preference input without non_preferred_output",
+		},
+		{
+			name:    "fine-tune unknown shape",
+			file:    "train.jsonl",
+			content: `{"example":"left for the API"}`,
+			purpose: FilePurposeFineTune,
+		},
+		{
+			name:    "fine-tune not json",
+			file:    "train.jsonl",
+			content: "prompt,completion\n",
+			purpose: FilePurposeFineTune,
+			invalid: "line 1 is not a JSON object",
+		},
+		{
+			name:    "fine-tune empty",
+			file:    "train.jsonl",
+			content: "\n",
+			purpose: FilePurposeFineTune,
+			invalid: "is empty",
+		},
+		{
+			name:    "batch",
+			file:    "batch.jsonl",
+			content: `{"custom_id":"1","method":"POST","url":"/v1/chat/completions","body":{}}`,
+			purpose: FilePurposeBatch,
+		},
+		{
+			name:    "batch missing custom_id",
+			file:    "batch.jsonl",
+			content: `{"method":"POST","url":"/v1/chat/completions","body":{}}`,
+			purpose: FilePurposeBatch,
+			invalid: "line 1 is missing custom_id",
+		},
+		{
+			name:    "vision",
+			file:    "cat.PNG",
+			content: "png",
+			purpose: FilePurposeVision,
+		},
+		{
+			name:    "vision wrong extension",
+			file:    "cat.bmp",
+			content: "bmp",
+			purpose: FilePurposeVision,
+			invalid: "not cat.bmp",
+		},
+		{
+			name:    "assistants",
+			file:    "notes.md",
+			content: "# notes",
+			purpose: FilePurposeAssistants,
+		},
+		{
+			name:    "unrestricted purpose",
+			file:    "data.bin",
+			content: "data",
+			purpose: FilePurposeUserData,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			path := writeUploadFile(t, tc.file, tc.content)
+			err := ValidateFileUpload(FileRequest{FileName: tc.file, FilePath: path, Purpose: tc.purpose})
+			if tc.invalid == "" {
+				checks.NoError(t, err, "ValidateFileUpload error")
+				return
+			}
+			if !errors.Is(err, ErrFileUploadInvalid) {
+				t.Fatalf("expected ErrFileUploadInvalid, got %v", err)
+			}
+			if !strings.Contains(err.Error(), tc.invalid) {
+				t.Fatalf("expected error to contain %q, got %q", tc.invalid, err.Error())
+			}
+		})
+	}
+}
+
+func TestCreateFileValidatesBeforeUpload(t *testing.T) {
+	config := DefaultConfig("token")
+	config.ValidateFileUploads = true
+	client := NewClientWithConfig(config)
+	path := writeUploadFile(t, "train.jsonl", `{"prompt":"a"}`)
+	// The file is rejected before any request to the default base URL is made.
+	_, err := client.CreateFile(context.Background(), FileRequest{FilePath: path, Purpose: FilePurposeFineTune})
+	if !errors.Is(err, ErrFileUploadInvalid) {
+		t.Fatalf("expected ErrFileUploadInvalid, got %v", err)
+	}
+}
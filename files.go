@@ -23,16 +23,28 @@ type File struct {
 	Object    string `json:"object"`
 	Owner     string `json:"owner"`
 	Purpose   string `json:"purpose"`
+
+	ResponseMeta `json:"-"`
 }
 
 // FilesList is a list of files that belong to the user or organization.
 type FilesList struct {
 	Files []File `json:"data"`
+
+	ResponseMeta `json:"-"`
 }
 
 // CreateFile uploads a jsonl file to GPT3
-// FilePath must be a local file path.
-func (c *Client) CreateFile(ctx context.Context, request FileRequest) (file File, err error) {
+// FilePath must be a local file path. The file is checked with ValidateFileUpload first
+// when ClientConfig.ValidateFileUploads is set.
+func (c *Client) CreateFile(ctx context.Context, request FileRequest, opts ...RequestOption) (file File, err error) {
+	ctx = withCallOptions(ctx, opts)
+	if c.config.ValidateFileUploads {
+		if err = ValidateFileUpload(request); err != nil {
+			return
+		}
+	}
+
 	var b bytes.Buffer
 	builder := c.createFormBuilder(&b)
 
@@ -45,6 +57,7 @@ func (c *Client) CreateFile(ctx context.Context, request FileRequest) (file File
 	if err != nil {
 		return
 	}
+	defer fileData.Close()
 
 	err = builder.createFormFile("file", fileData)
 	if err != nil {
@@ -69,7 +82,8 @@ func (c *Client) CreateFile(ctx context.Context, request FileRequest) (file File
 }
 
 // DeleteFile deletes an existing file.
-func (c *Client) DeleteFile(ctx context.Context, fileID string) (err error) {
+func (c *Client) DeleteFile(ctx context.Context, fileID string, opts ...RequestOption) (err error) {
+	ctx = withCallOptions(ctx, opts)
 	req, err := c.requestBuilder.build(ctx, http.MethodDelete, c.fullURL("/files/"+fileID), nil)
 	if err != nil {
 		return
@@ -81,7 +95,8 @@ func (c *Client) DeleteFile(ctx context.Context, fileID string) (err error) {
 
 // ListFiles Lists the currently available files,
 // and provides basic information about each file such as the file name and purpose.
-func (c *Client) ListFiles(ctx context.Context) (files FilesList, err error) {
+func (c *Client) ListFiles(ctx context.Context, opts ...RequestOption) (files FilesList, err error) {
+	ctx = withCallOptions(ctx, opts)
 	req, err := c.requestBuilder.build(ctx, http.MethodGet, c.fullURL("/files"), nil)
 	if err != nil {
 		return
@@ -93,7 +108,8 @@ func (c *Client) ListFiles(ctx context.Context) (files FilesList, err error) {
 
 // GetFile Retrieves a file instance, providing basic information about the file
 // such as the file name and purpose.
-func (c *Client) GetFile(ctx context.Context, fileID string) (file File, err error) {
+func (c *Client) GetFile(ctx context.Context, fileID string, opts ...RequestOption) (file File, err error) {
+	ctx = withCallOptions(ctx, opts)
 	urlSuffix := fmt.Sprintf("/files/%s", fileID)
 	req, err := c.requestBuilder.build(ctx, http.MethodGet, c.fullURL(urlSuffix), nil)
 	if err != nil {
@@ -103,3 +119,16 @@ func (c *Client) GetFile(ctx context.Context, fileID string) (file File, err err
 	err = c.sendRequest(req, &file)
 	return
 }
+
+// GetFileContent returns the contents of a file, e.g. the results of a fine-tune.
+func (c *Client) GetFileContent(ctx context.Context, fileID string, opts ...RequestOption) (content []byte, err error) {
+	ctx = withCallOptions(ctx, opts)
+	urlSuffix := fmt.Sprintf("/files/%s/content", fileID)
+	req, err := c.requestBuilder.build(ctx, http.MethodGet, c.fullURL(urlSuffix), nil)
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &content)
+	return
+}
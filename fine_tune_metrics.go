@@ -0,0 +1,191 @@
+package openai
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrFineTuneNoResultFiles = errors.New("fine-tune has no result files yet")
+)
+
+// metricField returns the typed field of m the result file column is parsed into, or nil.
+// Fine-tunes and fine-tuning jobs name the same metrics differently.
+func metricField(m *FineTuneMetric, column string) **float64 {
+	switch column {
+	case "train_loss", "training_loss":
+		return &m.TrainLoss
+	case "train_accuracy", "train_mean_token_accuracy", "training_token_accuracy":
+		return &m.TrainAccuracy
+	case "valid_loss", "validation_loss":
+		return &m.ValidLoss
+	case "valid_accuracy", "valid_mean_token_accuracy", "validation_token_accuracy":
+		return &m.ValidAccuracy
+	case "full_valid_loss":
+		return &m.FullValidLoss
+	case "full_valid_mean_token_accuracy":
+		return &m.FullValidAccuracy
+	}
+	return nil
+}
+
+// FineTuneMetric is a row of a fine-tune result file. Metrics missing from the row,
+// e.g. validation metrics on steps without validation, are nil.
+type FineTuneMetric struct {
+	Step              int      `json:"step"`
+	TrainLoss         *float64 `json:"train_loss,omitempty"`
+	TrainAccuracy     *float64 `json:"train_accuracy,omitempty"`
+	ValidLoss         *float64 `json:"valid_loss,omitempty"`
+	ValidAccuracy     *float64 `json:"valid_accuracy,omitempty"`
+	FullValidLoss     *float64 `json:"full_valid_loss,omitempty"`
+	FullValidAccuracy *float64 `json:"full_valid_accuracy,omitempty"`
+	// Extra holds the other numeric columns, e.g. elapsed_tokens or classification metrics.
+	Extra map[string]float64 `json:"extra,omitempty"`
+}
+
+// FineTuneMetrics is the series of metrics of a fine-tune, ordered by step.
+type FineTuneMetrics []FineTuneMetric
+
+// Series returns the steps that reported the metric selected by value, and their values,
+// e.g. metrics.Series(func(m FineTuneMetric) *float64 { return m.ValidLoss }).
+func (m FineTuneMetrics) Series(value func(metric FineTuneMetric) *float64) (steps []int, values []float64) {
+	for _, metric := range m {
+		if v := value(metric); v != nil {
+			steps = append(steps, metric.Step)
+			values = append(values, *v)
+		}
+	}
+	return
+}
+
+// ParseFineTuneMetrics parses a fine-tune result file, either CSV with a header row
+// or JSON lines with one object per step.
+func ParseFineTuneMetrics(data []byte) (metrics FineTuneMetrics, err error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) > 0 && trimmed[0] == '{' {
+		return parseFineTuneMetricsJSONL(trimmed)
+	}
+	return parseFineTuneMetricsCSV(trimmed)
+}
+
+func parseFineTuneMetricsCSV(data []byte) (metrics FineTuneMetrics, err error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return
+	}
+
+	for line := 2; ; line++ {
+		var record []string
+		record, err = reader.Read()
+		if errors.Is(err, io.EOF) {
+			return metrics, nil
+		}
+		if err != nil {
+			return
+		}
+
+		values := make(map[string]string, len(header))
+		for i, column := range header {
+			if i < len(record) {
+				values[strings.TrimSpace(column)] = strings.TrimSpace(record[i])
+			}
+		}
+		var metric FineTuneMetric
+		if metric, err = newFineTuneMetric(values); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		metrics = append(metrics, metric)
+	}
+}
+
+func parseFineTuneMetricsJSONL(data []byte) (metrics FineTuneMetrics, err error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(nil, 1<<20)
+	for line := 1; scanner.Scan(); line++ {
+		if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+			continue
+		}
+
+		var row map[string]json.RawMessage
+		if err = json.Unmarshal(scanner.Bytes(), &row); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		values := make(map[string]string, len(row))
+		for key, value := range row {
+			values[key] = strings.Trim(string(value), `"`)
+		}
+		var metric FineTuneMetric
+		if metric, err = newFineTuneMetric(values); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		metrics = append(metrics, metric)
+	}
+	err = scanner.Err()
+	return
+}
+
+// newFineTuneMetric converts a row of column values. Empty, null, NaN and non-numeric
+// values are treated as missing; only the step is required.
+func newFineTuneMetric(values map[string]string) (metric FineTuneMetric, err error) {
+	metric.Step, err = strconv.Atoi(values["step"])
+	if err != nil {
+		err = fmt.Errorf("invalid step %q", values["step"])
+		return
+	}
+
+	for column, raw := range values {
+		if column == "step" {
+			continue
+		}
+		value, parseErr := strconv.ParseFloat(raw, 64)
+		if parseErr != nil || math.IsNaN(value) {
+			continue
+		}
+		if field := metricField(&metric, column); field != nil {
+			v := value
+			*field = &v
+			continue
+		}
+		if metric.Extra == nil {
+			metric.Extra = make(map[string]float64)
+		}
+		metric.Extra[column] = value
+	}
+	return
+}
+
+// GetFineTuneMetrics downloads and parses the result file of a fine-tune.
+func (c *Client) GetFineTuneMetrics(
+	ctx context.Context,
+	fineTuneID string,
+	opts ...RequestOption,
+) (metrics FineTuneMetrics, err error) {
+	ctx = withCallOptions(ctx, opts)
+	fineTune, err := c.GetFineTune(ctx, fineTuneID)
+	if err != nil {
+		return
+	}
+	if len(fineTune.ResultFiles) == 0 {
+		err = fmt.Errorf("%w: %s is %s", ErrFineTuneNoResultFiles, fineTuneID, fineTune.Status)
+		return
+	}
+
+	content, err := c.GetFileContent(ctx, fineTune.ResultFiles[0].ID)
+	if err != nil {
+		return
+	}
+	return ParseFineTuneMetrics(content)
+}
@@ -0,0 +1,75 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+const testFineTuneResults = `step,elapsed_tokens,training_loss,training_token_accuracy,validation_loss
+1,1024,2.5,0.5,3.0
+2,2048,1.5,0.75,
+`
+
+func TestParseFineTuneMetrics(t *testing.T) {
+	metrics, err := ParseFineTuneMetrics([]byte(testFineTuneResults))
+	checks.NoError(t, err, "ParseFineTuneMetrics error")
+	if len(metrics) != 2 || metrics[1].Step != 2 || *metrics[1].TrainLoss != 1.5 || metrics[1].ValidLoss != nil {
+		t.Fatalf("Unexpected metrics: %+v", metrics)
+	}
+	if metrics[0].Extra["elapsed_tokens"] != 1024 || *metrics[0].TrainAccuracy != 0.5 {
+		t.Fatalf("Unexpected metric: %+v", metrics[0])
+	}
+	steps, values := metrics.Series(func(m FineTuneMetric) *float64 { return m.ValidLoss })
+	if len(steps) != 1 || steps[0] != 1 || values[0] != 3 {
+		t.Fatalf("Unexpected validation loss series: %v %v", steps, values)
+	}
+
+	jsonl := `{"step":1,"train_loss":0.9,"valid_mean_token_accuracy":null}
+{"step":2,"train_loss":0.4,"valid_mean_token_accuracy":0.8}`
+	metrics, err = ParseFineTuneMetrics([]byte(jsonl))
+	checks.NoError(t, err, "ParseFineTuneMetrics error")
+	if len(metrics) != 2 || metrics[0].ValidAccuracy != nil || *metrics[1].ValidAccuracy != 0.8 {
+		t.Fatalf("Unexpected metrics: %+v", metrics)
+	}
+
+	_, err = ParseFineTuneMetrics([]byte("step,train_loss\nlast,1\n"))
+	checks.HasError(t, err, "ParseFineTuneMetrics should fail on invalid steps")
+}
+
+func TestGetFineTuneMetrics(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/fine-tunes/"+testFineTuneID, func(w http.ResponseWriter, r *http.Request) {
+		resBytes, _ := json.Marshal(FineTune{ID: testFineTuneID, ResultFiles: []File{{ID: "file-results"}}})
+		_, _ = w.Write(resBytes)
+	})
+	server.RegisterHandler("/v1/fine-tunes/pending", func(w http.ResponseWriter, r *http.Request) {
+		resBytes, _ := json.Marshal(FineTune{ID: "pending", Status: "pending"})
+		_, _ = w.Write(resBytes)
+	})
+	server.RegisterHandler("/v1/files/file-results/content", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(testFineTuneResults))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	metrics, err := client.GetFineTuneMetrics(ctx, testFineTuneID)
+	checks.NoError(t, err, "GetFineTuneMetrics error")
+	if len(metrics) != 2 {
+		t.Fatalf("Unexpected metrics: %+v", metrics)
+	}
+
+	_, err = client.GetFineTuneMetrics(ctx, "pending")
+	checks.ErrorIs(t, err, ErrFineTuneNoResultFiles, "GetFineTuneMetrics should fail without result files")
+}
@@ -0,0 +1,136 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const (
+	fineTuneStatusSucceeded    = "succeeded"
+	defaultSmokeCheckMaxTokens = 64
+	fineTunedChatModelPrefix   = "ft:"
+)
+
+var (
+	ErrFineTuneNotSucceeded = errors.New("fine-tune has not succeeded")
+	ErrSmokeCheckNoChoices  = errors.New("smoke check reply has no choices")
+)
+
+// SmokeCheck is a prompt whose reply from a fine-tuned model must match Expect.
+type SmokeCheck struct {
+	Name   string
+	Prompt string
+	Expect *regexp.Regexp
+	// Reject, if set, fails the check when the reply matches it, e.g. a refusal or the
+	// formatting of the base model.
+	Reject *regexp.Regexp
+}
+
+// SmokeTestOptions configures SmokeTestFineTune.
+type SmokeTestOptions struct {
+	// System is the system message of the checks of chat models.
+	System string
+	// MaxTokens bounds the replies, 64 tokens by default.
+	MaxTokens   int
+	Temperature float32
+}
+
+// SmokeCheckResult is the outcome of a SmokeCheck.
+type SmokeCheckResult struct {
+	Name   string
+	Reply  string
+	Passed bool
+	// Err is set when the model could not be called; the check failed.
+	Err     error
+	Latency time.Duration
+}
+
+// SmokeTestReport is the outcome of SmokeTestFineTune.
+type SmokeTestReport struct {
+	FineTuneID string
+	Model      string
+	Results    []SmokeCheckResult
+	// Passed reports whether every check passed.
+	Passed bool
+}
+
+// SmokeTestFineTune runs checks against the model of a succeeded fine-tune and reports which
+// replies matched, e.g. to gate the promotion of the model in CI. Models fine-tuned from chat
+// models are called with CreateChatCompletion, others with CreateCompletion. A failing call
+// fails its check without stopping the others; err is only set when the fine-tune cannot be
+// tested at all.
+func (c *Client) SmokeTestFineTune(
+	ctx context.Context,
+	fineTuneID string,
+	checks []SmokeCheck,
+	options SmokeTestOptions,
+	opts ...RequestOption,
+) (report SmokeTestReport, err error) {
+	ctx = withCallOptions(ctx, opts)
+	fineTune, err := c.GetFineTune(ctx, fineTuneID)
+	if err != nil {
+		return
+	}
+	if fineTune.Status != fineTuneStatusSucceeded || fineTune.FineTunedModel == "" {
+		err = fmt.Errorf("%w: %s is %s", ErrFineTuneNotSucceeded, fineTuneID, fineTune.Status)
+		return
+	}
+	if options.MaxTokens <= 0 {
+		options.MaxTokens = defaultSmokeCheckMaxTokens
+	}
+
+	report = SmokeTestReport{FineTuneID: fineTuneID, Model: fineTune.FineTunedModel, Passed: true}
+	for _, check := range checks {
+		result := SmokeCheckResult{Name: check.Name}
+		start := time.Now()
+		result.Reply, result.Err = c.smokeReply(ctx, fineTune.FineTunedModel, check.Prompt, options)
+		result.Latency = time.Since(start)
+		result.Passed = result.Err == nil && (check.Expect == nil || check.Expect.MatchString(result.Reply)) &&
+			(check.Reject == nil || !check.Reject.MatchString(result.Reply))
+		report.Passed = report.Passed && result.Passed
+		report.Results = append(report.Results, result)
+	}
+	return
+}
+
+// smokeReply returns the reply of model to prompt.
+func (c *Client) smokeReply(ctx context.Context, model, prompt string, options SmokeTestOptions) (string, error) {
+	if !strings.HasPrefix(model, fineTunedChatModelPrefix) {
+		response, err := c.CreateCompletion(ctx, CompletionRequest{
+			Model:       model,
+			Prompt:      prompt,
+			MaxTokens:   options.MaxTokens,
+			Temperature: options.Temperature,
+		})
+		if err != nil {
+			return "", err
+		}
+		if len(response.Choices) == 0 {
+			return "", ErrSmokeCheckNoChoices
+		}
+		return response.Choices[0].Text, nil
+	}
+
+	var messages []ChatCompletionMessage
+	if options.System != "" {
+		messages = append(messages, ChatCompletionMessage{Role: ChatMessageRoleSystem, Content: options.System})
+	}
+	messages = append(messages, ChatCompletionMessage{Role: ChatMessageRoleUser, Content: prompt})
+	response, err := c.CreateChatCompletion(ctx, ChatCompletionRequest{
+		Model:       model,
+		Messages:    messages,
+		MaxTokens:   options.MaxTokens,
+		Temperature: options.Temperature,
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(response.Choices) == 0 {
+		return "", ErrSmokeCheckNoChoices
+	}
+	return response.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,87 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+)
+
+func TestSmokeTestFineTune(t *testing.T) {
+	server := test.NewTestServer()
+	for id, fineTune := range map[string]FineTune{
+		"ft-chat":    {Status: "succeeded", FineTunedModel: "ft:gpt-3.5-turbo:org:support:1"},
+		"ft-legacy":  {Status: "succeeded", FineTunedModel: "davinci:ft-org-2023"},
+		"ft-pending": {Status: "pending"},
+	} {
+		fineTune.ID = id
+		resBytes, _ := json.Marshal(fineTune)
+		server.RegisterHandler("/v1/fine-tunes/"+id, func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(resBytes)
+		})
+	}
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		req, err := getChatCompletionBody(r)
+		if err != nil || len(req.Messages) != 2 || req.Messages[0].Role != ChatMessageRoleSystem {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		if req.Messages[1].Content == "fail" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"error":{"message":"bad prompt"}}`))
+			return
+		}
+		if req.Messages[1].Content == "empty" {
+			_, _ = w.Write([]byte(`{"id":"1","created":1,"choices":[]}`))
+			return
+		}
+		reply, _ := json.Marshal("Reply to " + req.Messages[1].Content)
+		fmt.Fprintf(w, `{"id":"1","created":1,"choices":[{"message":{"role":"assistant","content":%s}}]}`, reply)
+	})
+	server.RegisterHandler("/v1/completions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"1","choices":[{"text":" 42"}]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	smokeChecks := []SmokeCheck{
+		{Name: "greeting", Prompt: "hello", Expect: regexp.MustCompile(`^Reply to hello$`)},
+		{Name: "rejected", Prompt: "sorry", Reject: regexp.MustCompile(`sorry`)},
+		{Name: "error", Prompt: "fail"},
+		{Name: "empty", Prompt: "empty"},
+	}
+	report, err := client.SmokeTestFineTune(ctx, "ft-chat", smokeChecks, SmokeTestOptions{System: "Be brief."})
+	checks.NoError(t, err, "SmokeTestFineTune error")
+	if report.Passed || report.Model != "ft:gpt-3.5-turbo:org:support:1" || len(report.Results) != 4 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	results := report.Results
+	if !results[0].Passed || results[1].Passed || results[1].Reply != "Reply to sorry" ||
+		results[2].Passed || results[2].Err == nil {
+		t.Fatalf("Unexpected results: %+v", results)
+	}
+	checks.ErrorIs(t, results[3].Err, ErrSmokeCheckNoChoices, "a reply without choices should fail its check")
+
+	report, err = client.SmokeTestFineTune(ctx, "ft-legacy", []SmokeCheck{
+		{Name: "answer", Prompt: "6*7=", Expect: regexp.MustCompile(`42`)},
+	}, SmokeTestOptions{})
+	checks.NoError(t, err, "SmokeTestFineTune error")
+	if !report.Passed || report.Results[0].Reply != " 42" {
+		t.Fatalf("Unexpected legacy report: %+v", report)
+	}
+
+	_, err = client.SmokeTestFineTune(ctx, "ft-pending", smokeChecks, SmokeTestOptions{})
+	checks.ErrorIs(t, err, ErrFineTuneNotSucceeded, "SmokeTestFineTune should reject pending fine-tunes")
+}
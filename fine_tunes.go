@@ -35,6 +35,8 @@ type FineTune struct {
 	ValidationFiles   []File              `json:"validation_files"`
 	TrainingFiles     []File              `json:"training_files"`
 	UpdatedAt         int64               `json:"updated_at"`
+
+	ResponseMeta `json:"-"`
 }
 
 type FineTuneEvent struct {
@@ -54,19 +56,30 @@ type FineTuneHyperParams struct {
 type FineTuneList struct {
 	Object string     `json:"object"`
 	Data   []FineTune `json:"data"`
+
+	ResponseMeta `json:"-"`
 }
 type FineTuneEventList struct {
 	Object string          `json:"object"`
 	Data   []FineTuneEvent `json:"data"`
+
+	ResponseMeta `json:"-"`
 }
 
 type FineTuneDeleteResponse struct {
 	ID      string `json:"id"`
 	Object  string `json:"object"`
 	Deleted bool   `json:"deleted"`
+
+	ResponseMeta `json:"-"`
 }
 
-func (c *Client) CreateFineTune(ctx context.Context, request FineTuneRequest) (response FineTune, err error) {
+func (c *Client) CreateFineTune(
+	ctx context.Context,
+	request FineTuneRequest,
+	opts ...RequestOption,
+) (response FineTune, err error) {
+	ctx = withCallOptions(ctx, opts)
 	urlSuffix := "/fine-tunes"
 	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL(urlSuffix), request)
 	if err != nil {
@@ -78,7 +91,12 @@ func (c *Client) CreateFineTune(ctx context.Context, request FineTuneRequest) (r
 }
 
 // CancelFineTune cancel a fine-tune job.
-func (c *Client) CancelFineTune(ctx context.Context, fineTuneID string) (response FineTune, err error) {
+func (c *Client) CancelFineTune(
+	ctx context.Context,
+	fineTuneID string,
+	opts ...RequestOption,
+) (response FineTune, err error) {
+	ctx = withCallOptions(ctx, opts)
 	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL("/fine-tunes/"+fineTuneID+"/cancel"), nil)
 	if err != nil {
 		return
@@ -88,7 +106,8 @@ func (c *Client) CancelFineTune(ctx context.Context, fineTuneID string) (respons
 	return
 }
 
-func (c *Client) ListFineTunes(ctx context.Context) (response FineTuneList, err error) {
+func (c *Client) ListFineTunes(ctx context.Context, opts ...RequestOption) (response FineTuneList, err error) {
+	ctx = withCallOptions(ctx, opts)
 	req, err := c.requestBuilder.build(ctx, http.MethodGet, c.fullURL("/fine-tunes"), nil)
 	if err != nil {
 		return
@@ -98,7 +117,12 @@ func (c *Client) ListFineTunes(ctx context.Context) (response FineTuneList, err
 	return
 }
 
-func (c *Client) GetFineTune(ctx context.Context, fineTuneID string) (response FineTune, err error) {
+func (c *Client) GetFineTune(
+	ctx context.Context,
+	fineTuneID string,
+	opts ...RequestOption,
+) (response FineTune, err error) {
+	ctx = withCallOptions(ctx, opts)
 	urlSuffix := fmt.Sprintf("/fine-tunes/%s", fineTuneID)
 	req, err := c.requestBuilder.build(ctx, http.MethodGet, c.fullURL(urlSuffix), nil)
 	if err != nil {
@@ -109,7 +133,12 @@ func (c *Client) GetFineTune(ctx context.Context, fineTuneID string) (response F
 	return
 }
 
-func (c *Client) DeleteFineTune(ctx context.Context, fineTuneID string) (response FineTuneDeleteResponse, err error) {
+func (c *Client) DeleteFineTune(
+	ctx context.Context,
+	fineTuneID string,
+	opts ...RequestOption,
+) (response FineTuneDeleteResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
 	req, err := c.requestBuilder.build(ctx, http.MethodDelete, c.fullURL("/fine-tunes/"+fineTuneID), nil)
 	if err != nil {
 		return
@@ -119,7 +148,12 @@ func (c *Client) DeleteFineTune(ctx context.Context, fineTuneID string) (respons
 	return
 }
 
-func (c *Client) ListFineTuneEvents(ctx context.Context, fineTuneID string) (response FineTuneEventList, err error) {
+func (c *Client) ListFineTuneEvents(
+	ctx context.Context,
+	fineTuneID string,
+	opts ...RequestOption,
+) (response FineTuneEventList, err error) {
+	ctx = withCallOptions(ctx, opts)
 	req, err := c.requestBuilder.build(ctx, http.MethodGet, c.fullURL("/fine-tunes/"+fineTuneID+"/events"), nil)
 	if err != nil {
 		return
@@ -9,6 +9,7 @@ import (
 type formBuilder interface {
 	createFormFile(fieldname string, file *os.File) error
 	createFormFileFromBytes(fieldname, fileName string, data []byte) error
+	createFormFileFromReader(fieldname, fileName string, r io.Reader) error
 	writeField(fieldname, value string) error
 	close() error
 	formDataContentType() string
@@ -50,6 +51,16 @@ func (fb *defaultFormBuilder) createFormFileFromBytes(fieldname, fileName string
 	return nil
 }
 
+func (fb *defaultFormBuilder) createFormFileFromReader(fieldname, fileName string, r io.Reader) error {
+	fieldWriter, err := fb.writer.CreateFormFile(fieldname, fileName)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(fieldWriter, r)
+	return err
+}
+
 func (fb *defaultFormBuilder) writeField(fieldname, value string) error {
 	return fb.writer.WriteField(fieldname, value)
 }
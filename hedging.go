@@ -0,0 +1,171 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	hedgeLatencyWindow     = 128
+	hedgeMinLatencySamples = 16
+)
+
+// HedgeConfig enables hedged requests for idempotent calls (GET requests and embeddings):
+// if no response arrives within the hedge delay, a second attempt is launched and the
+// first reply wins while the other attempt is cancelled.
+type HedgeConfig struct {
+	// Quantile of recently observed latencies used as the hedge delay, e.g. 0.95.
+	Quantile float64
+	// Delay is used until enough latencies have been observed.
+	// It is also a lower bound for the hedge delay.
+	Delay time.Duration
+}
+
+type hedger struct {
+	config HedgeConfig
+
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+func newHedger(config HedgeConfig) *hedger {
+	return &hedger{
+		config:    config,
+		latencies: make([]time.Duration, 0, hedgeLatencyWindow),
+	}
+}
+
+func (h *hedger) observe(latency time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if len(h.latencies) < hedgeLatencyWindow {
+		h.latencies = append(h.latencies, latency)
+		return
+	}
+	h.latencies[h.next] = latency
+	h.next = (h.next + 1) % hedgeLatencyWindow
+}
+
+func (h *hedger) delay() time.Duration {
+	h.mu.Lock()
+	if len(h.latencies) < hedgeMinLatencySamples {
+		h.mu.Unlock()
+		return h.config.Delay
+	}
+	sorted := make([]time.Duration, len(h.latencies))
+	copy(sorted, h.latencies)
+	h.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	idx := int(h.config.Quantile * float64(len(sorted)-1))
+	if idx < 0 {
+		idx = 0
+	} else if idx >= len(sorted) {
+		idx = len(sorted) - 1
+	}
+	if sorted[idx] < h.config.Delay {
+		return h.config.Delay
+	}
+	return sorted[idx]
+}
+
+// isHedgeable reports whether req may be sent twice without side effects.
+func isHedgeable(req *http.Request) bool {
+	if req.Method == http.MethodGet || req.Method == http.MethodHead {
+		return true
+	}
+	return req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/embeddings") && req.GetBody != nil
+}
+
+type hedgeResult struct {
+	attempt  int
+	response *http.Response
+	err      error
+}
+
+// cancelOnClose cancels the context of the winning attempt once its body is consumed.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+// doHedged sends req and, if it takes longer than the hedge delay, a copy of it.
+func (c *Client) doHedged(req *http.Request) (*http.Response, error) {
+	results := make(chan hedgeResult, 2)
+	var cancels []context.CancelFunc
+	start := time.Now()
+	launch := func() error {
+		ctx, cancel := context.WithCancel(req.Context())
+		attempt := req.Clone(ctx)
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				cancel()
+				return err
+			}
+			attempt.Body = body
+		}
+		idx := len(cancels)
+		cancels = append(cancels, cancel)
+		go func() {
+			res, err := c.config.HTTPClient.Do(attempt) //nolint:bodyclose // closed by the caller or drainHedgeLosers
+			results <- hedgeResult{attempt: idx, response: res, err: err}
+		}()
+		return nil
+	}
+
+	if err := launch(); err != nil {
+		return nil, err
+	}
+	timer := time.NewTimer(c.hedger.delay())
+	defer timer.Stop()
+
+	pending := 1
+	var lastErr error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if launch() == nil {
+				pending++
+			}
+		case result := <-results:
+			pending--
+			if result.err != nil {
+				cancels[result.attempt]()
+				lastErr = result.err
+				continue
+			}
+			c.hedger.observe(time.Since(start))
+			for i, cancel := range cancels {
+				if i != result.attempt {
+					cancel()
+				}
+			}
+			go drainHedgeLosers(results, pending)
+			result.response.Body = &cancelOnClose{ReadCloser: result.response.Body, cancel: cancels[result.attempt]}
+			return result.response, nil
+		}
+	}
+	return nil, lastErr
+}
+
+// drainHedgeLosers closes the responses of the attempts that lost the race.
+func drainHedgeLosers(results <-chan hedgeResult, pending int) {
+	for ; pending > 0; pending-- {
+		if result := <-results; result.response != nil {
+			result.response.Body.Close()
+		}
+	}
+}
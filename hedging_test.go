@@ -0,0 +1,78 @@
+package openai //nolint:testpackage // testing private field
+
+import (
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestHedgedRequest(t *testing.T) {
+	var calls int32
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			// The first attempt hangs until it is cancelled by the hedged one.
+			<-r.Context().Done()
+			return
+		}
+		fmt.Fprintln(w, `{"data":[{"id":"gpt-4"}]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.Hedging = &HedgeConfig{Quantile: 0.95, Delay: 10 * time.Millisecond}
+	client := NewClientWithConfig(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	models, err := client.ListModels(ctx)
+	checks.NoError(t, err, "ListModels error")
+	if len(models.Models) != 1 {
+		t.Fatalf("Expected the hedged response, got %+v", models)
+	}
+	if calls != 2 {
+		t.Fatalf("Expected two attempts, got %d", calls)
+	}
+}
+
+func TestHedgeDelay(t *testing.T) {
+	h := newHedger(HedgeConfig{Quantile: 0.5, Delay: 5 * time.Millisecond})
+	if h.delay() != 5*time.Millisecond {
+		t.Fatalf("Configured delay should be used without samples, got %v", h.delay())
+	}
+
+	for i := 1; i <= hedgeLatencyWindow+10; i++ {
+		h.observe(time.Duration(i) * time.Millisecond)
+	}
+	// The window keeps the last hedgeLatencyWindow samples: 11ms..138ms.
+	if got := h.delay(); got != 74*time.Millisecond {
+		t.Fatalf("Unexpected median delay: %v", got)
+	}
+
+	h = newHedger(HedgeConfig{Quantile: 0.5, Delay: time.Second})
+	for i := 0; i < hedgeMinLatencySamples; i++ {
+		h.observe(time.Millisecond)
+	}
+	if h.delay() != time.Second {
+		t.Fatalf("Configured delay should be a lower bound, got %v", h.delay())
+	}
+}
+
+func TestIsHedgeable(t *testing.T) {
+	get, _ := http.NewRequest(http.MethodGet, "https://api.openai.com/v1/models", nil)
+	embeddings, _ := http.NewRequest(http.MethodPost, "https://api.openai.com/v1/embeddings", bytes.NewReader(nil))
+	chat, _ := http.NewRequest(http.MethodPost, "https://api.openai.com/v1/chat/completions", http.NoBody)
+	if !isHedgeable(get) || !isHedgeable(embeddings) || isHedgeable(chat) {
+		t.Fatal("Only GET and embeddings requests should be hedged")
+	}
+}
@@ -3,6 +3,7 @@ package openai
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"net/http"
 	"os"
 	"strconv"
@@ -30,19 +31,52 @@ type ImageRequest struct {
 }
 
 // ImageResponse represents a response structure for image API.
+// Fields unknown to this version of the client are kept in Extra and written back by MarshalJSON.
 type ImageResponse struct {
-	Created int64                    `json:"created,omitempty"`
-	Data    []ImageResponseDataInner `json:"data,omitempty"`
+	Created      int64                    `json:"created,omitempty"`
+	Data         []ImageResponseDataInner `json:"data,omitempty"`
+	Background   string                   `json:"background,omitempty"`
+	OutputFormat string                   `json:"output_format,omitempty"`
+	Quality      string                   `json:"quality,omitempty"`
+	Size         string                   `json:"size,omitempty"`
+	Usage        *ImageUsage              `json:"usage,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+
+	ResponseMeta `json:"-"`
 }
 
 // ImageResponseDataInner represents a response data structure for image API.
+// Fields unknown to this version of the client are kept in Extra and written back by MarshalJSON.
 type ImageResponseDataInner struct {
-	URL     string `json:"url,omitempty"`
-	B64JSON string `json:"b64_json,omitempty"`
+	URL           string `json:"url,omitempty"`
+	B64JSON       string `json:"b64_json,omitempty"`
+	RevisedPrompt string `json:"revised_prompt,omitempty"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+// ImageUsage is the token usage reported by token-billed image models.
+type ImageUsage struct {
+	TotalTokens        int                    `json:"total_tokens"`
+	InputTokens        int                    `json:"input_tokens"`
+	OutputTokens       int                    `json:"output_tokens"`
+	InputTokensDetails ImageInputTokenDetails `json:"input_tokens_details"`
+}
+
+// ImageInputTokenDetails breaks down the input tokens of an image request.
+type ImageInputTokenDetails struct {
+	TextTokens  int `json:"text_tokens"`
+	ImageTokens int `json:"image_tokens"`
 }
 
 // CreateImage - API call to create an image. This is the main endpoint of the DALL-E API.
-func (c *Client) CreateImage(ctx context.Context, request ImageRequest) (response ImageResponse, err error) {
+func (c *Client) CreateImage(
+	ctx context.Context,
+	request ImageRequest,
+	opts ...RequestOption,
+) (response ImageResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
 	urlSuffix := "/images/generations"
 	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL(urlSuffix), request)
 	if err != nil {
@@ -64,7 +98,12 @@ type ImageEditRequest struct {
 }
 
 // CreateEditImage - API call to create an image. This is the main endpoint of the DALL-E API.
-func (c *Client) CreateEditImage(ctx context.Context, request ImageEditRequest) (response ImageResponse, err error) {
+func (c *Client) CreateEditImage(
+	ctx context.Context,
+	request ImageEditRequest,
+	opts ...RequestOption,
+) (response ImageResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
 	body := &bytes.Buffer{}
 	builder := c.createFormBuilder(body)
 
@@ -128,7 +167,12 @@ type ImageVariRequest struct {
 
 // CreateVariImage - API call to create an image variation. This is the main endpoint of the DALL-E API.
 // Use abbreviations(vari for variation) because ci-lint has a single-line length limit ...
-func (c *Client) CreateVariImage(ctx context.Context, request ImageVariRequest) (response ImageResponse, err error) {
+func (c *Client) CreateVariImage(
+	ctx context.Context,
+	request ImageVariRequest,
+	opts ...RequestOption,
+) (response ImageResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
 	body := &bytes.Buffer{}
 	builder := c.createFormBuilder(body)
 
@@ -0,0 +1,90 @@
+package openai
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+)
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields in Extra.
+func (r *ImageResponse) UnmarshalJSON(data []byte) (err error) {
+	type alias ImageResponse
+	var decoded alias
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		return
+	}
+	decoded.Extra, err = unknownJSONFields(data, decoded)
+	*r = ImageResponse(decoded)
+	return
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra back alongside the known fields.
+func (r ImageResponse) MarshalJSON() ([]byte, error) {
+	type alias ImageResponse
+	return marshalWithExtra(alias(r), r.Extra)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, keeping unknown fields in Extra.
+func (d *ImageResponseDataInner) UnmarshalJSON(data []byte) (err error) {
+	type alias ImageResponseDataInner
+	var decoded alias
+	if err = json.Unmarshal(data, &decoded); err != nil {
+		return
+	}
+	decoded.Extra, err = unknownJSONFields(data, decoded)
+	*d = ImageResponseDataInner(decoded)
+	return
+}
+
+// MarshalJSON implements json.Marshaler, writing Extra back alongside the known fields.
+func (d ImageResponseDataInner) MarshalJSON() ([]byte, error) {
+	type alias ImageResponseDataInner
+	return marshalWithExtra(alias(d), d.Extra)
+}
+
+// unknownJSONFields returns the members of the JSON object data that are not
+// fields of the struct v, or nil if there are none.
+func unknownJSONFields(data []byte, v any) (map[string]json.RawMessage, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name := range jsonFieldNames(reflect.TypeOf(v)) {
+		delete(fields, name)
+	}
+	if len(fields) == 0 {
+		return nil, nil
+	}
+	return fields, nil
+}
+
+func jsonFieldNames(t reflect.Type) map[string]struct{} {
+	names := make(map[string]struct{}, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			names[name] = struct{}{}
+		}
+	}
+	return names
+}
+
+// marshalWithExtra marshals v, which must encode as a JSON object, merging in extra.
+// Known fields win over extra fields of the same name.
+func marshalWithExtra(v any, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(v)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range extra {
+		if _, ok := fields[name]; !ok {
+			fields[name] = value
+		}
+	}
+	return json.Marshal(fields)
+}
@@ -265,6 +265,7 @@ func handleVariateImageEndpoint(w http.ResponseWriter, r *http.Request) {
 type mockFormBuilder struct {
 	mockCreateFormFile  func(string, *os.File) error
 	mockCreateFormBytes func(string, string, []byte) error
+	mockCreateFormRead  func(string, string, io.Reader) error
 	mockWriteField      func(string, string) error
 	mockClose           func() error
 }
@@ -277,6 +278,10 @@ func (fb *mockFormBuilder) createFormFileFromBytes(fieldname, fileName string, d
 	return fb.mockCreateFormBytes(fieldname, fileName, data)
 }
 
+func (fb *mockFormBuilder) createFormFileFromReader(fieldname, fileName string, r io.Reader) error {
+	return fb.mockCreateFormRead(fieldname, fileName, r)
+}
+
 func (fb *mockFormBuilder) writeField(fieldname, value string) error {
 	return fb.mockWriteField(fieldname, value)
 }
@@ -407,3 +412,31 @@ func TestVariImageFormBuilderFailures(t *testing.T) {
 	_, err = client.CreateVariImage(ctx, req)
 	checks.ErrorIs(t, err, mockFailedErr, "CreateImage should return error if form builder fails")
 }
+
+func TestImageResponseMetadata(t *testing.T) {
+	//nolint:lll
+	data := `{"created":1,"background":"transparent","output_format":"png","quality":"high","size":"1024x1536","seed":42,"usage":{"total_tokens":30,"input_tokens":10,"output_tokens":20,"input_tokens_details":{"text_tokens":10,"image_tokens":0}},"data":[{"b64_json":"aGk=","revised_prompt":"a cat","content_filter":"none"}]}`
+
+	var response ImageResponse
+	err := json.Unmarshal([]byte(data), &response)
+	checks.NoError(t, err, "Unmarshal error")
+	if response.Background != "transparent" || response.OutputFormat != "png" || response.Size != "1024x1536" ||
+		response.Usage == nil || response.Usage.OutputTokens != 20 {
+		t.Fatalf("Unexpected response: %+v", response)
+	}
+	if string(response.Extra["seed"]) != "42" || len(response.Extra) != 1 {
+		t.Fatalf("Unknown fields should be kept in Extra: %v", response.Extra)
+	}
+	if response.Data[0].RevisedPrompt != "a cat" || string(response.Data[0].Extra["content_filter"]) != `"none"` {
+		t.Fatalf("Unexpected data: %+v", response.Data[0])
+	}
+
+	encoded, err := json.Marshal(response)
+	checks.NoError(t, err, "Marshal error")
+	var original, roundTripped map[string]any
+	_ = json.Unmarshal([]byte(data), &original)
+	_ = json.Unmarshal(encoded, &roundTripped)
+	if fmt.Sprint(original) != fmt.Sprint(roundTripped) {
+		t.Fatalf("Round trip lost fields:\n%s\n%s", data, encoded)
+	}
+}
@@ -0,0 +1,93 @@
+package openai
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// InventoryFile is a file of an InventoryReport.
+type InventoryFile struct {
+	File
+	Created time.Time
+	Age     time.Duration
+	// FineTunes are the IDs of the fine-tunes training, validating or reporting with the file.
+	FineTunes []string
+}
+
+// InventoryPurpose sums up the files of a purpose.
+type InventoryPurpose struct {
+	Files int
+	Bytes int64
+}
+
+// InventoryReport lists the files and fine-tunes of the organization or project of a client,
+// see Client.Inventory.
+type InventoryReport struct {
+	GeneratedAt time.Time
+	// Files are ordered from the oldest to the newest.
+	Files      []InventoryFile
+	FineTunes  []FineTune
+	TotalBytes int64
+	ByPurpose  map[string]InventoryPurpose
+}
+
+// Inventory lists the files and fine-tunes available to the client with their sizes and ages,
+// and which fine-tunes use every file, as groundwork for cleanup tooling. Assistants, threads and
+// vector stores are not supported by this client and are not listed.
+func (c *Client) Inventory(ctx context.Context, opts ...RequestOption) (report InventoryReport, err error) {
+	ctx = withCallOptions(ctx, opts)
+	files, err := c.ListFiles(ctx)
+	if err != nil {
+		return
+	}
+	fineTunes, err := c.ListFineTunes(ctx)
+	if err != nil {
+		return
+	}
+
+	usedBy := make(map[string][]string)
+	for _, fineTune := range fineTunes.Data {
+		for _, list := range [][]File{fineTune.TrainingFiles, fineTune.ValidationFiles, fineTune.ResultFiles} {
+			for _, file := range list {
+				usedBy[file.ID] = append(usedBy[file.ID], fineTune.ID)
+			}
+		}
+	}
+
+	report = InventoryReport{
+		GeneratedAt: time.Now(),
+		FineTunes:   fineTunes.Data,
+		ByPurpose:   make(map[string]InventoryPurpose),
+	}
+	for _, file := range files.Files {
+		createdAt := time.Unix(file.CreatedAt, 0)
+		report.Files = append(report.Files, InventoryFile{
+			File:      file,
+			Created:   createdAt,
+			Age:       report.GeneratedAt.Sub(createdAt),
+			FineTunes: usedBy[file.ID],
+		})
+		report.TotalBytes += int64(file.Bytes)
+		purpose := report.ByPurpose[file.Purpose]
+		purpose.Files++
+		purpose.Bytes += int64(file.Bytes)
+		report.ByPurpose[file.Purpose] = purpose
+	}
+	sort.SliceStable(report.Files, func(i, j int) bool {
+		return report.Files[i].Created.Before(report.Files[j].Created)
+	})
+	return
+}
+
+// UnusedFilesOlderThan returns the files of the report older than age that no fine-tune uses,
+// the usual candidates for deletion.
+func (r InventoryReport) UnusedFilesOlderThan(age time.Duration) []InventoryFile {
+	var unused []InventoryFile
+	for _, file := range r.Files {
+		if file.Age > age && len(file.FineTunes) == 0 {
+			unused = append(unused, file)
+		}
+	}
+	return unused
+}
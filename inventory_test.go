@@ -0,0 +1,54 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestInventory(t *testing.T) {
+	now := time.Now()
+	day := int64(24 * time.Hour / time.Second)
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"data":[`+
+			`{"id":"file-new","bytes":100,"created_at":%d,"purpose":"fine-tune"},`+
+			`{"id":"file-train","bytes":200,"created_at":%d,"purpose":"fine-tune"},`+
+			`{"id":"file-old","bytes":300,"created_at":%d,"purpose":"batch"}]}`,
+			now.Unix()-day, now.Unix()-20*day, now.Unix()-30*day)
+	})
+	server.RegisterHandler("/v1/fine-tunes", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"data":[{"id":"ft-1","training_files":[{"id":"file-train"}],"result_files":[]}]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	report, err := client.Inventory(context.Background())
+	checks.NoError(t, err, "Inventory error")
+	if len(report.Files) != 3 || report.Files[0].ID != "file-old" || report.Files[2].ID != "file-new" {
+		t.Fatalf("Expected the files from the oldest, got %+v", report.Files)
+	}
+	if report.TotalBytes != 600 || report.ByPurpose["fine-tune"] != (InventoryPurpose{Files: 2, Bytes: 300}) ||
+		len(report.FineTunes) != 1 {
+		t.Fatalf("Unexpected totals: %d bytes, %+v", report.TotalBytes, report.ByPurpose)
+	}
+	if used := report.Files[1].FineTunes; len(used) != 1 || used[0] != "ft-1" {
+		t.Fatalf("Expected file-train to be used by ft-1, got %v", used)
+	}
+
+	unused := report.UnusedFilesOlderThan(7 * 24 * time.Hour)
+	if len(unused) != 1 || unused[0].ID != "file-old" || unused[0].Age < 29*24*time.Hour {
+		t.Fatalf("Unexpected unused files: %+v", unused)
+	}
+}
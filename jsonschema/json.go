@@ -0,0 +1,173 @@
+// Package jsonschema provides the JSON Schema definitions used by structured outputs and
+// function calling, with helpers for the constructs strict schemas most often get wrong:
+// enums, discriminated unions and recursive types.
+package jsonschema
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DataType is the type of a JSON value.
+type DataType string
+
+const (
+	Object  DataType = "object"
+	Number  DataType = "number"
+	Integer DataType = "integer"
+	String  DataType = "string"
+	Array   DataType = "array"
+	Null    DataType = "null"
+	Boolean DataType = "boolean"
+)
+
+// defsPrefix is the prefix of references to definitions of Definition.Defs.
+const defsPrefix = "#/$defs/"
+
+var (
+	ErrEnumEmpty       = errors.New("enum has no values")
+	ErrRefUnresolved   = errors.New("reference does not resolve to a definition")
+	ErrUnionTagInvalid = errors.New("union variant is not an object or already has the tag property")
+)
+
+// Definition is a JSON Schema. The zero value accepts any value.
+type Definition struct {
+	Type        DataType `json:"type,omitempty"`
+	Description string   `json:"description,omitempty"`
+	// Enum lists the values accepted for a string.
+	Enum []string `json:"enum,omitempty"`
+	// Const is the only value accepted, e.g. the tag of a variant of a discriminated union.
+	Const any `json:"const,omitempty"`
+	// Properties are the properties of an object. Strict schemas require all of them.
+	Properties map[string]Definition `json:"properties,omitempty"`
+	Required   []string              `json:"required,omitempty"`
+	// Items is the schema of the elements of an array.
+	Items *Definition `json:"items,omitempty"`
+	// AdditionalProperties must be false for the objects of strict schemas.
+	AdditionalProperties any `json:"additionalProperties,omitempty"`
+	// AnyOf accepts values matching any of the definitions.
+	AnyOf []Definition `json:"anyOf,omitempty"`
+	// Ref replaces the definition with the one it refers to, see Ref.
+	Ref string `json:"$ref,omitempty"`
+	// Defs are the definitions references refer to. Only the root definition may have them.
+	Defs map[string]Definition `json:"$defs,omitempty"`
+}
+
+// definition has the fields of Definition without its JSON methods.
+type definition Definition
+
+// MarshalJSON implements json.Marshaler. Objects are always written with their properties,
+// as the API rejects object schemas without them.
+func (d Definition) MarshalJSON() ([]byte, error) {
+	if d.Type == Object && len(d.Properties) == 0 {
+		return json.Marshal(struct {
+			definition
+			Properties map[string]Definition `json:"properties"`
+		}{definition(d), map[string]Definition{}})
+	}
+	return json.Marshal(definition(d))
+}
+
+// StrictObject returns an object schema with properties, all of them required and no
+// additional properties, as strict mode requires.
+func StrictObject(description string, properties map[string]Definition) Definition {
+	return Definition{
+		Type:                 Object,
+		Description:          description,
+		Properties:           properties,
+		Required:             sortedKeys(properties),
+		AdditionalProperties: false,
+	}
+}
+
+// Enum returns the schema of a string taking one of values.
+func Enum(description string, values ...string) Definition {
+	return Definition{Type: String, Description: description, Enum: values}
+}
+
+// DiscriminatedUnion returns the schema of objects taking the shape of one of variants,
+// told apart by their tag property holding the name of the variant. Every variant must be
+// an object schema without a tag property; it gets one, which is also required.
+// The API does not accept a union as the root of a schema: use it as a property.
+func DiscriminatedUnion(tag string, variants map[string]Definition) (union Definition, err error) {
+	for _, name := range sortedKeys(variants) {
+		variant := variants[name]
+		if _, ok := variant.Properties[tag]; ok || variant.Type != Object {
+			err = fmt.Errorf("%w: %s", ErrUnionTagInvalid, name)
+			return
+		}
+		properties := make(map[string]Definition, len(variant.Properties)+1)
+		for key, property := range variant.Properties {
+			properties[key] = property
+		}
+		properties[tag] = Definition{Type: String, Const: name}
+		variant.Properties = properties
+		variant.Required = append([]string{tag}, variant.Required...)
+		if variant.AdditionalProperties == nil {
+			variant.AdditionalProperties = false
+		}
+		union.AnyOf = append(union.AnyOf, variant)
+	}
+	return
+}
+
+// Ref returns a reference to the definition name of the Defs of the root schema, which is
+// how recursive types are described, e.g. the children of a tree node referring to the node.
+// Ref("") refers to the root schema itself.
+func Ref(name string) Definition {
+	if name == "" {
+		return Definition{Ref: "#"}
+	}
+	return Definition{Ref: defsPrefix + name}
+}
+
+// Validate checks d as the root of a schema: that its enums have values and that its
+// references resolve to its Defs.
+func (d Definition) Validate() error {
+	return d.validate(d.Defs, "#")
+}
+
+func (d Definition) validate(defs map[string]Definition, path string) error {
+	if d.Enum != nil && len(d.Enum) == 0 {
+		return fmt.Errorf("%w: %s", ErrEnumEmpty, path)
+	}
+	if d.Ref != "" && d.Ref != "#" {
+		name := strings.TrimPrefix(d.Ref, defsPrefix)
+		if _, ok := defs[name]; !ok || name == d.Ref {
+			return fmt.Errorf("%w: %s at %s", ErrRefUnresolved, d.Ref, path)
+		}
+	}
+	for _, name := range sortedKeys(d.Properties) {
+		if err := d.Properties[name].validate(defs, path+"/properties/"+name); err != nil {
+			return err
+		}
+	}
+	if d.Items != nil {
+		if err := d.Items.validate(defs, path+"/items"); err != nil {
+			return err
+		}
+	}
+	for i, variant := range d.AnyOf {
+		if err := variant.validate(defs, fmt.Sprintf("%s/anyOf/%d", path, i)); err != nil {
+			return err
+		}
+	}
+	for _, name := range sortedKeys(d.Defs) {
+		if err := d.Defs[name].validate(defs, path+"/$defs/"+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]Definition) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,89 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+func TestDefinitionMarshalJSON(t *testing.T) {
+	schema := jsonschema.StrictObject("A forecast.", map[string]jsonschema.Definition{
+		"unit":     jsonschema.Enum("The unit.", "celsius", "fahrenheit"),
+		"location": {Type: jsonschema.String},
+		"extra":    {Type: jsonschema.Object},
+	})
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	want := `{"type":"object","description":"A forecast.","properties":{` +
+		`"extra":{"type":"object","properties":{}},"location":{"type":"string"},` +
+		`"unit":{"type":"string","description":"The unit.","enum":["celsius","fahrenheit"]}},` +
+		`"required":["extra","location","unit"],"additionalProperties":false}`
+	if string(data) != want {
+		t.Fatalf("Unexpected schema:\n%s\nwant:\n%s", data, want)
+	}
+}
+
+func TestDiscriminatedUnion(t *testing.T) {
+	union, err := jsonschema.DiscriminatedUnion("kind", map[string]jsonschema.Definition{
+		"circle": jsonschema.StrictObject("", map[string]jsonschema.Definition{"radius": {Type: jsonschema.Number}}),
+		"square": jsonschema.StrictObject("", map[string]jsonschema.Definition{"side": {Type: jsonschema.Number}}),
+	})
+	if err != nil {
+		t.Fatalf("DiscriminatedUnion error: %v", err)
+	}
+	data, err := json.Marshal(union)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	want := `{"anyOf":[` +
+		`{"type":"object","properties":{"kind":{"type":"string","const":"circle"},"radius":{"type":"number"}},` +
+		`"required":["kind","radius"],"additionalProperties":false},` +
+		`{"type":"object","properties":{"kind":{"type":"string","const":"square"},"side":{"type":"number"}},` +
+		`"required":["kind","side"],"additionalProperties":false}]}`
+	if string(data) != want {
+		t.Fatalf("Unexpected union:\n%s\nwant:\n%s", data, want)
+	}
+
+	_, err = jsonschema.DiscriminatedUnion("kind", map[string]jsonschema.Definition{
+		"tagged": jsonschema.StrictObject("", map[string]jsonschema.Definition{"kind": {Type: jsonschema.String}}),
+	})
+	if !errors.Is(err, jsonschema.ErrUnionTagInvalid) {
+		t.Fatalf("Expected ErrUnionTagInvalid for a variant with the tag, got %v", err)
+	}
+	_, err = jsonschema.DiscriminatedUnion("kind", map[string]jsonschema.Definition{"text": {Type: jsonschema.String}})
+	if !errors.Is(err, jsonschema.ErrUnionTagInvalid) {
+		t.Fatalf("Expected ErrUnionTagInvalid for a string variant, got %v", err)
+	}
+}
+
+func TestRecursiveDefinition(t *testing.T) {
+	schema := jsonschema.StrictObject("", map[string]jsonschema.Definition{
+		"root": jsonschema.Ref("node"),
+	})
+	schema.Defs = map[string]jsonschema.Definition{
+		"node": jsonschema.StrictObject("", map[string]jsonschema.Definition{
+			"name":     {Type: jsonschema.String},
+			"children": {Type: jsonschema.Array, Items: &jsonschema.Definition{Ref: "#/$defs/node"}},
+		}),
+	}
+	if err := schema.Validate(); err != nil {
+		t.Fatalf("Validate error: %v", err)
+	}
+	data, err := json.Marshal(jsonschema.Ref(""))
+	if err != nil || string(data) != `{"$ref":"#"}` {
+		t.Fatalf("Unexpected root reference: %s, %v", data, err)
+	}
+
+	schema.Properties["parent"] = jsonschema.Ref("parent")
+	if err = schema.Validate(); !errors.Is(err, jsonschema.ErrRefUnresolved) {
+		t.Fatalf("Expected ErrRefUnresolved, got %v", err)
+	}
+	schema.Properties["parent"] = jsonschema.Definition{Type: jsonschema.String, Enum: []string{}}
+	if err = schema.Validate(); !errors.Is(err, jsonschema.ErrEnumEmpty) {
+		t.Fatalf("Expected ErrEnumEmpty, got %v", err)
+	}
+}
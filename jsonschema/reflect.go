@@ -0,0 +1,167 @@
+package jsonschema
+
+import (
+	"encoding"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+var ErrTypeNotSupported = errors.New("type cannot be described by a strict schema")
+
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
+// GenerateSchemaForType returns the schema of the JSON encoding of the type of v, for use as
+// the schema of a response format or as the parameters of a function. The schema is strict:
+// fields follow their json tags and are all required, fields tagged omitempty also accept null,
+// objects disallow additional properties, and the description and enum tags set Description
+// and Enum, e.g.
+//
+//	Unit string `json:"unit" description:"The unit." enum:"celsius,fahrenheit"`
+//
+// Recursive types refer to themselves through Defs. Maps, channels and functions are not
+// supported; types implementing encoding.TextMarshaler are strings.
+func GenerateSchemaForType(v any) (*Definition, error) {
+	t := reflect.TypeOf(v)
+	if t == nil {
+		return nil, fmt.Errorf("%w: nil", ErrTypeNotSupported)
+	}
+	g := &generator{root: indirect(t), visiting: map[reflect.Type]bool{}, recursive: map[reflect.Type]bool{}}
+	definition, err := g.reflect(t)
+	if err != nil {
+		return nil, err
+	}
+	definition.Defs = g.defs
+	return &definition, nil
+}
+
+type generator struct {
+	root      reflect.Type
+	visiting  map[reflect.Type]bool
+	recursive map[reflect.Type]bool
+	defs      map[string]Definition
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t
+}
+
+func (g *generator) reflect(t reflect.Type) (Definition, error) {
+	t = indirect(t)
+	if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return Definition{Type: String}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.String:
+		return Definition{Type: String}, nil
+	case reflect.Bool:
+		return Definition{Type: Boolean}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Definition{Type: Integer}, nil
+	case reflect.Float32, reflect.Float64:
+		return Definition{Type: Number}, nil
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			// Byte slices are encoded as base64 strings.
+			return Definition{Type: String}, nil
+		}
+		items, err := g.reflect(t.Elem())
+		if err != nil {
+			return Definition{}, err
+		}
+		return Definition{Type: Array, Items: &items}, nil
+	case reflect.Interface:
+		return Definition{}, nil
+	case reflect.Struct:
+		return g.reflectStruct(t)
+	default:
+		return Definition{}, fmt.Errorf("%w: %s", ErrTypeNotSupported, t)
+	}
+}
+
+// reflectStruct returns the object schema of t, or a reference to it for recursive types.
+func (g *generator) reflectStruct(t reflect.Type) (Definition, error) {
+	if g.visiting[t] {
+		g.recursive[t] = true
+		return g.ref(t), nil
+	}
+	g.visiting[t] = true
+	defer delete(g.visiting, t)
+
+	definition := Definition{Type: Object, Properties: map[string]Definition{}, AdditionalProperties: false}
+	if err := g.addFields(&definition, t); err != nil {
+		return Definition{}, err
+	}
+	if !g.recursive[t] || t == g.root {
+		return definition, nil
+	}
+	if g.defs == nil {
+		g.defs = map[string]Definition{}
+	}
+	g.defs[t.Name()] = definition
+	return g.ref(t), nil
+}
+
+func (g *generator) ref(t reflect.Type) Definition {
+	if t == g.root {
+		return Ref("")
+	}
+	return Ref(t.Name())
+}
+
+// addFields adds the exported fields of t, and those of its embedded structs, to definition.
+func (g *generator) addFields(definition *Definition, t reflect.Type) error {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" || (!field.IsExported() && !field.Anonymous) {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" && indirect(field.Type).Kind() == reflect.Struct {
+			if err := g.addFields(definition, indirect(field.Type)); err != nil {
+				return err
+			}
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property, err := g.reflect(field.Type)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", field.Name, err)
+		}
+		property.Description = field.Tag.Get("description")
+		if enum := field.Tag.Get("enum"); enum != "" {
+			property.Enum = strings.Split(enum, ",")
+		}
+		if containsOption(options, "omitempty") {
+			// Strict schemas require every property, so optional fields accept null instead.
+			property = Definition{AnyOf: []Definition{property, {Type: Null}}}
+		}
+		definition.Properties[name] = property
+		definition.Required = append(definition.Required, name)
+	}
+	return nil
+}
+
+func containsOption(options, option string) bool {
+	for options != "" {
+		var current string
+		current, options, _ = strings.Cut(options, ",")
+		if current == option {
+			return true
+		}
+	}
+	return false
+}
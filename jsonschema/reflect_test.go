@@ -0,0 +1,92 @@
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+type forecastBase struct {
+	Location string `json:"location" description:"The city."`
+}
+
+type forecast struct {
+	forecastBase
+	Unit     string    `json:"unit" enum:"celsius,fahrenheit"`
+	Days     []int     `json:"days"`
+	Date     time.Time `json:"date"`
+	Note     *string   `json:"note,omitempty"`
+	internal string
+	Ignored  string `json:"-"`
+}
+
+type node struct {
+	Name     string  `json:"name"`
+	Children []*node `json:"children"`
+}
+
+type tree struct {
+	Root node `json:"root"`
+}
+
+func marshalSchema(t *testing.T, v any) string {
+	t.Helper()
+	schema, err := jsonschema.GenerateSchemaForType(v)
+	if err != nil {
+		t.Fatalf("GenerateSchemaForType error: %v", err)
+	}
+	if err = schema.Validate(); err != nil {
+		t.Fatalf("Generated schema is invalid: %v", err)
+	}
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatalf("Marshal error: %v", err)
+	}
+	return string(data)
+}
+
+func TestGenerateSchemaForType(t *testing.T) {
+	got := marshalSchema(t, forecast{internal: "unused"})
+	want := `{"type":"object","properties":{` +
+		`"date":{"type":"string"},"days":{"type":"array","items":{"type":"integer"}},` +
+		`"location":{"type":"string","description":"The city."},` +
+		`"note":{"anyOf":[{"type":"string"},{"type":"null"}]},` +
+		`"unit":{"type":"string","enum":["celsius","fahrenheit"]}},` +
+		`"required":["location","unit","days","date","note"],"additionalProperties":false}`
+	if got != want {
+		t.Fatalf("Unexpected schema:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerateSchemaForRecursiveType(t *testing.T) {
+	got := marshalSchema(t, &node{})
+	want := `{"type":"object","properties":{"children":{"type":"array","items":{"$ref":"#"}},` +
+		`"name":{"type":"string"}},"required":["name","children"],"additionalProperties":false}`
+	if got != want {
+		t.Fatalf("Unexpected schema:\n%s\nwant:\n%s", got, want)
+	}
+
+	got = marshalSchema(t, tree{})
+	want = `{"type":"object","properties":{"root":{"$ref":"#/$defs/node"}},"required":["root"],` +
+		`"additionalProperties":false,"$defs":{"node":{"type":"object","properties":{"children":` +
+		`{"type":"array","items":{"$ref":"#/$defs/node"}},"name":{"type":"string"}},` +
+		`"required":["name","children"],"additionalProperties":false}}}`
+	if got != want {
+		t.Fatalf("Unexpected schema:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestGenerateSchemaForUnsupportedType(t *testing.T) {
+	_, err := jsonschema.GenerateSchemaForType(struct {
+		Labels map[string]string `json:"labels"`
+	}{})
+	if !errors.Is(err, jsonschema.ErrTypeNotSupported) {
+		t.Fatalf("Expected ErrTypeNotSupported for maps, got %v", err)
+	}
+	if _, err = jsonschema.GenerateSchemaForType(nil); !errors.Is(err, jsonschema.ErrTypeNotSupported) {
+		t.Fatalf("Expected ErrTypeNotSupported for nil, got %v", err)
+	}
+}
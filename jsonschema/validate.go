@@ -0,0 +1,189 @@
+package jsonschema
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+var ErrValueInvalid = errors.New("value does not match the schema")
+
+// ValidateJSON checks that data is a JSON value matching d, e.g. the arguments of a tool call
+// against the parameters of its function. It covers the keywords of Definition: types, enums,
+// consts, properties, required properties, additional properties, items, anyOf and references
+// to the Defs of d. The error names the path of the first mismatch, such as $.days[2].
+func (d Definition) ValidateJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("%w: %s", ErrValueInvalid, err.Error())
+	}
+	if decoder.More() {
+		return fmt.Errorf("%w: data after the value", ErrValueInvalid)
+	}
+	v := valueValidator{root: d}
+	return v.validate(d, value, "$")
+}
+
+type valueValidator struct {
+	root Definition
+}
+
+func (v valueValidator) validate(d Definition, value any, path string) error {
+	if d.Ref != "" {
+		resolved, err := v.resolve(d.Ref)
+		if err != nil {
+			return fmt.Errorf("%w at %s", err, path)
+		}
+		return v.validate(resolved, value, path)
+	}
+	if len(d.AnyOf) > 0 {
+		return v.validateAnyOf(d.AnyOf, value, path)
+	}
+	if d.Const != nil && !equalJSON(d.Const, value) {
+		return fmt.Errorf("%w: %s must be %v", ErrValueInvalid, path, d.Const)
+	}
+
+	switch d.Type {
+	case "":
+		return nil
+	case Object:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return typeError(d.Type, path)
+		}
+		return v.validateObject(d, object, path)
+	case Array:
+		array, ok := value.([]any)
+		if !ok {
+			return typeError(d.Type, path)
+		}
+		if d.Items != nil {
+			for i, item := range array {
+				if err := v.validate(*d.Items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	case String:
+		s, ok := value.(string)
+		if !ok {
+			return typeError(d.Type, path)
+		}
+		if len(d.Enum) > 0 && !containsString(d.Enum, s) {
+			return fmt.Errorf("%w: %s must be one of %q", ErrValueInvalid, path, d.Enum)
+		}
+	case Number:
+		if _, ok := value.(json.Number); !ok {
+			return typeError(d.Type, path)
+		}
+	case Integer:
+		number, ok := value.(json.Number)
+		if _, err := number.Int64(); !ok || err != nil {
+			return typeError(d.Type, path)
+		}
+	case Boolean:
+		if _, ok := value.(bool); !ok {
+			return typeError(d.Type, path)
+		}
+	case Null:
+		if value != nil {
+			return typeError(d.Type, path)
+		}
+	}
+	return nil
+}
+
+func (v valueValidator) validateObject(d Definition, object map[string]any, path string) error {
+	for _, name := range d.Required {
+		if _, ok := object[name]; !ok {
+			return fmt.Errorf("%w: %s is missing the required property %s", ErrValueInvalid, path, name)
+		}
+	}
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property, ok := d.Properties[name]
+		if !ok {
+			switch additional := d.AdditionalProperties.(type) {
+			case bool:
+				if !additional {
+					return fmt.Errorf("%w: %s has the unknown property %s", ErrValueInvalid, path, name)
+				}
+				continue
+			case Definition:
+				property = additional
+			case *Definition:
+				property = *additional
+			default:
+				continue
+			}
+		}
+		if err := v.validate(property, object[name], path+"."+name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v valueValidator) validateAnyOf(variants []Definition, value any, path string) error {
+	var first error
+	for _, variant := range variants {
+		err := v.validate(variant, value, path)
+		if err == nil {
+			return nil
+		}
+		if first == nil {
+			first = err
+		}
+	}
+	return fmt.Errorf("%w: %s matches none of the anyOf variants, e.g. %s", ErrValueInvalid, path, first.Error())
+}
+
+func (v valueValidator) resolve(ref string) (Definition, error) {
+	if ref == "#" {
+		return v.root, nil
+	}
+	if name := strings.TrimPrefix(ref, defsPrefix); name != ref {
+		if definition, ok := v.root.Defs[name]; ok {
+			return definition, nil
+		}
+	}
+	return Definition{}, fmt.Errorf("%w: %s", ErrRefUnresolved, ref)
+}
+
+func typeError(expected DataType, path string) error {
+	return fmt.Errorf("%w: %s must be of type %s", ErrValueInvalid, path, expected)
+}
+
+// equalJSON reports whether expected encodes to the same JSON value as the decoded value.
+func equalJSON(expected, value any) bool {
+	data, err := json.Marshal(expected)
+	if err != nil {
+		return false
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var decoded any
+	if decoder.Decode(&decoded) != nil {
+		return false
+	}
+	return reflect.DeepEqual(decoded, value)
+}
+
+func containsString(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
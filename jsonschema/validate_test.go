@@ -0,0 +1,70 @@
+package jsonschema_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/sashabaranov/go-openai/jsonschema"
+)
+
+func TestValidateJSON(t *testing.T) {
+	schema := jsonschema.StrictObject("", map[string]jsonschema.Definition{
+		"city": {Type: jsonschema.String},
+		"unit": jsonschema.Enum("", "celsius", "fahrenheit"),
+		"days": {Type: jsonschema.Array, Items: &jsonschema.Definition{Type: jsonschema.Integer}},
+		"note": {AnyOf: []jsonschema.Definition{{Type: jsonschema.String}, {Type: jsonschema.Null}}},
+	})
+	valid := []string{
+		`{"city":"Paris","unit":"celsius","days":[1,2],"note":null}`,
+		`{"city":"Paris","unit":"fahrenheit","days":[],"note":"warm"}`,
+	}
+	for _, data := range valid {
+		if err := schema.ValidateJSON([]byte(data)); err != nil {
+			t.Errorf("%s should be valid: %v", data, err)
+		}
+	}
+
+	invalid := []string{
+		`{"city":"Paris","unit":"celsius","days":[1,2]}`,
+		`{"city":"Paris","unit":"kelvin","days":[],"note":null}`,
+		`{"city":"Paris","unit":"celsius","days":[1,2.5],"note":null}`,
+		`{"city":3,"unit":"celsius","days":[],"note":null}`,
+		`{"city":"Paris","unit":"celsius","days":[],"note":1}`,
+		`{"city":"Paris","unit":"celsius","days":[],"note":null,"extra":true}`,
+		`{"city":"Paris"`,
+		`[]`,
+	}
+	for _, data := range invalid {
+		if err := schema.ValidateJSON([]byte(data)); !errors.Is(err, jsonschema.ErrValueInvalid) {
+			t.Errorf("%s should be invalid, got %v", data, err)
+		}
+	}
+}
+
+func TestValidateJSONRefs(t *testing.T) {
+	schema, err := jsonschema.GenerateSchemaForType(tree{})
+	if err != nil {
+		t.Fatalf("GenerateSchemaForType error: %v", err)
+	}
+	data := `{"root":{"name":"a","children":[{"name":"b","children":[]}]}}`
+	if err = schema.ValidateJSON([]byte(data)); err != nil {
+		t.Fatalf("%s should be valid: %v", data, err)
+	}
+	data = `{"root":{"name":"a","children":[{"name":"b","children":[{"children":[]}]}]}}`
+	if err = schema.ValidateJSON([]byte(data)); !errors.Is(err, jsonschema.ErrValueInvalid) {
+		t.Fatalf("%s should be invalid, got %v", data, err)
+	}
+
+	union, err := jsonschema.DiscriminatedUnion("kind", map[string]jsonschema.Definition{
+		"circle": jsonschema.StrictObject("", map[string]jsonschema.Definition{"radius": {Type: jsonschema.Number}}),
+	})
+	if err != nil {
+		t.Fatalf("DiscriminatedUnion error: %v", err)
+	}
+	if err = union.ValidateJSON([]byte(`{"kind":"circle","radius":1.5}`)); err != nil {
+		t.Fatalf("The circle should be valid: %v", err)
+	}
+	if err = union.ValidateJSON([]byte(`{"kind":"square","radius":1.5}`)); !errors.Is(err, jsonschema.ErrValueInvalid) {
+		t.Fatalf("The square should be invalid, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package openai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+type latencyBudgetKey struct{}
+
+type latencyBudget struct {
+	budget   time.Duration
+	fallback *LatencyBudgetFallback
+}
+
+// LatencyBudgetFallback is what the retries of a call exceeding its latency budget switch to.
+type LatencyBudgetFallback struct {
+	// Model replaces the model of the retried request when set, e.g. with a smaller model.
+	Model string
+	// ServiceTier sets the service_tier of the retried request when set, e.g. "priority".
+	ServiceTier string
+}
+
+// LatencyBudgetEvent describes a call that took longer than its latency budget.
+type LatencyBudgetEvent struct {
+	Method   string
+	Path     string
+	Budget   time.Duration
+	Elapsed  time.Duration
+	Metadata map[string]string
+	Tag      string
+	// StatusCode is zero and Err is set when the call failed without a response.
+	StatusCode int
+	Err        error
+}
+
+// WithLatencyBudget returns a context carrying a soft latency budget for the calls made with it.
+// Unlike a deadline, exceeding the budget does not cancel the call: ClientConfig.OnLatencyBudgetExceeded
+// is called instead, so SLO breaches can be recorded and callers can adapt, e.g. by retrying
+// with a faster model, see WithLatencyBudgetFallback.
+//
+// The elapsed time is measured until the response headers arrive, which for streams is the
+// time to the first event.
+func WithLatencyBudget(ctx context.Context, budget time.Duration) context.Context {
+	return context.WithValue(ctx, latencyBudgetKey{}, latencyBudget{budget: budget})
+}
+
+// WithLatencyBudgetFallback attaches a latency budget like WithLatencyBudget, and switches the
+// retries of the calls exceeding it to fallback. An attempt is only retried as configured by
+// RetryConfig: the budget itself never fails a call. Only JSON request bodies are rewritten.
+func WithLatencyBudgetFallback(
+	ctx context.Context,
+	budget time.Duration,
+	fallback LatencyBudgetFallback,
+) context.Context {
+	return context.WithValue(ctx, latencyBudgetKey{}, latencyBudget{budget: budget, fallback: &fallback})
+}
+
+// LatencyBudgetFromContext returns the latency budget attached to ctx, if any.
+func LatencyBudgetFromContext(ctx context.Context) (budget time.Duration, ok bool) {
+	value, ok := ctx.Value(latencyBudgetKey{}).(latencyBudget)
+	return value.budget, ok
+}
+
+// applyLatencyBudgetFallback switches the body of req, whose last attempt took elapsed, to the
+// latency budget fallback of its context if the attempt exceeded the budget. Bodies that cannot
+// be read as a JSON object are resent unchanged.
+func applyLatencyBudgetFallback(req *http.Request, elapsed time.Duration) {
+	value, ok := req.Context().Value(latencyBudgetKey{}).(latencyBudget)
+	if !ok || value.fallback == nil || elapsed <= value.budget || req.GetBody == nil ||
+		!strings.HasPrefix(req.Header.Get("Content-Type"), "application/json") {
+		return
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return
+	}
+	defer body.Close()
+	var fields map[string]json.RawMessage
+	if err = json.NewDecoder(body).Decode(&fields); err != nil || fields == nil {
+		return
+	}
+	overrides := map[string]string{"model": value.fallback.Model, "service_tier": value.fallback.ServiceTier}
+	for key, override := range overrides {
+		if override != "" {
+			fields[key], _ = json.Marshal(override)
+		}
+	}
+	rewritten, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+	req.ContentLength = int64(len(rewritten))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(rewritten)), nil
+	}
+}
+
+func (c *Client) checkLatencyBudget(req *http.Request, res *http.Response, err error, elapsed time.Duration) {
+	if c.config.OnLatencyBudgetExceeded == nil {
+		return
+	}
+	budget, ok := LatencyBudgetFromContext(req.Context())
+	if !ok || elapsed <= budget {
+		return
+	}
+
+	event := LatencyBudgetEvent{
+		Method:   req.Method,
+		Path:     req.URL.Path,
+		Budget:   budget,
+		Elapsed:  elapsed,
+		Metadata: RequestMetadataFromContext(req.Context()),
+		Tag:      c.requestTag(req.Context()),
+		Err:      err,
+	}
+	if res != nil {
+		event.StatusCode = res.StatusCode
+	}
+	c.config.OnLatencyBudgetExceeded(event)
+}
@@ -0,0 +1,100 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestLatencyBudget(t *testing.T) {
+	delay := time.Duration(0)
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(delay)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var events []LatencyBudgetEvent
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.OnLatencyBudgetExceeded = func(event LatencyBudgetEvent) { events = append(events, event) }
+	client := NewClientWithConfig(config)
+
+	ctx := WithLatencyBudget(context.Background(), 50*time.Millisecond)
+	if budget, ok := LatencyBudgetFromContext(ctx); !ok || budget != 50*time.Millisecond {
+		t.Fatalf("Unexpected budget: %v", budget)
+	}
+
+	_, err := client.ListModels(ctx)
+	checks.NoError(t, err, "ListModels error")
+	if len(events) != 0 {
+		t.Fatalf("Calls within the budget should not be reported: %+v", events)
+	}
+
+	delay = 60 * time.Millisecond
+	_, err = client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if len(events) != 0 {
+		t.Fatalf("Calls without a budget should not be reported: %+v", events)
+	}
+
+	_, err = client.ListModels(ctx)
+	checks.NoError(t, err, "ListModels should not be cancelled by the budget")
+	if len(events) != 1 {
+		t.Fatalf("Expected one exceeded budget event, got %d", len(events))
+	}
+	event := events[0]
+	if event.Path != "/v1/models" || event.StatusCode != http.StatusOK || event.Elapsed < delay {
+		t.Fatalf("Unexpected event: %+v", event)
+	}
+}
+
+func TestLatencyBudgetFallback(t *testing.T) {
+	var attempts []map[string]any
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		attempts = append(attempts, body)
+		if len(attempts) == 1 {
+			time.Sleep(30 * time.Millisecond)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"1","choices":[]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.Retry = &RetryConfig{MaxAttempts: 2, InitialDelay: time.Millisecond}
+	client := NewClientWithConfig(config)
+
+	ctx := WithLatencyBudgetFallback(context.Background(), 10*time.Millisecond,
+		LatencyBudgetFallback{Model: GPT4oMini, ServiceTier: "priority"})
+	_, err := client.CreateChatCompletion(ctx, ChatCompletionRequest{
+		Model:    GPT4o,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hi"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if len(attempts) != 2 || attempts[0]["model"] != GPT4o || attempts[0]["service_tier"] != nil {
+		t.Fatalf("Unexpected attempts: %v", attempts)
+	}
+	if attempts[1]["model"] != GPT4oMini || attempts[1]["service_tier"] != "priority" || attempts[1]["messages"] == nil {
+		t.Fatalf("Expected the retry to switch to the fallback, got %v", attempts[1])
+	}
+	if budget, ok := LatencyBudgetFromContext(ctx); !ok || budget != 10*time.Millisecond {
+		t.Fatalf("Unexpected budget %s, %v", budget, ok)
+	}
+}
@@ -0,0 +1,31 @@
+package openai
+
+import (
+	"context"
+)
+
+type requestMetadataKey struct{}
+
+// WithRequestMetadata returns a context carrying key/value metadata about the calls made
+// with it, such as the request purpose, tenant or trace ID. The metadata is merged with
+// any metadata already attached to ctx, later values winning.
+//
+// The client sends the metadata as headers when ClientConfig.MetadataHeaderPrefix is set,
+// and it is available to hooks and wrappers through RequestMetadataFromContext.
+func WithRequestMetadata(ctx context.Context, metadata map[string]string) context.Context {
+	parent := RequestMetadataFromContext(ctx)
+	merged := make(map[string]string, len(parent)+len(metadata))
+	for key, value := range parent {
+		merged[key] = value
+	}
+	for key, value := range metadata {
+		merged[key] = value
+	}
+	return context.WithValue(ctx, requestMetadataKey{}, merged)
+}
+
+// RequestMetadataFromContext returns the metadata attached to ctx. The returned map must not be modified.
+func RequestMetadataFromContext(ctx context.Context) map[string]string {
+	metadata, _ := ctx.Value(requestMetadataKey{}).(map[string]string)
+	return metadata
+}
@@ -0,0 +1,58 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestWithRequestMetadata(t *testing.T) {
+	ctx := WithRequestMetadata(context.Background(), map[string]string{"tenant": "acme", "purpose": "search"})
+	ctx = WithRequestMetadata(ctx, map[string]string{"purpose": "summary", "trace-id": "abc"})
+
+	metadata := RequestMetadataFromContext(ctx)
+	expected := map[string]string{"tenant": "acme", "purpose": "summary", "trace-id": "abc"}
+	if fmt.Sprint(metadata) != fmt.Sprint(expected) {
+		t.Fatalf("Unexpected metadata: %v", metadata)
+	}
+
+	if RequestMetadataFromContext(context.Background()) != nil {
+		t.Fatal("Context without metadata should return nil")
+	}
+}
+
+func TestRequestMetadataHeaders(t *testing.T) {
+	var header http.Header
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		fmt.Fprintln(w, `{"data":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	ctx := WithRequestMetadata(context.Background(), map[string]string{"tenant": "acme"})
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	_, err := client.ListModels(ctx)
+	checks.NoError(t, err, "ListModels error")
+	if header.Get("X-App-Tenant") != "" {
+		t.Fatal("Metadata headers should not be sent without a prefix")
+	}
+
+	config.MetadataHeaderPrefix = "X-App-"
+	client = NewClientWithConfig(config)
+	_, err = client.ListModels(ctx)
+	checks.NoError(t, err, "ListModels error")
+	if header.Get("X-App-Tenant") != "acme" {
+		t.Fatalf("Metadata header not sent: %v", header)
+	}
+}
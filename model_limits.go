@@ -0,0 +1,105 @@
+package openai
+
+import (
+	"errors"
+)
+
+var (
+	ErrMaxTokensHeadroomExhausted = errors.New("the estimated prompt leaves no room for completion tokens in the context window") //nolint:lll
+)
+
+// ModelLimits describes the token limits of a model.
+type ModelLimits struct {
+	// ContextWindow is the maximum number of prompt plus completion tokens.
+	ContextWindow int
+	// MaxOutputTokens is the maximum number of completion tokens.
+	MaxOutputTokens int
+}
+
+// modelLimits lists the limits of models and their dated snapshots by exact name.
+var modelLimits = map[string]ModelLimits{
+	GPT3Dot5Turbo:            {ContextWindow: 16385, MaxOutputTokens: 4096},
+	GPT3Dot5Turbo0301:        {ContextWindow: 4096, MaxOutputTokens: 4096},
+	"gpt-3.5-turbo-0613":     {ContextWindow: 4096, MaxOutputTokens: 4096},
+	"gpt-3.5-turbo-1106":     {ContextWindow: 16385, MaxOutputTokens: 4096},
+	"gpt-3.5-turbo-0125":     {ContextWindow: 16385, MaxOutputTokens: 4096},
+	"gpt-3.5-turbo-16k":      {ContextWindow: 16385, MaxOutputTokens: 4096},
+	GPT4:                     {ContextWindow: 8192, MaxOutputTokens: 8192},
+	GPT40314:                 {ContextWindow: 8192, MaxOutputTokens: 8192},
+	"gpt-4-0613":             {ContextWindow: 8192, MaxOutputTokens: 8192},
+	GPT432K:                  {ContextWindow: 32768, MaxOutputTokens: 32768},
+	GPT432K0314:              {ContextWindow: 32768, MaxOutputTokens: 32768},
+	"gpt-4-32k-0613":         {ContextWindow: 32768, MaxOutputTokens: 32768},
+	"gpt-4-turbo":            {ContextWindow: 128000, MaxOutputTokens: 4096},
+	"gpt-4-turbo-2024-04-09": {ContextWindow: 128000, MaxOutputTokens: 4096},
+	"gpt-4-1106-preview":     {ContextWindow: 128000, MaxOutputTokens: 4096},
+	"gpt-4-0125-preview":     {ContextWindow: 128000, MaxOutputTokens: 4096},
+	GPT4o:                    {ContextWindow: 128000, MaxOutputTokens: 16384},
+	"gpt-4o-2024-05-13":      {ContextWindow: 128000, MaxOutputTokens: 4096},
+	"gpt-4o-2024-08-06":      {ContextWindow: 128000, MaxOutputTokens: 16384},
+	"gpt-4o-2024-11-20":      {ContextWindow: 128000, MaxOutputTokens: 16384},
+	GPT4oMini:                {ContextWindow: 128000, MaxOutputTokens: 16384},
+	"gpt-4o-mini-2024-07-18": {ContextWindow: 128000, MaxOutputTokens: 16384},
+	O1:                       {ContextWindow: 200000, MaxOutputTokens: 100000},
+	"o1-2024-12-17":          {ContextWindow: 200000, MaxOutputTokens: 100000},
+	O1Mini:                   {ContextWindow: 128000, MaxOutputTokens: 65536},
+	"o1-mini-2024-09-12":     {ContextWindow: 128000, MaxOutputTokens: 65536},
+	O3:                       {ContextWindow: 200000, MaxOutputTokens: 100000},
+	"o3-2025-04-16":          {ContextWindow: 200000, MaxOutputTokens: 100000},
+	O3Mini:                   {ContextWindow: 200000, MaxOutputTokens: 100000},
+	"o3-mini-2025-01-31":     {ContextWindow: 200000, MaxOutputTokens: 100000},
+	O4Mini:                   {ContextWindow: 200000, MaxOutputTokens: 100000},
+	"o4-mini-2025-04-16":     {ContextWindow: 200000, MaxOutputTokens: 100000},
+	GPT5:                     {ContextWindow: 400000, MaxOutputTokens: 128000},
+	"gpt-5-2025-08-07":       {ContextWindow: 400000, MaxOutputTokens: 128000},
+	GPT5Mini:                 {ContextWindow: 400000, MaxOutputTokens: 128000},
+	"gpt-5-mini-2025-08-07":  {ContextWindow: 400000, MaxOutputTokens: 128000},
+	GPT5Nano:                 {ContextWindow: 400000, MaxOutputTokens: 128000},
+	"gpt-5-nano-2025-08-07":  {ContextWindow: 400000, MaxOutputTokens: 128000},
+	GPT3TextDavinci003:       {ContextWindow: 4097, MaxOutputTokens: 4097},
+	GPT3TextDavinci002:       {ContextWindow: 4097, MaxOutputTokens: 4097},
+}
+
+// LookupModelLimits returns the token limits of model, which must be a known model or dated
+// snapshot such as gpt-4-0613. Other models, e.g. fine-tunes and deployment names, are not
+// limited: it returns false for them rather than guessing from a similar name.
+func LookupModelLimits(model string) (ModelLimits, bool) {
+	limits, ok := modelLimits[model]
+	return limits, ok
+}
+
+// completionHeadroom returns how many completion tokens fit next to promptTokens, keeping reserve
+// tokens free to absorb estimation errors. It returns false for models with unknown limits.
+func completionHeadroom(model string, promptTokens, reserve int) (int, bool, error) {
+	limits, ok := LookupModelLimits(model)
+	if !ok {
+		return 0, false, nil
+	}
+	headroom := limits.ContextWindow - promptTokens - reserve
+	if headroom <= 0 {
+		return 0, true, ErrMaxTokensHeadroomExhausted
+	}
+	if headroom > limits.MaxOutputTokens {
+		headroom = limits.MaxOutputTokens
+	}
+	return headroom, true, nil
+}
+
+// applyMaxTokensHeadroom sets the completion token limit of request when AutoMaxTokens is enabled
+// and the request does not set one.
+func (c *Client) applyMaxTokensHeadroom(request *ChatCompletionRequest) error {
+	if !c.config.AutoMaxTokens || request.MaxTokens != 0 || request.MaxCompletionTokens != 0 {
+		return nil
+	}
+	headroom, ok, err := completionHeadroom(request.Model, estimateChatTokens(request.Messages), c.config.MaxTokensReserve)
+	if err != nil || !ok {
+		return err
+	}
+	// Reasoning models reject max_tokens.
+	if isReasoningModel(request.Model) {
+		request.MaxCompletionTokens = headroom
+	} else {
+		request.MaxTokens = headroom
+	}
+	return nil
+}
@@ -0,0 +1,82 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestLookupModelLimits(t *testing.T) {
+	cases := []struct {
+		model   string
+		context int
+		found   bool
+	}{
+		{GPT4, 8192, true},
+		{"gpt-4-0613", 8192, true},
+		{"gpt-4-32k-0613", 32768, true},
+		{"gpt-4o-mini-2024-07-18", 128000, true},
+		{"my-fine-tuned-model", 0, false},
+		{"gpt-4-my-deployment", 0, false},
+		{"gpt-4o-mini-2099-01-01", 0, false},
+	}
+	for _, c := range cases {
+		limits, ok := LookupModelLimits(c.model)
+		if ok != c.found || limits.ContextWindow != c.context {
+			t.Errorf("Unexpected limits for %s: %+v, %v", c.model, limits, ok)
+		}
+	}
+}
+
+func TestAutoMaxTokens(t *testing.T) {
+	var received ChatCompletionRequest
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if received, err = getChatCompletionBody(r); err != nil {
+			http.Error(w, "could not read request", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintln(w, `{"id":"chatcmpl-1","choices":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.AutoMaxTokens = true
+	config.MaxTokensReserve = 100
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	// 4000 ASCII characters are estimated at 1000 tokens, plus 7 tokens of chat overhead.
+	messages := []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: strings.Repeat("a", 4000)}}
+	_, err := client.CreateChatCompletion(ctx, ChatCompletionRequest{Model: GPT4, Messages: messages})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if received.MaxTokens != 8192-1007-100 {
+		t.Fatalf("Unexpected max_tokens: %d", received.MaxTokens)
+	}
+
+	_, err = client.CreateChatCompletion(ctx, ChatCompletionRequest{Model: O3Mini, Messages: messages})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if received.MaxTokens != 0 || received.MaxCompletionTokens != 100000 {
+		t.Fatalf("Reasoning models should get max_completion_tokens capped at the output limit: %+v", received)
+	}
+
+	_, err = client.CreateChatCompletion(ctx, ChatCompletionRequest{Model: GPT4, Messages: messages, MaxTokens: 5})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if received.MaxTokens != 5 {
+		t.Fatalf("Explicit max_tokens should be kept, got %d", received.MaxTokens)
+	}
+
+	messages[0].Content = strings.Repeat("a", 40000)
+	_, err = client.CreateChatCompletion(ctx, ChatCompletionRequest{Model: GPT4, Messages: messages})
+	checks.ErrorIs(t, err, ErrMaxTokensHeadroomExhausted, "prompt larger than the context window should fail")
+}
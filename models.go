@@ -35,11 +35,14 @@ type Permission struct {
 // ModelsList is a list of models, including those that belong to the user or organization.
 type ModelsList struct {
 	Models []Model `json:"data"`
+
+	ResponseMeta `json:"-"`
 }
 
 // ListModels Lists the currently available models,
 // and provides basic information about each model such as the model id and parent.
-func (c *Client) ListModels(ctx context.Context) (models ModelsList, err error) {
+func (c *Client) ListModels(ctx context.Context, opts ...RequestOption) (models ModelsList, err error) {
+	ctx = withCallOptions(ctx, opts)
 	req, err := c.requestBuilder.build(ctx, http.MethodGet, c.fullURL("/models"), nil)
 	if err != nil {
 		return
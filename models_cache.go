@@ -0,0 +1,85 @@
+package openai
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ModelChange lists the model IDs that appeared or vanished between two refreshes of a ModelCache.
+type ModelChange struct {
+	Added   []string
+	Removed []string
+}
+
+// ModelCache caches ListModels for a TTL. It is safe for concurrent use.
+type ModelCache struct {
+	client   *Client
+	ttl      time.Duration
+	onChange func(change ModelChange)
+
+	mu        sync.Mutex
+	models    ModelsList
+	fetchedAt time.Time
+	fetched   bool
+}
+
+// NewModelCache creates a cache of the models available to client, refreshed when older than ttl.
+// onChange, if not nil, is called when a refresh finds added or removed models;
+// the first fetch is not reported.
+func NewModelCache(client *Client, ttl time.Duration, onChange func(change ModelChange)) *ModelCache {
+	return &ModelCache{client: client, ttl: ttl, onChange: onChange}
+}
+
+// ListModels returns the cached models, refreshing them first if the cache is empty or expired.
+// If a refresh fails the error is returned and the previous models stay cached.
+func (m *ModelCache) ListModels(ctx context.Context) (models ModelsList, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.fetched && time.Since(m.fetchedAt) < m.ttl {
+		return m.models, nil
+	}
+
+	models, err = m.client.ListModels(ctx)
+	if err != nil {
+		return
+	}
+
+	if m.fetched && m.onChange != nil {
+		if change := diffModels(m.models, models); len(change.Added) > 0 || len(change.Removed) > 0 {
+			m.onChange(change)
+		}
+	}
+	m.models = models
+	m.fetchedAt = time.Now()
+	m.fetched = true
+	return
+}
+
+// Invalidate makes the next ListModels call refresh the cache.
+func (m *ModelCache) Invalidate() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.fetchedAt = time.Time{}
+}
+
+func diffModels(previous, current ModelsList) (change ModelChange) {
+	seen := make(map[string]bool, len(previous.Models))
+	for _, model := range previous.Models {
+		seen[model.ID] = true
+	}
+	for _, model := range current.Models {
+		if !seen[model.ID] {
+			change.Added = append(change.Added, model.ID)
+		}
+		delete(seen, model.ID)
+	}
+	for id := range seen {
+		change.Removed = append(change.Removed, id)
+	}
+	sort.Strings(change.Added)
+	sort.Strings(change.Removed)
+	return
+}
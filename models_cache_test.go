@@ -0,0 +1,63 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestModelCache(t *testing.T) {
+	ids := []string{"gpt-4", "gpt-3.5-turbo"}
+	calls := 0
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var list ModelsList
+		for _, id := range ids {
+			list.Models = append(list.Models, Model{ID: id})
+		}
+		resBytes, _ := json.Marshal(list)
+		_, _ = w.Write(resBytes)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	var changes []ModelChange
+	cache := NewModelCache(client, time.Hour, func(change ModelChange) { changes = append(changes, change) })
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		models, err := cache.ListModels(ctx)
+		checks.NoError(t, err, "ListModels error")
+		if len(models.Models) != 2 {
+			t.Fatalf("Unexpected models: %+v", models)
+		}
+	}
+	if calls != 1 || len(changes) != 0 {
+		t.Fatalf("Expected a single fetch without changes, got %d fetches and %+v", calls, changes)
+	}
+
+	ids = []string{"gpt-4", "gpt-4o", "gpt-4o-mini"}
+	cache.Invalidate()
+	_, err := cache.ListModels(ctx)
+	checks.NoError(t, err, "ListModels error")
+	if calls != 2 || len(changes) != 1 {
+		t.Fatalf("Expected a refresh reporting one change, got %d fetches and %+v", calls, changes)
+	}
+	added, removed := strings.Join(changes[0].Added, ","), strings.Join(changes[0].Removed, ",")
+	if added != "gpt-4o,gpt-4o-mini" || removed != "gpt-3.5-turbo" {
+		t.Fatalf("Unexpected change: %+v", changes[0])
+	}
+}
@@ -58,11 +58,18 @@ type ModerationResponse struct {
 	ID      string   `json:"id"`
 	Model   string   `json:"model"`
 	Results []Result `json:"results"`
+
+	ResponseMeta `json:"-"`
 }
 
 // Moderations — perform a moderation api call over a string.
 // Input can be an array or slice but a string will reduce the complexity.
-func (c *Client) Moderations(ctx context.Context, request ModerationRequest) (response ModerationResponse, err error) {
+func (c *Client) Moderations(
+	ctx context.Context,
+	request ModerationRequest,
+	opts ...RequestOption,
+) (response ModerationResponse, err error) {
+	ctx = withCallOptions(ctx, opts)
 	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL("/moderations"), request)
 	if err != nil {
 		return
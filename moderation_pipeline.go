@@ -0,0 +1,255 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultModerationBatchSize   = 32
+	defaultModerationConcurrency = 2
+	defaultModerationRetryDelay  = time.Second
+)
+
+// ModerationItem is a text moderated by RunModerationPipeline.
+type ModerationItem struct {
+	ID   string
+	Text string
+}
+
+// ModerationOutcome is the moderation result of an item, or the error its batch failed with.
+type ModerationOutcome struct {
+	ID     string
+	Result Result
+	Err    error
+}
+
+// ModerationPipelineProgress reports how far a pipeline run has got.
+type ModerationPipelineProgress struct {
+	Done    int
+	Flagged int
+	Failed  int
+}
+
+// ModerationPipelineConfig configures RunModerationPipeline.
+type ModerationPipelineConfig struct {
+	Model string
+	// BatchSize is the number of items per moderation request, defaults to 32.
+	BatchSize int
+	// Concurrency is the number of batches in flight, defaults to 2.
+	Concurrency int
+	// MaxAttempts is how many times a batch is tried before its items fail, defaults to 1. When set
+	// above 1, it is used as the RetryConfig of the moderation calls.
+	MaxAttempts int
+	// RetryDelay is the delay before the first retry of a batch, doubled for every further retry.
+	// Defaults to one second. A rate limited batch holds back new batches for its delay.
+	RetryDelay time.Duration
+	// OnProgress is called after every batch. Calls are serialized.
+	OnProgress func(progress ModerationPipelineProgress)
+}
+
+// moderationBatchRequest moderates several inputs in one request.
+type moderationBatchRequest struct {
+	Input []string `json:"input"`
+	Model string   `json:"model,omitempty"`
+}
+
+// RunModerationPipeline moderates the items received from in, in concurrent batches, and
+// sends an outcome for every item to out, e.g. for trust-and-safety backfills. Batches take
+// their estimated tokens from ClientConfig.TokenLimiter, if any. Failed batches do not stop
+// the run: their items are sent with the error. It returns once in is closed and every
+// outcome was sent, or with the error of ctx once it is done; it does not close out.
+func (c *Client) RunModerationPipeline(
+	ctx context.Context,
+	in <-chan ModerationItem,
+	out chan<- ModerationOutcome,
+	config ModerationPipelineConfig,
+	opts ...RequestOption,
+) error {
+	ctx = withCallOptions(ctx, opts)
+	batchSize := config.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultModerationBatchSize
+	}
+	concurrency := config.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultModerationConcurrency
+	}
+	pauser := &moderationPauser{}
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		progress ModerationPipelineProgress
+	)
+	work := make(chan []ModerationItem)
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for batch := range work {
+				outcomes := c.moderateBatchWithRetries(ctx, batch, config, pauser)
+
+				mu.Lock()
+				for _, outcome := range outcomes {
+					switch {
+					case outcome.Err != nil:
+						progress.Failed++
+					case outcome.Result.Flagged:
+						progress.Flagged++
+					}
+				}
+				progress.Done += len(outcomes)
+				if config.OnProgress != nil {
+					config.OnProgress(progress)
+				}
+				mu.Unlock()
+
+				for _, outcome := range outcomes {
+					select {
+					case out <- outcome:
+					case <-ctx.Done():
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	batchModerationItems(ctx, in, batchSize, work)
+	close(work)
+	wg.Wait()
+	return ctx.Err()
+}
+
+// batchModerationItems groups the items received from in into batches sent to work, until in is closed
+// or ctx is done.
+func batchModerationItems(ctx context.Context, in <-chan ModerationItem, batchSize int, work chan<- []ModerationItem) {
+	var batch []ModerationItem
+	send := func() bool {
+		select {
+		case work <- batch:
+			batch = nil
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+	for {
+		select {
+		case item, ok := <-in:
+			if !ok {
+				if len(batch) > 0 {
+					send()
+				}
+				return
+			}
+			if batch = append(batch, item); len(batch) == batchSize && !send() {
+				return
+			}
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (c *Client) moderateBatchWithRetries(
+	ctx context.Context,
+	batch []ModerationItem,
+	config ModerationPipelineConfig,
+	pauser *moderationPauser,
+) []ModerationOutcome {
+	if config.MaxAttempts > 1 {
+		delay := config.RetryDelay
+		if delay <= 0 {
+			delay = defaultModerationRetryDelay
+		}
+		ctx = WithRequestOptions(ctx,
+			WithRetry(&RetryConfig{MaxAttempts: config.MaxAttempts, InitialDelay: delay}),
+			withRetryHook(func(attempt RetryAttempt) {
+				if attempt.StatusCode == http.StatusTooManyRequests {
+					pauser.pause(attempt.Delay)
+				}
+			}))
+	}
+
+	var results []Result
+	err := pauser.wait(ctx)
+	if err == nil {
+		results, err = c.moderateBatch(ctx, batch, config.Model)
+	}
+	outcomes := make([]ModerationOutcome, len(batch))
+	for i, item := range batch {
+		outcomes[i] = ModerationOutcome{ID: item.ID, Err: err}
+		if err == nil {
+			outcomes[i].Result = results[i]
+		}
+	}
+	return outcomes
+}
+
+func (c *Client) moderateBatch(ctx context.Context, batch []ModerationItem, model string) ([]Result, error) {
+	request := moderationBatchRequest{Input: make([]string, len(batch)), Model: model}
+	var tokens int
+	for i, item := range batch {
+		request.Input[i] = item.Text
+		tokens += estimateTokens(item.Text)
+	}
+	if c.config.TokenLimiter != nil {
+		if err := c.config.TokenLimiter.Acquire(ctx, tokens); err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL("/moderations"), request)
+	if err != nil {
+		return nil, err
+	}
+	var response ModerationResponse
+	if err = c.sendRequest(req, &response); err != nil {
+		return nil, err
+	}
+	if len(response.Results) != len(batch) {
+		return nil, fmt.Errorf("moderation returned %d results for %d inputs", len(response.Results), len(batch))
+	}
+	return response.Results, nil
+}
+
+// moderationPauser holds back all batches of a pipeline run after one was rate limited.
+type moderationPauser struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+func (p *moderationPauser) pause(delay time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if until := time.Now().Add(delay); until.After(p.until) {
+		p.until = until
+	}
+}
+
+func (p *moderationPauser) wait(ctx context.Context) error {
+	p.mu.Lock()
+	delay := time.Until(p.until)
+	p.mu.Unlock()
+	if delay <= 0 {
+		return ctx.Err()
+	}
+	return sleepContext(ctx, delay)
+}
+
+// sleepContext waits for delay, or until ctx is done.
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
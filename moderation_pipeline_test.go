@@ -0,0 +1,93 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRunModerationPipeline(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests int
+	)
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/moderations", func(w http.ResponseWriter, r *http.Request) {
+		var req struct {
+			Input []string `json:"input"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || len(req.Input) == 0 || len(req.Input) > 3 {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		requests++
+		first := requests == 1
+		mu.Unlock()
+		if first {
+			w.WriteHeader(http.StatusTooManyRequests)
+			fmt.Fprint(w, `{"error":{"message":"rate limited"}}`)
+			return
+		}
+		if req.Input[0] == "broken" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error":{"message":"invalid input"}}`)
+			return
+		}
+		results := make([]string, len(req.Input))
+		for i, input := range req.Input {
+			results[i] = fmt.Sprintf(`{"flagged":%t}`, strings.Contains(input, "hate"))
+		}
+		fmt.Fprintf(w, `{"id":"modr-1","results":[%s]}`, strings.Join(results, ","))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.TokenLimiter = NewTokenLimiter(1000000, true)
+	client := NewClientWithConfig(config)
+
+	texts := []string{"hello", "hate speech", "ok", "broken", "bad", "worse", "fine"}
+	in := make(chan ModerationItem)
+	out := make(chan ModerationOutcome, len(texts))
+	go func() {
+		for i, text := range texts {
+			in <- ModerationItem{ID: fmt.Sprint(i), Text: text}
+		}
+		close(in)
+	}()
+
+	var last ModerationPipelineProgress
+	err := client.RunModerationPipeline(context.Background(), in, out, ModerationPipelineConfig{
+		BatchSize:   3,
+		Concurrency: 1,
+		MaxAttempts: 2,
+		RetryDelay:  time.Millisecond,
+		OnProgress:  func(progress ModerationPipelineProgress) { last = progress },
+	})
+	checks.NoError(t, err, "RunModerationPipeline error")
+	close(out)
+
+	outcomes := map[string]ModerationOutcome{}
+	for outcome := range out {
+		outcomes[outcome.ID] = outcome
+	}
+	if len(outcomes) != len(texts) || !outcomes["1"].Result.Flagged || outcomes["0"].Result.Flagged ||
+		outcomes["0"].Err != nil || outcomes["3"].Err == nil || outcomes["5"].Err == nil || outcomes["6"].Err != nil {
+		t.Fatalf("Unexpected outcomes: %+v", outcomes)
+	}
+	if last != (ModerationPipelineProgress{Done: 7, Flagged: 1, Failed: 3}) {
+		t.Fatalf("Unexpected progress: %+v", last)
+	}
+}
@@ -0,0 +1,62 @@
+// Package openaitest provides canonical JSON payloads of the OpenAI API and helpers to check
+// that Go types, including custom extensions of the go-openai types, stay wire-compatible.
+package openaitest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+//go:embed fixtures/*.json
+var fixtures embed.FS
+
+// Fixture names.
+const (
+	ChatCompletionRequest        = "chat_completion_request"
+	ChatCompletionResponse       = "chat_completion_response"
+	ChatCompletionStreamResponse = "chat_completion_stream_response"
+)
+
+// Fixture returns the canonical JSON payload with the given name.
+// It panics for unknown names, which is a programming error in a test.
+func Fixture(name string) []byte {
+	data, err := fixtures.ReadFile("fixtures/" + name + ".json")
+	if err != nil {
+		panic(fmt.Sprintf("openaitest: unknown fixture %q", name))
+	}
+	return data
+}
+
+// RoundTrip decodes data into v, encodes v again and checks that the result is semantically
+// equal to data, i.e. that no field was lost or renamed on the way. v must be a pointer.
+func RoundTrip(data []byte, v any) error {
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("decoding fixture: %w", err)
+	}
+	encoded, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding %T: %w", v, err)
+	}
+	return Equal(data, encoded)
+}
+
+// Equal checks that two JSON documents are semantically equal, ignoring formatting and key order.
+func Equal(expected, actual []byte) error {
+	var want, got any
+	if err := json.Unmarshal(expected, &want); err != nil {
+		return fmt.Errorf("decoding expected JSON: %w", err)
+	}
+	if err := json.Unmarshal(actual, &got); err != nil {
+		return fmt.Errorf("decoding actual JSON: %w", err)
+	}
+	if reflect.DeepEqual(want, got) {
+		return nil
+	}
+
+	// Maps are encoded with sorted keys, which makes the two documents comparable by eye.
+	wantJSON, _ := json.Marshal(want)
+	gotJSON, _ := json.Marshal(got)
+	return fmt.Errorf("JSON mismatch:\nexpected: %s\nactual:   %s", wantJSON, gotJSON)
+}
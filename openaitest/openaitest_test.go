@@ -0,0 +1,36 @@
+package openaitest_test
+
+import (
+	"github.com/sashabaranov/go-openai/openaitest"
+
+	"strings"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	if err := openaitest.Equal([]byte(`{"a":1,"b":[true]}`), []byte(`{"b":[true], "a":1.0}`)); err != nil {
+		t.Fatalf("Documents should be equal: %v", err)
+	}
+	err := openaitest.Equal([]byte(`{"a":1}`), []byte(`{"a":1,"b":2}`))
+	if err == nil || !strings.Contains(err.Error(), `actual:   {"a":1,"b":2}`) {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+func TestRoundTripDetectsLostFields(t *testing.T) {
+	var v struct {
+		Model string `json:"model"`
+	}
+	if err := openaitest.RoundTrip([]byte(`{"model":"gpt-4","user":"x"}`), &v); err == nil {
+		t.Fatal("Dropped fields should be reported")
+	}
+}
+
+func TestUnknownFixturePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Unknown fixture should panic")
+		}
+	}()
+	openaitest.Fixture("does_not_exist")
+}
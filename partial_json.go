@@ -0,0 +1,154 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrPartialJSONTrailingData = errors.New("structured output has data after the JSON value")
+)
+
+type partialJSONFrame struct {
+	object    bool
+	expectKey bool
+	// safe is the offset up to which the input can be cut and closed into valid JSON.
+	safe int
+}
+
+// RepairPartialJSON turns a truncated JSON document, e.g. a structured output that is still
+// streaming, into valid JSON: an unterminated string value is closed, a trailing incomplete
+// key, literal or number is dropped, and open objects and arrays are closed. Complete
+// documents are returned unchanged.
+func RepairPartialJSON(data []byte) []byte {
+	frames := []partialJSONFrame{{}}
+	var (
+		inString, stringIsKey, escaped bool
+		tokenStart                     = -1
+		// unicodeEscape is the offset of the last \u escape in the current string.
+		unicodeEscape = -1
+	)
+	top := func() *partialJSONFrame { return &frames[len(frames)-1] }
+	// valueDone marks the end of a value, making the input up to end safe to cut at.
+	valueDone := func(end int) {
+		top().safe = end
+	}
+
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if inString {
+			switch {
+			case escaped:
+				escaped = false
+				if c == 'u' {
+					unicodeEscape = i - 1
+				}
+			case c == '\\':
+				escaped = true
+			case c == '"':
+				inString = false
+				if !stringIsKey {
+					valueDone(i + 1)
+				}
+			}
+			continue
+		}
+
+		if tokenStart >= 0 {
+			if bytes.IndexByte([]byte(" \t\r\n,:]}"), c) < 0 {
+				continue
+			}
+			tokenStart = -1
+			valueDone(i)
+		}
+
+		switch c {
+		case '{', '[':
+			frames = append(frames, partialJSONFrame{object: c == '{', expectKey: c == '{', safe: i + 1})
+		case '}', ']':
+			if len(frames) > 1 {
+				frames = frames[:len(frames)-1]
+			}
+			valueDone(i + 1)
+		case ',':
+			top().expectKey = top().object
+		case ':':
+			top().expectKey = false
+		case '"':
+			inString, stringIsKey = true, top().object && top().expectKey
+			unicodeEscape = -1
+		case ' ', '\t', '\r', '\n':
+		default:
+			tokenStart = i
+		}
+	}
+
+	var repaired []byte
+	switch {
+	case inString && !stringIsKey:
+		value := data
+		if escaped {
+			value = value[:len(value)-1]
+		} else if unicodeEscape >= 0 && len(value)-unicodeEscape < 6 {
+			value = value[:unicodeEscape]
+		}
+		repaired = append(append([]byte{}, value...), '"')
+	case tokenStart >= 0 && json.Valid(data[tokenStart:]):
+		repaired = append([]byte{}, data...)
+	default:
+		repaired = append([]byte{}, data[:top().safe]...)
+	}
+
+	for i := len(frames) - 1; i > 0; i-- {
+		if frames[i].object {
+			repaired = append(repaired, '}')
+		} else {
+			repaired = append(repaired, ']')
+		}
+	}
+	return repaired
+}
+
+// PartialJSONDecoder decodes a structured output of type T while it is streamed:
+// write the content deltas to it, call Snapshot for a best-effort view of the fields
+// received so far, and Final once the stream ended.
+type PartialJSONDecoder[T any] struct {
+	buffer bytes.Buffer
+}
+
+// Write appends a chunk of the document. It never fails.
+func (d *PartialJSONDecoder[T]) Write(p []byte) (int, error) {
+	return d.buffer.Write(p)
+}
+
+// WriteString appends a chunk of the document. It never fails.
+func (d *PartialJSONDecoder[T]) WriteString(s string) (int, error) {
+	return d.buffer.WriteString(s)
+}
+
+// Snapshot decodes the document received so far as repaired by RepairPartialJSON. String fields
+// may hold a prefix of their final value, and fields not received yet keep their zero value.
+func (d *PartialJSONDecoder[T]) Snapshot() (value T, err error) {
+	repaired := RepairPartialJSON(d.buffer.Bytes())
+	if len(bytes.TrimSpace(repaired)) == 0 {
+		return
+	}
+	err = json.Unmarshal(repaired, &value)
+	return
+}
+
+// Final strictly decodes the complete document: it must be a single JSON value
+// without fields unknown to T.
+func (d *PartialJSONDecoder[T]) Final() (value T, err error) {
+	decoder := json.NewDecoder(bytes.NewReader(d.buffer.Bytes()))
+	decoder.DisallowUnknownFields()
+	if err = decoder.Decode(&value); err != nil {
+		return
+	}
+	if decoder.More() {
+		err = fmt.Errorf("%w at offset %d", ErrPartialJSONTrailingData, decoder.InputOffset())
+	}
+	return
+}
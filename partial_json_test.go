@@ -0,0 +1,83 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"encoding/json"
+	"testing"
+)
+
+func TestRepairPartialJSON(t *testing.T) {
+	testCases := []struct {
+		partial  string
+		expected string
+	}{
+		{``, ``},
+		{`{"name":"Ada","tags":["a","b"]}`, `{"name":"Ada","tags":["a","b"]}`},
+		{`{`, `{}`},
+		{`{"na`, `{}`},
+		{`{"name"`, `{}`},
+		{`{"name":`, `{}`},
+		{`{"name":"Ad`, `{"name":"Ad"}`},
+		{`{"name":"Ada",`, `{"name":"Ada"}`},
+		{`{"name":"A\`, `{"name":"A"}`},
+		{`{"name":"A\u00`, `{"name":"A"}`},
+		{`{"a":"c\\u00`, `{"a":"c\\u00"}`},
+		{`{"a":"\u0041\u00e`, `{"a":"\u0041"}`},
+		{`{"age":4`, `{"age":4}`},
+		{`{"age":4.`, `{}`},
+		{`{"ok":tru`, `{}`},
+		{`{"ok":true,"tags":["a",`, `{"ok":true,"tags":["a"]}`},
+		{`{"items":[{"id":1},{"id"`, `{"items":[{"id":1},{}]}`},
+		{`[1, 2, {"a": [`, `[1, 2, {"a": []}]`},
+		{`"partial`, `"partial"`},
+	}
+	for _, tc := range testCases {
+		repaired := string(RepairPartialJSON([]byte(tc.partial)))
+		if repaired != tc.expected {
+			t.Errorf("RepairPartialJSON(%q) = %q, expected %q", tc.partial, repaired, tc.expected)
+		}
+		if repaired != "" && !json.Valid([]byte(repaired)) {
+			t.Errorf("RepairPartialJSON(%q) = %q is not valid JSON", tc.partial, repaired)
+		}
+	}
+}
+
+func TestPartialJSONDecoder(t *testing.T) {
+	type person struct {
+		Name string   `json:"name"`
+		Tags []string `json:"tags"`
+	}
+
+	var decoder PartialJSONDecoder[person]
+	snapshot, err := decoder.Snapshot()
+	checks.NoError(t, err, "Snapshot of an empty document should not fail")
+
+	var names []string
+	for _, delta := range []string{`{"na`, `me": "Ad`, `a", "tags": ["x`, `"]}`} {
+		_, _ = decoder.WriteString(delta)
+		snapshot, err = decoder.Snapshot()
+		checks.NoError(t, err, "Snapshot error")
+		names = append(names, snapshot.Name)
+	}
+	if names[0] != "" || names[1] != "Ad" || names[2] != "Ada" || len(snapshot.Tags) != 1 {
+		t.Fatalf("Unexpected snapshots: %q, %+v", names, snapshot)
+	}
+
+	final, err := decoder.Final()
+	checks.NoError(t, err, "Final error")
+	if final.Name != "Ada" || final.Tags[0] != "x" {
+		t.Fatalf("Unexpected final value: %+v", final)
+	}
+
+	var strict PartialJSONDecoder[person]
+	_, _ = strict.WriteString(`{"name": "Ada", "age": 36}`)
+	_, err = strict.Final()
+	checks.HasError(t, err, "Final should reject unknown fields")
+
+	var trailing PartialJSONDecoder[person]
+	_, _ = trailing.WriteString(`{"name": "Ada"} {"name": "Bob"}`)
+	_, err = trailing.Final()
+	checks.ErrorIs(t, err, ErrPartialJSONTrailingData, "Final should reject trailing data")
+}
@@ -0,0 +1,80 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"time"
+)
+
+// PingStatus classifies the outcome of Ping.
+type PingStatus string
+
+const (
+	// PingStatusOK means the API answered and accepted the credentials.
+	PingStatusOK PingStatus = "ok"
+	// PingStatusUnauthorized means the API key is missing, invalid or revoked.
+	PingStatusUnauthorized PingStatus = "unauthorized"
+	// PingStatusForbidden means the key, organization or project may not list models.
+	PingStatusForbidden PingStatus = "forbidden"
+	// PingStatusNotFound means the server answered but has no API at BaseURL, e.g. a wrong path.
+	PingStatusNotFound PingStatus = "not_found"
+	// PingStatusUnreachable means the request failed without a response, e.g. because of DNS,
+	// TLS or proxy configuration.
+	PingStatusUnreachable PingStatus = "unreachable"
+	// PingStatusError means the API answered with another error, e.g. rate limiting or a 5xx.
+	PingStatusError PingStatus = "error"
+)
+
+// PingResult is the outcome of Ping.
+type PingResult struct {
+	Status PingStatus
+	// StatusCode is zero when Status is PingStatusUnreachable.
+	StatusCode int
+	// RequestID and Organization are the x-request-id and openai-organization response headers.
+	RequestID    string
+	Organization string
+	Latency      time.Duration
+}
+
+// Ping validates the API key, BaseURL and proxy configuration with a single models list
+// request, e.g. at service startup before serving traffic. It is neither retried nor reported
+// to CallHooks. The error is nil only when Status is PingStatusOK.
+func (c *Client) Ping(ctx context.Context, opts ...RequestOption) (result PingResult, err error) {
+	ctx = withCallOptions(ctx, opts)
+	req, err := c.requestBuilder.build(ctx, http.MethodGet, c.fullURL("/models"), nil)
+	if err != nil {
+		return
+	}
+	req.Header.Set("Accept", "application/json; charset=utf-8")
+	c.setCommonHeaders(req)
+
+	start := time.Now()
+	res, err := c.do(req)
+	result.Latency = time.Since(start)
+	if err != nil {
+		result.Status = PingStatusUnreachable
+		return
+	}
+	defer res.Body.Close()
+
+	result.StatusCode = res.StatusCode
+	result.RequestID = res.Header.Get("x-request-id")
+	result.Organization = res.Header.Get("openai-organization")
+	switch {
+	case res.StatusCode >= http.StatusOK && res.StatusCode < http.StatusMultipleChoices:
+		result.Status = PingStatusOK
+		_, _ = io.Copy(io.Discard, res.Body)
+		return
+	case res.StatusCode == http.StatusUnauthorized:
+		result.Status = PingStatusUnauthorized
+	case res.StatusCode == http.StatusForbidden:
+		result.Status = PingStatusForbidden
+	case res.StatusCode == http.StatusNotFound:
+		result.Status = PingStatusNotFound
+	default:
+		result.Status = PingStatusError
+	}
+	err = c.handleErrorResp(res)
+	return
+}
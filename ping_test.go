@@ -0,0 +1,54 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPing(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-request-id", "req_1")
+		w.Header().Set("openai-organization", "org-1")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	result, err := NewClientWithConfig(config).Ping(context.Background())
+	checks.NoError(t, err, "Ping error")
+	if result.Status != PingStatusOK || result.StatusCode != http.StatusOK ||
+		result.RequestID != "req_1" || result.Organization != "org-1" {
+		t.Fatalf("Unexpected result: %+v", result)
+	}
+
+	unauthorized := DefaultConfig("invalid")
+	unauthorized.BaseURL = config.BaseURL
+	result, err = NewClientWithConfig(unauthorized).Ping(context.Background())
+	var reqErr *RequestError
+	if result.Status != PingStatusUnauthorized || !errors.As(err, &reqErr) {
+		t.Fatalf("Expected an unauthorized result, got %+v: %v", result, err)
+	}
+
+	config = DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/wrong"
+	if result, err = NewClientWithConfig(config).Ping(context.Background()); result.Status != PingStatusNotFound {
+		t.Fatalf("Expected a not found result, got %+v: %v", result, err)
+	}
+
+	ts.Close()
+	config.BaseURL = ts.URL + "/v1"
+	if result, err = NewClientWithConfig(config).Ping(context.Background()); result.Status != PingStatusUnreachable ||
+		err == nil {
+		t.Fatalf("Expected an unreachable result, got %+v: %v", result, err)
+	}
+}
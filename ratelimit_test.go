@@ -0,0 +1,38 @@
+package openai //nolint:testpackage // testing private field
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestNewRateLimitHeaders(t *testing.T) {
+	h := http.Header{}
+	h.Set("x-ratelimit-limit-tokens", "150000")
+	h.Set("x-ratelimit-remaining-tokens", "149984")
+	h.Set("x-ratelimit-reset-tokens", "6m0s")
+	h.Set("x-ratelimit-reset-requests", "garbage")
+
+	headers := newRateLimitHeaders(h)
+	if headers.LimitTokens != 150000 || headers.RemainingTokens != 149984 {
+		t.Fatalf("Unexpected headers: %+v", headers)
+	}
+	if headers.ResetTokens.Duration() != 6*time.Minute || headers.ResetRequests.Duration() != 0 {
+		t.Fatalf("Unexpected reset times: %+v", headers)
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	h := http.Header{}
+	if parseRetryAfter(h) != 0 {
+		t.Fatal("Missing Retry-After should be zero")
+	}
+	h.Set("Retry-After", "3")
+	if parseRetryAfter(h) != 3*time.Second {
+		t.Fatalf("Unexpected delay: %v", parseRetryAfter(h))
+	}
+	h.Set("Retry-After", time.Now().Add(time.Minute).UTC().Format(http.TimeFormat))
+	if d := parseRetryAfter(h); d <= 58*time.Second || d > time.Minute {
+		t.Fatalf("Unexpected delay: %v", d)
+	}
+}
@@ -0,0 +1,239 @@
+package openai
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RealtimeAudioFormat is the encoding of the audio frames of a realtime session.
+type RealtimeAudioFormat string
+
+const (
+	// RealtimeAudioFormatPCM16 is 16-bit little-endian mono PCM at 24kHz.
+	RealtimeAudioFormatPCM16 RealtimeAudioFormat = "pcm16"
+	RealtimeAudioFormatG711U RealtimeAudioFormat = "g711_ulaw"
+	RealtimeAudioFormatG711A RealtimeAudioFormat = "g711_alaw"
+)
+
+// Events of realtime transcription sessions.
+const (
+	RealtimeEventInputAudioAppend        = "input_audio_buffer.append"
+	RealtimeEventInputAudioCommit        = "input_audio_buffer.commit"
+	RealtimeEventTranscriptionDelta      = "conversation.item.input_audio_transcription.delta"
+	RealtimeEventTranscriptionCompleted  = "conversation.item.input_audio_transcription.completed"
+	RealtimeEventTranscriptionFailed     = "conversation.item.input_audio_transcription.failed"
+	RealtimeEventInputAudioSpeechStarted = "input_audio_buffer.speech_started"
+	RealtimeEventInputAudioSpeechStopped = "input_audio_buffer.speech_stopped"
+	RealtimeEventError                   = "error"
+	// The assistant's responses of conversation sessions carry transcripts of their audio too.
+	RealtimeEventResponseAudioTranscriptDelta = "response.audio_transcript.delta"
+	RealtimeEventResponseAudioTranscriptDone  = "response.audio_transcript.done"
+)
+
+// RealtimeTranscriptSource tells whose speech a realtime transcript event transcribes.
+type RealtimeTranscriptSource string
+
+const (
+	// RealtimeTranscriptSourceInput is the input audio, transcribed as configured by
+	// RealtimeInputAudioTranscription.
+	RealtimeTranscriptSourceInput RealtimeTranscriptSource = "input"
+	// RealtimeTranscriptSourceResponse is the audio of the assistant's response.
+	RealtimeTranscriptSourceResponse RealtimeTranscriptSource = "response"
+)
+
+// RealtimeTranscriptionSessionRequest configures a realtime transcription session.
+type RealtimeTranscriptionSessionRequest struct {
+	InputAudioFormat         RealtimeAudioFormat              `json:"input_audio_format,omitempty"`
+	InputAudioTranscription  *RealtimeInputAudioTranscription `json:"input_audio_transcription,omitempty"`
+	TurnDetection            *RealtimeTurnDetection           `json:"turn_detection,omitempty"`
+	InputAudioNoiseReduction *RealtimeNoiseReduction          `json:"input_audio_noise_reduction,omitempty"`
+	Include                  []string                         `json:"include,omitempty"`
+}
+
+// RealtimeInputAudioTranscription selects the model transcribing the audio of a session.
+type RealtimeInputAudioTranscription struct {
+	Model    string `json:"model"`
+	Language string `json:"language,omitempty"`
+	Prompt   string `json:"prompt,omitempty"`
+}
+
+// RealtimeTurnDetectionType is the voice activity detection (VAD) splitting audio into turns.
+type RealtimeTurnDetectionType string
+
+const (
+	// RealtimeTurnDetectionServerVAD ends turns after a period of silence.
+	RealtimeTurnDetectionServerVAD RealtimeTurnDetectionType = "server_vad"
+	// RealtimeTurnDetectionSemanticVAD ends turns when the speaker is judged to have finished.
+	RealtimeTurnDetectionSemanticVAD RealtimeTurnDetectionType = "semantic_vad"
+)
+
+// RealtimeVADEagerness is how quickly semantic VAD ends a turn.
+type RealtimeVADEagerness string
+
+const (
+	RealtimeVADEagernessLow    RealtimeVADEagerness = "low"
+	RealtimeVADEagernessMedium RealtimeVADEagerness = "medium"
+	RealtimeVADEagernessHigh   RealtimeVADEagerness = "high"
+	RealtimeVADEagernessAuto   RealtimeVADEagerness = "auto"
+)
+
+var ErrRealtimeTurnDetectionInvalid = errors.New("invalid realtime turn detection")
+
+// RealtimeTurnDetection configures how the server splits the audio into turns. Audio is only
+// transcribed on RealtimeEventInputAudioCommit events when it is not set. Threshold,
+// PrefixPaddingMs and SilenceDurationMs configure server VAD, Eagerness semantic VAD.
+type RealtimeTurnDetection struct {
+	Type RealtimeTurnDetectionType `json:"type"`
+	// Threshold is the activation threshold of server VAD, between 0 and 1: higher values need
+	// louder audio to detect speech, e.g. in noisy environments.
+	Threshold float64 `json:"threshold,omitempty"`
+	// PrefixPaddingMs is the audio included before detected speech.
+	PrefixPaddingMs int `json:"prefix_padding_ms,omitempty"`
+	// SilenceDurationMs is the silence ending a turn; shorter values end turns sooner.
+	SilenceDurationMs int                  `json:"silence_duration_ms,omitempty"`
+	Eagerness         RealtimeVADEagerness `json:"eagerness,omitempty"`
+}
+
+// Validate checks the settings are of Type and within their allowed ranges.
+func (d RealtimeTurnDetection) Validate() error {
+	switch d.Type {
+	case RealtimeTurnDetectionServerVAD:
+		if d.Eagerness != "" {
+			return fmt.Errorf("%w: eagerness requires semantic VAD", ErrRealtimeTurnDetectionInvalid)
+		}
+	case RealtimeTurnDetectionSemanticVAD:
+		if d.Threshold != 0 || d.PrefixPaddingMs != 0 || d.SilenceDurationMs != 0 {
+			return fmt.Errorf("%w: threshold, prefix padding and silence duration require server VAD",
+				ErrRealtimeTurnDetectionInvalid)
+		}
+		switch d.Eagerness {
+		case "", RealtimeVADEagernessLow, RealtimeVADEagernessMedium, RealtimeVADEagernessHigh,
+			RealtimeVADEagernessAuto:
+		default:
+			return fmt.Errorf("%w: unknown eagerness %q", ErrRealtimeTurnDetectionInvalid, d.Eagerness)
+		}
+	default:
+		return fmt.Errorf("%w: unknown type %q", ErrRealtimeTurnDetectionInvalid, d.Type)
+	}
+	switch {
+	case d.Threshold < 0 || d.Threshold > 1:
+		return fmt.Errorf("%w: threshold %v is not between 0 and 1", ErrRealtimeTurnDetectionInvalid, d.Threshold)
+	case d.PrefixPaddingMs < 0 || d.SilenceDurationMs < 0:
+		return fmt.Errorf("%w: durations must not be negative", ErrRealtimeTurnDetectionInvalid)
+	}
+	return nil
+}
+
+// RealtimeNoiseReduction filters the audio before it is transcribed.
+type RealtimeNoiseReduction struct {
+	Type string `json:"type"` // "near_field" or "far_field".
+}
+
+// RealtimeTranscriptionSession is a created session; ClientSecret authenticates the WebSocket
+// connection to RealtimeTranscriptionURL until it expires.
+type RealtimeTranscriptionSession struct {
+	ID           string `json:"id"`
+	Object       string `json:"object"`
+	ClientSecret struct {
+		Value     string `json:"value"`
+		ExpiresAt int64  `json:"expires_at"`
+	} `json:"client_secret"`
+	RealtimeTranscriptionSessionRequest
+
+	ResponseMeta `json:"-"`
+}
+
+// RealtimeClientEvent is an event sent to a realtime session.
+type RealtimeClientEvent struct {
+	Type    string `json:"type"`
+	EventID string `json:"event_id,omitempty"`
+	// Audio is the base64 encoded frame of RealtimeEventInputAudioAppend events.
+	Audio string `json:"audio,omitempty"`
+}
+
+// RealtimeServerEvent is an event received from a realtime transcription session.
+type RealtimeServerEvent struct {
+	Type       string    `json:"type"`
+	EventID    string    `json:"event_id"`
+	ItemID     string    `json:"item_id,omitempty"`
+	Delta      string    `json:"delta,omitempty"`
+	Transcript string    `json:"transcript,omitempty"`
+	Error      *APIError `json:"error,omitempty"`
+}
+
+// CreateRealtimeTranscriptionSession — API call to create a realtime transcription session.
+//
+// The client creates sessions and encodes and decodes their events, but does not stream audio
+// itself, since it has no WebSocket implementation: connect to RealtimeTranscriptionURL with
+// the WebSocket library of the caller's choice, authenticated with the session ClientSecret
+// and with BetaRealtime as the OpenAI-Beta header, send frames as RealtimeAudioAppendEvent
+// messages, and decode the received messages as RealtimeServerEvent.
+func (c *Client) CreateRealtimeTranscriptionSession(
+	ctx context.Context,
+	request RealtimeTranscriptionSessionRequest,
+	opts ...RequestOption,
+) (response RealtimeTranscriptionSession, err error) {
+	ctx = withCallOptions(ctx, opts)
+	if request.TurnDetection != nil {
+		if err = request.TurnDetection.Validate(); err != nil {
+			return
+		}
+	}
+
+	urlSuffix := "/realtime/transcription_sessions"
+	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL(urlSuffix), request)
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &response)
+	return
+}
+
+// RealtimeTranscriptionURL returns the WebSocket URL of realtime transcription sessions. Azure
+// deployments are selected with the deployment query parameter next to api-version.
+func (c *Client) RealtimeTranscriptionURL() string {
+	var rawURL string
+	query := url.Values{}
+	if c.config.APIType == APITypeAzure || c.config.APIType == APITypeAzureAD {
+		rawURL = fmt.Sprintf("%s/%s/realtime", strings.TrimRight(c.config.BaseURL, "/"), azureAPIPrefix)
+		query.Set("api-version", c.config.APIVersion)
+		query.Set("deployment", c.config.Engine)
+	} else {
+		rawURL = c.fullURL("/realtime")
+	}
+	query.Set("intent", "transcription")
+	rawURL += "?" + query.Encode()
+
+	if strings.HasPrefix(rawURL, "https://") {
+		return "wss://" + strings.TrimPrefix(rawURL, "https://")
+	}
+	return "ws://" + strings.TrimPrefix(rawURL, "http://")
+}
+
+// TranscriptSource returns whose speech e transcribes, and false for events that are not
+// transcript deltas or completed transcripts.
+func (e RealtimeServerEvent) TranscriptSource() (RealtimeTranscriptSource, bool) {
+	switch e.Type {
+	case RealtimeEventTranscriptionDelta, RealtimeEventTranscriptionCompleted:
+		return RealtimeTranscriptSourceInput, true
+	case RealtimeEventResponseAudioTranscriptDelta, RealtimeEventResponseAudioTranscriptDone:
+		return RealtimeTranscriptSourceResponse, true
+	}
+	return "", false
+}
+
+// RealtimeAudioAppendEvent returns the message sending an audio frame, encoded in the
+// InputAudioFormat of the session, to a realtime session.
+func RealtimeAudioAppendEvent(frame []byte) ([]byte, error) {
+	return json.Marshal(RealtimeClientEvent{
+		Type:  RealtimeEventInputAudioAppend,
+		Audio: base64.StdEncoding.EncodeToString(frame),
+	})
+}
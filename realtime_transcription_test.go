@@ -0,0 +1,136 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestCreateRealtimeTranscriptionSession(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/realtime/transcription_sessions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("OpenAI-Beta") != string(BetaRealtime) {
+			http.Error(w, "missing OpenAI-Beta header", http.StatusBadRequest)
+			return
+		}
+		var req RealtimeTranscriptionSessionRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.InputAudioFormat != RealtimeAudioFormatG711U ||
+			req.InputAudioTranscription == nil || req.InputAudioTranscription.Model != GPT4oTranscribe {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"sess_1","object":"realtime.transcription_session",
+			"client_secret":{"value":"ek_1","expires_at":1700000000},"input_audio_format":"g711_ulaw"}`))
+	})
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("OpenAI-Beta") != "" {
+			http.Error(w, "unexpected OpenAI-Beta header", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err, "Stable endpoints should not send the OpenAI-Beta header")
+
+	session, err := client.CreateRealtimeTranscriptionSession(context.Background(), RealtimeTranscriptionSessionRequest{
+		InputAudioFormat:        RealtimeAudioFormatG711U,
+		InputAudioTranscription: &RealtimeInputAudioTranscription{Model: GPT4oTranscribe},
+		TurnDetection:           &RealtimeTurnDetection{Type: RealtimeTurnDetectionServerVAD, Threshold: 0.6},
+	})
+	checks.NoError(t, err, "CreateRealtimeTranscriptionSession error")
+	if session.ID != "sess_1" || session.ClientSecret.Value != "ek_1" ||
+		session.InputAudioFormat != RealtimeAudioFormatG711U {
+		t.Fatalf("Unexpected session: %+v", session)
+	}
+
+	if url := client.RealtimeTranscriptionURL(); !strings.HasPrefix(url, "ws://") ||
+		!strings.HasSuffix(url, "/v1/realtime?intent=transcription") {
+		t.Errorf("Unexpected WebSocket URL %q", url)
+	}
+
+	azureConfig := DefaultAzureConfig(test.GetTestToken(), "https://example.openai.azure.com/", "transcriber")
+	azureConfig.APIVersion = "2025-04-01-preview"
+	azure := NewClientWithConfig(azureConfig)
+	want := "wss://example.openai.azure.com/openai/realtime?" +
+		"api-version=2025-04-01-preview&deployment=transcriber&intent=transcription"
+	if url := azure.RealtimeTranscriptionURL(); url != want {
+		t.Errorf("Unexpected Azure WebSocket URL %q, expected %q", url, want)
+	}
+}
+
+func TestRealtimeAudioAppendEvent(t *testing.T) {
+	message, err := RealtimeAudioAppendEvent([]byte{0, 1, 2})
+	checks.NoError(t, err, "RealtimeAudioAppendEvent error")
+	if string(message) != `{"type":"input_audio_buffer.append","audio":"AAEC"}` {
+		t.Fatalf("Unexpected message %s", message)
+	}
+
+	var event RealtimeServerEvent
+	err = json.Unmarshal([]byte(`{"type":"conversation.item.input_audio_transcription.delta",
+		"event_id":"ev_1","item_id":"item_1","delta":"Hel"}`), &event)
+	checks.NoError(t, err, "Unmarshal error")
+	if event.Type != RealtimeEventTranscriptionDelta || event.Delta != "Hel" {
+		t.Fatalf("Unexpected event: %+v", event)
+	}
+}
+
+func TestRealtimeTranscriptSource(t *testing.T) {
+	testcases := []struct {
+		event  string
+		source RealtimeTranscriptSource
+		ok     bool
+	}{
+		{RealtimeEventTranscriptionDelta, RealtimeTranscriptSourceInput, true},
+		{RealtimeEventTranscriptionCompleted, RealtimeTranscriptSourceInput, true},
+		{RealtimeEventResponseAudioTranscriptDelta, RealtimeTranscriptSourceResponse, true},
+		{RealtimeEventResponseAudioTranscriptDone, RealtimeTranscriptSourceResponse, true},
+		{RealtimeEventInputAudioSpeechStarted, "", false},
+	}
+	for _, tc := range testcases {
+		source, ok := RealtimeServerEvent{Type: tc.event}.TranscriptSource()
+		if source != tc.source || ok != tc.ok {
+			t.Errorf("%s: expected %q, %v, got %q, %v", tc.event, tc.source, tc.ok, source, ok)
+		}
+	}
+}
+
+func TestRealtimeTurnDetectionValidate(t *testing.T) {
+	valid := []RealtimeTurnDetection{
+		{Type: RealtimeTurnDetectionServerVAD, Threshold: 0.5, PrefixPaddingMs: 300, SilenceDurationMs: 500},
+		{Type: RealtimeTurnDetectionSemanticVAD, Eagerness: RealtimeVADEagernessHigh},
+		{Type: RealtimeTurnDetectionSemanticVAD},
+	}
+	for _, detection := range valid {
+		checks.NoError(t, detection.Validate(), "Validate error")
+	}
+
+	invalid := []RealtimeTurnDetection{
+		{Type: "client_vad"},
+		{Type: RealtimeTurnDetectionServerVAD, Threshold: 1.5},
+		{Type: RealtimeTurnDetectionServerVAD, SilenceDurationMs: -1},
+		{Type: RealtimeTurnDetectionServerVAD, Eagerness: RealtimeVADEagernessLow},
+		{Type: RealtimeTurnDetectionSemanticVAD, Threshold: 0.5},
+		{Type: RealtimeTurnDetectionSemanticVAD, Eagerness: "eager"},
+	}
+	for _, detection := range invalid {
+		checks.ErrorIs(t, detection.Validate(), ErrRealtimeTurnDetectionInvalid, "Validate should fail")
+	}
+
+	_, err := NewClient(test.GetTestToken()).CreateRealtimeTranscriptionSession(context.Background(),
+		RealtimeTranscriptionSessionRequest{TurnDetection: &invalid[0]})
+	checks.ErrorIs(t, err, ErrRealtimeTurnDetectionInvalid, "sessions should be validated")
+}
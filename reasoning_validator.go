@@ -0,0 +1,154 @@
+package openai
+
+import (
+	"errors"
+	"strings"
+)
+
+var (
+	ErrReasoningModelSamplingParams = errors.New("this model does not support changing temperature, top_p, presence_penalty or frequency_penalty") //nolint:lll
+	ErrReasoningEffortInvalid       = errors.New("reasoning effort is not supported by this model")                                                //nolint:lll
+	ErrVerbosityInvalid             = errors.New("verbosity is not supported by this model")                                                       //nolint:lll
+)
+
+// ReasoningEffort constrains how much reasoning a reasoning model performs before answering.
+type ReasoningEffort string
+
+const (
+	// ReasoningEffortMinimal is only supported by the gpt-5 family.
+	ReasoningEffortMinimal ReasoningEffort = "minimal"
+	ReasoningEffortLow     ReasoningEffort = "low"
+	ReasoningEffortMedium  ReasoningEffort = "medium"
+	ReasoningEffortHigh    ReasoningEffort = "high"
+)
+
+// Verbosity constrains the length of the answer of gpt-5 family models.
+type Verbosity string
+
+const (
+	VerbosityLow    Verbosity = "low"
+	VerbosityMedium Verbosity = "medium"
+	VerbosityHigh   Verbosity = "high"
+)
+
+// SamplingParamsPolicy controls what the client does when a request sets sampling
+// parameters that the target model rejects, e.g. temperature on reasoning models. Reasoning
+// efforts and verbosities the model rejects fail the call under every policy but
+// SamplingParamsPassThrough.
+type SamplingParamsPolicy int
+
+const (
+	// SamplingParamsError fails the call locally with ErrReasoningModelSamplingParams.
+	SamplingParamsError SamplingParamsPolicy = iota
+	// SamplingParamsStrip resets unsupported parameters to their defaults before sending.
+	SamplingParamsStrip
+	// SamplingParamsPassThrough sends the request unchanged and lets the API decide.
+	SamplingParamsPassThrough
+)
+
+// reasoningModelPrefixes lists the model families that only accept default sampling parameters.
+var reasoningModelPrefixes = []string{"o1", "o3", "o4", "gpt-5"}
+
+// isReasoningModel reports whether model belongs to a reasoning model family.
+// Dated snapshots (e.g. o3-mini-2025-01-31) are matched by their family prefix.
+func isReasoningModel(model string) bool {
+	if strings.HasPrefix(model, "gpt-5-chat") {
+		return false
+	}
+	for _, prefix := range reasoningModelPrefixes {
+		if model == prefix || strings.HasPrefix(model, prefix+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+func isGPT5Model(model string) bool {
+	return isReasoningModel(model) && strings.HasPrefix(model, "gpt-5")
+}
+
+// reasoningFamily is the family of a model as far as reasoning options are concerned.
+type reasoningFamily int
+
+const (
+	// reasoningFamilyUnknown covers models the client does not know, such as newer models,
+	// Azure deployments and models of compatible servers.
+	reasoningFamilyUnknown reasoningFamily = iota
+	// reasoningFamilyNone covers known models without reasoning, e.g. gpt-4o and gpt-5-chat.
+	reasoningFamilyNone
+	reasoningFamilyO
+	reasoningFamilyGPT5
+)
+
+// reasoningFamilyOf returns the family of model. Fine-tuned models (ft:o4-mini:org::id) belong
+// to the family of their base model.
+func reasoningFamilyOf(model string) reasoningFamily {
+	if strings.HasPrefix(model, fineTunedChatModelPrefix) {
+		model, _, _ = strings.Cut(strings.TrimPrefix(model, fineTunedChatModelPrefix), ":")
+	}
+	switch {
+	case isGPT5Model(model):
+		return reasoningFamilyGPT5
+	case isReasoningModel(model):
+		return reasoningFamilyO
+	case strings.HasPrefix(model, "gpt-3.5") || strings.HasPrefix(model, "gpt-4") ||
+		strings.HasPrefix(model, "gpt-5-chat"):
+		return reasoningFamilyNone
+	default:
+		return reasoningFamilyUnknown
+	}
+}
+
+// validateReasoningOptions rejects reasoning efforts and verbosities that the family of the
+// requested model is known not to support. Unknown models and values are left to the API, as
+// is everything under SamplingParamsPassThrough.
+func validateReasoningOptions(request ChatCompletionRequest, policy SamplingParamsPolicy) error {
+	if policy == SamplingParamsPassThrough {
+		return nil
+	}
+	family := reasoningFamilyOf(request.Model)
+
+	switch request.ReasoningEffort {
+	case ReasoningEffortLow, ReasoningEffortMedium, ReasoningEffortHigh:
+		if family == reasoningFamilyNone {
+			return ErrReasoningEffortInvalid
+		}
+	case ReasoningEffortMinimal:
+		if family == reasoningFamilyNone || family == reasoningFamilyO {
+			return ErrReasoningEffortInvalid
+		}
+	}
+
+	switch request.Verbosity {
+	case VerbosityLow, VerbosityMedium, VerbosityHigh:
+		if family == reasoningFamilyNone || family == reasoningFamilyO {
+			return ErrVerbosityInvalid
+		}
+	}
+	return nil
+}
+
+// validateSamplingParams applies policy to the sampling parameters of request.
+func validateSamplingParams(request *ChatCompletionRequest, policy SamplingParamsPolicy) error {
+	if policy == SamplingParamsPassThrough || !isReasoningModel(request.Model) {
+		return nil
+	}
+
+	unsupported := (request.Temperature != 0 && request.Temperature != 1) ||
+		(request.TopP != 0 && request.TopP != 1) ||
+		request.PresencePenalty != 0 ||
+		request.FrequencyPenalty != 0
+	if !unsupported {
+		return nil
+	}
+
+	if policy == SamplingParamsError {
+		return ErrReasoningModelSamplingParams
+	}
+
+	request.Temperature = 0
+	request.TopP = 0
+	request.PresencePenalty = 0
+	request.FrequencyPenalty = 0
+	return nil
+}
@@ -0,0 +1,129 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestReasoningModelSamplingParams(t *testing.T) {
+	var received ChatCompletionRequest
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if received, err = getChatCompletionBody(r); err != nil {
+			http.Error(w, "could not read request", http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintln(w, `{"id":"chatcmpl-1","object":"chat.completion","choices":[]}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	req := ChatCompletionRequest{
+		Model:       O3Mini,
+		Temperature: 0.2,
+		TopP:        0.9,
+		Messages:    []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hello!"}},
+	}
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	_, err := client.CreateChatCompletion(ctx, req)
+	checks.ErrorIs(t, err, ErrReasoningModelSamplingParams, "default policy should reject sampling params")
+
+	_, err = client.CreateChatCompletionStream(ctx, req)
+	checks.ErrorIs(t, err, ErrReasoningModelSamplingParams, "default policy should reject sampling params")
+
+	config.SamplingParamsPolicy = SamplingParamsStrip
+	client = NewClientWithConfig(config)
+	_, err = client.CreateChatCompletion(ctx, req)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if received.Temperature != 0 || received.TopP != 0 {
+		t.Fatalf("Sampling params should be stripped, got temperature %v and top_p %v",
+			received.Temperature, received.TopP)
+	}
+
+	config.SamplingParamsPolicy = SamplingParamsPassThrough
+	client = NewClientWithConfig(config)
+	_, err = client.CreateChatCompletion(ctx, req)
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if received.Temperature != req.Temperature {
+		t.Fatalf("Sampling params should be passed through, got temperature %v", received.Temperature)
+	}
+}
+
+func TestNonReasoningModelSamplingParams(t *testing.T) {
+	config := DefaultConfig("whatever")
+	config.BaseURL = "http://localhost/v1"
+	client := NewClientWithConfig(config)
+
+	for _, model := range []string{GPT4, "gpt-5-chat-latest", "o1x"} {
+		_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{
+			Model:       model,
+			Temperature: 0.2,
+		})
+		if errors.Is(err, ErrReasoningModelSamplingParams) {
+			t.Fatalf("Model %s should accept sampling params", model)
+		}
+	}
+}
+
+func TestReasoningOptionsValidation(t *testing.T) {
+	config := DefaultConfig("whatever")
+	config.BaseURL = "http://localhost/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	cases := []struct {
+		model     string
+		effort    ReasoningEffort
+		verbosity Verbosity
+		expected  error
+	}{
+		{GPT4, ReasoningEffortLow, "", ErrReasoningEffortInvalid},
+		{"ft:gpt-4o-mini:org::abc", ReasoningEffortLow, "", ErrReasoningEffortInvalid},
+		{O3Mini, ReasoningEffortMinimal, "", ErrReasoningEffortInvalid},
+		{O3Mini, ReasoningEffortHigh, VerbosityLow, ErrVerbosityInvalid},
+	}
+	for _, c := range cases {
+		_, err := client.CreateChatCompletion(ctx, ChatCompletionRequest{
+			Model:           c.model,
+			ReasoningEffort: c.effort,
+			Verbosity:       c.verbosity,
+		})
+		checks.ErrorIs(t, err, c.expected, "unexpected error for", c.model)
+	}
+
+	for _, req := range []ChatCompletionRequest{
+		{Model: GPT5Mini, ReasoningEffort: ReasoningEffortMinimal, Verbosity: VerbosityHigh},
+		{Model: O4Mini, ReasoningEffort: ReasoningEffortMedium},
+		{Model: O3Mini, ReasoningEffort: "extreme"},
+		{Model: GPT5, ReasoningEffort: ReasoningEffortMinimal, Verbosity: "loud"},
+		{Model: "gpt-5.1", ReasoningEffort: ReasoningEffortMinimal, Verbosity: VerbosityLow},
+		{Model: "ft:o4-mini:org::abc", ReasoningEffort: ReasoningEffortHigh},
+		{Model: "my-deployment", ReasoningEffort: ReasoningEffortLow, Verbosity: VerbosityLow},
+	} {
+		_, err := client.CreateChatCompletion(ctx, req)
+		if errors.Is(err, ErrReasoningEffortInvalid) || errors.Is(err, ErrVerbosityInvalid) {
+			t.Fatalf("Request should pass validation: %+v", req)
+		}
+	}
+
+	config.SamplingParamsPolicy = SamplingParamsPassThrough
+	client = NewClientWithConfig(config)
+	_, err := client.CreateChatCompletion(ctx, ChatCompletionRequest{Model: GPT4, ReasoningEffort: ReasoningEffortLow})
+	if errors.Is(err, ErrReasoningEffortInvalid) {
+		t.Fatal("SamplingParamsPassThrough should leave reasoning options to the API")
+	}
+}
@@ -5,6 +5,7 @@ import (
 
 	"context"
 	"errors"
+	"io"
 	"net/http"
 	"testing"
 )
@@ -116,6 +117,11 @@ func TestClientReturnsRequestBuilderErrors(t *testing.T) {
 		t.Fatalf("Did not return error when request builder failed: %v", err)
 	}
 
+	_, err = client.CreateSpeech(ctx, SpeechRequest{Input: "Hello!"})
+	if !errors.Is(err, errTestRequestBuilderFailed) {
+		t.Fatalf("Did not return error when request builder failed: %v", err)
+	}
+
 	err = client.DeleteFile(ctx, "")
 	if !errors.Is(err, errTestRequestBuilderFailed) {
 		t.Fatalf("Did not return error when request builder failed: %v", err)
@@ -131,6 +137,11 @@ func TestClientReturnsRequestBuilderErrors(t *testing.T) {
 		t.Fatalf("Did not return error when request builder failed: %v", err)
 	}
 
+	_, err = client.GetFileContent(ctx, "")
+	if !errors.Is(err, errTestRequestBuilderFailed) {
+		t.Fatalf("Did not return error when request builder failed: %v", err)
+	}
+
 	_, err = client.ListEngines(ctx)
 	if !errors.Is(err, errTestRequestBuilderFailed) {
 		t.Fatalf("Did not return error when request builder failed: %v", err)
@@ -175,3 +186,23 @@ func TestReturnsRequestBuilderErrorsAddtion(t *testing.T) {
 		t.Fatalf("Did not return error when request builder failed: %v", err)
 	}
 }
+
+func TestRequestBodyCanBeRebuilt(t *testing.T) {
+	builder := newRequestBuilder()
+	req, err := builder.build(context.Background(), http.MethodPost, "http://localhost", map[string]int{"n": 1})
+	if err != nil {
+		t.Fatalf("Failed to build request: %v", err)
+	}
+	first, _ := io.ReadAll(req.Body)
+
+	for i := 0; i < 2; i++ {
+		body, err := req.GetBody()
+		if err != nil {
+			t.Fatalf("GetBody error: %v", err)
+		}
+		again, _ := io.ReadAll(body)
+		if string(again) != string(first) || string(first) != `{"n":1}` {
+			t.Fatalf("Rebuilt body %q differs from %q", again, first)
+		}
+	}
+}
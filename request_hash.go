@@ -0,0 +1,51 @@
+package openai
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+)
+
+// volatileRequestFields are top-level request fields that do not change the result of a call.
+var volatileRequestFields = []string{"user", "safety_identifier"}
+
+// CanonicalRequestJSON returns the JSON encoding of request with object keys sorted, insignificant
+// whitespace removed and the volatile top-level fields ("user" and "safety_identifier") plus any
+// exclude fields dropped.
+// Requests that only differ in field order or in the excluded fields encode identically.
+func CanonicalRequestJSON(request any, exclude ...string) ([]byte, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value any
+	if err = decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	if fields, ok := value.(map[string]any); ok {
+		for _, name := range volatileRequestFields {
+			delete(fields, name)
+		}
+		for _, name := range exclude {
+			delete(fields, name)
+		}
+	}
+	// Maps are encoded with sorted keys.
+	return json.Marshal(value)
+}
+
+// RequestHash returns the hex encoded SHA-256 of CanonicalRequestJSON(request, exclude...).
+// It is stable across processes and releases as long as the request encodes the same, so it can
+// key caches, deduplicate requests or derive idempotency keys.
+func RequestHash(request any, exclude ...string) (string, error) {
+	data, err := CanonicalRequestJSON(request, exclude...)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,46 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"testing"
+)
+
+func TestCanonicalRequestJSON(t *testing.T) {
+	data, err := CanonicalRequestJSON(map[string]any{
+		"b":    map[string]any{"y": 1, "x": 0.1},
+		"a":    []int{3, 1},
+		"user": "alice",
+		"seed": 12345678901234567,
+	}, "seed")
+	checks.NoError(t, err, "CanonicalRequestJSON error")
+	if string(data) != `{"a":[3,1],"b":{"x":0.1,"y":1}}` {
+		t.Fatalf("Unexpected canonical JSON: %s", data)
+	}
+}
+
+func TestRequestHash(t *testing.T) {
+	request := ChatCompletionRequest{
+		Model:    GPT3Dot5Turbo,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hello!"}},
+	}
+	hash, err := RequestHash(request)
+	checks.NoError(t, err, "RequestHash error")
+
+	sameRequest := request
+	sameRequest.User = "someone"
+	sameHash, err := RequestHash(sameRequest)
+	checks.NoError(t, err, "RequestHash error")
+	if sameHash != hash || len(hash) != 64 {
+		t.Fatalf("Requests only differing in the user should hash the same: %s, %s", hash, sameHash)
+	}
+
+	otherRequest := request
+	otherRequest.Temperature = 0.5
+	otherHash, err := RequestHash(otherRequest)
+	checks.NoError(t, err, "RequestHash error")
+	if otherHash == hash {
+		t.Fatal("Requests with different parameters should hash differently")
+	}
+}
@@ -0,0 +1,112 @@
+package openai
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// RequestOption customizes a call. Every call accepts options as trailing arguments, and
+// WithRequestOptions attaches them to a context instead.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	header   http.Header
+	timeout  time.Duration
+	retry    *RetryConfig
+	retrySet bool
+	tag      string
+	// onRetry is called with every failed attempt that is retried.
+	onRetry func(attempt RetryAttempt)
+}
+
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a context applying opts to every call made with it, on top of
+// the options already attached to ctx. Like WithRequestTag and WithRequestMetadata, options
+// travel in the context, so the options passed to a helper such as TranscribeAll also apply
+// to the calls it makes. Options passed to a call are applied on top of those of its context.
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	options := requestOptionsFromContext(ctx)
+	options.header = options.header.Clone()
+	for _, opt := range opts {
+		opt(&options)
+	}
+	if options.tag != "" {
+		ctx = WithRequestTag(ctx, options.tag)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, options)
+}
+
+// withCallOptions applies the options passed to a call on top of those attached to ctx.
+func withCallOptions(ctx context.Context, opts []RequestOption) context.Context {
+	if len(opts) == 0 {
+		return ctx
+	}
+	return WithRequestOptions(ctx, opts...)
+}
+
+func requestOptionsFromContext(ctx context.Context) requestOptions {
+	options, _ := ctx.Value(requestOptionsKey{}).(requestOptions)
+	return options
+}
+
+// WithHeader sets an HTTP header on the requests, overriding the headers set by the client.
+func WithHeader(key, value string) RequestOption {
+	return func(o *requestOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// WithIdempotencyKey sets the Idempotency-Key header, so retried requests are not processed twice.
+func WithIdempotencyKey(key string) RequestOption {
+	return WithHeader("Idempotency-Key", key)
+}
+
+// WithTimeout bounds each call, including its retries; for streams it bounds the whole stream.
+func WithTimeout(timeout time.Duration) RequestOption {
+	return func(o *requestOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithTag tags the calls, see WithRequestTag.
+func WithTag(tag string) RequestOption {
+	return func(o *requestOptions) {
+		o.tag = tag
+	}
+}
+
+// WithRetry overrides ClientConfig.Retry; nil disables retries.
+func WithRetry(retry *RetryConfig) RequestOption {
+	return func(o *requestOptions) {
+		o.retry, o.retrySet = retry, true
+	}
+}
+
+// withRetryHook calls onRetry with every failed attempt that is retried, for helpers that
+// coordinate several calls.
+func withRetryHook(onRetry func(attempt RetryAttempt)) RequestOption {
+	return func(o *requestOptions) {
+		o.onRetry = onRetry
+	}
+}
+
+// callContext returns the context of a call made with ctx, bounded by its WithTimeout option.
+func callContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout := requestOptionsFromContext(ctx).timeout; timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// retryConfig returns the retry configuration of the calls made with ctx.
+func (c *Client) retryConfig(ctx context.Context) *RetryConfig {
+	if options := requestOptionsFromContext(ctx); options.retrySet {
+		return options.retry
+	}
+	return c.config.Retry
+}
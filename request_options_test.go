@@ -0,0 +1,59 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRequestOptions(t *testing.T) {
+	var calls int
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("Idempotency-Key") != "key-1" || r.Header.Get("X-Feature") != "search" {
+			http.Error(w, "missing headers", http.StatusBadRequest)
+			return
+		}
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	server.RegisterHandler("/v1/embeddings", func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var tags []string
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.CallHooks = &CallHooks{OnRequestStart: func(info CallInfo) { tags = append(tags, info.Tag) }}
+	client := NewClientWithConfig(config)
+
+	ctx := WithRequestOptions(context.Background(), WithHeader("X-Feature", "search"), WithTag("search"))
+	_, err := client.ListModels(ctx,
+		WithIdempotencyKey("key-1"),
+		WithRetry(&RetryConfig{MaxAttempts: 2, InitialDelay: time.Millisecond}),
+	)
+	checks.NoError(t, err, "ListModels error")
+	if calls != 2 || len(tags) != 1 || tags[0] != "search" {
+		t.Fatalf("Expected a tagged call retried once, got %d attempts and tags %v", calls, tags)
+	}
+
+	_, err = client.CreateEmbeddings(context.Background(),
+		EmbeddingRequest{Input: []string{"Hi"}, Model: AdaEmbeddingV2}, WithTimeout(10*time.Millisecond))
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the call to time out, got %v", err)
+	}
+}
@@ -0,0 +1,34 @@
+package openai
+
+import (
+	"context"
+)
+
+// OtherRequestTag replaces the tags missing from ClientConfig.RequestTags.
+const OtherRequestTag = "other"
+
+type requestTagKey struct{}
+
+// WithRequestTag returns a context tagging the calls made with it with the application
+// feature they serve, e.g. "search" or "summarize". Unlike request metadata, the tag is meant
+// as a low-cardinality label: it is reported in CallInfo, LatencyBudgetEvent, ArchiveRecord
+// and UsageEntry, so usage can be broken down by feature.
+func WithRequestTag(ctx context.Context, tag string) context.Context {
+	return context.WithValue(ctx, requestTagKey{}, tag)
+}
+
+// RequestTagFromContext returns the tag attached to ctx, as given to WithRequestTag.
+func RequestTagFromContext(ctx context.Context) string {
+	tag, _ := ctx.Value(requestTagKey{}).(string)
+	return tag
+}
+
+// requestTag returns the tag of the calls made with ctx, OtherRequestTag when it is not
+// one of ClientConfig.RequestTags.
+func (c *Client) requestTag(ctx context.Context) string {
+	tag := RequestTagFromContext(ctx)
+	if tag == "" || c.requestTags == nil || c.requestTags[tag] {
+		return tag
+	}
+	return OtherRequestTag
+}
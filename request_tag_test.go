@@ -0,0 +1,69 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRequestTags(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"model":"gpt-4","choices":[],` +
+			`"usage":{"prompt_tokens":1,"completion_tokens":2,"total_tokens":3}}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var snapshots []UsageSnapshot
+	reporter := NewUsageReporter(UsageReporterConfig{
+		Interval:   time.Hour,
+		OnSnapshot: func(snapshot UsageSnapshot) { snapshots = append(snapshots, snapshot) },
+	})
+	var tags []string
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.RequestTags = []string{"search", "summarize"}
+	config.CallHooks = &CallHooks{
+		OnRequestStart: func(info CallInfo) { tags = append(tags, info.Tag) },
+		OnComplete:     reporter.OnComplete,
+	}
+	client := NewClientWithConfig(config)
+
+	request := ChatCompletionRequest{Model: GPT4, Messages: []ChatCompletionMessage{{Role: "user", Content: "Hi"}}}
+	for _, tag := range []string{"search", "summarize", "search", "user-1234", ""} {
+		ctx := context.Background()
+		if tag != "" {
+			ctx = WithRequestTag(ctx, tag)
+		}
+		_, err := client.CreateChatCompletion(ctx, request)
+		checks.NoError(t, err, "CreateChatCompletion error")
+	}
+
+	expectedTags := []string{"search", "summarize", "search", OtherRequestTag, ""}
+	if len(tags) != len(expectedTags) {
+		t.Fatalf("Expected tags %v, got %v", expectedTags, tags)
+	}
+	for i := range expectedTags {
+		if tags[i] != expectedTags[i] {
+			t.Errorf("Call %d: expected tag %q, got %q", i, expectedTags[i], tags[i])
+		}
+	}
+
+	reporter.Flush()
+	if len(snapshots) != 1 || len(snapshots[0].Entries) != 4 {
+		t.Fatalf("Expected one snapshot of 4 entries, got %+v", snapshots)
+	}
+	entries := snapshots[0].Entries
+	if entries[0].Tag != "" || entries[1].Tag != OtherRequestTag ||
+		entries[2].Tag != "search" || entries[2].Requests != 2 || entries[2].Usage.TotalTokens != 6 ||
+		entries[3].Tag != "summarize" {
+		t.Errorf("Unexpected entries: %+v", entries)
+	}
+}
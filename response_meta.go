@@ -0,0 +1,50 @@
+package openai
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// ResponseMeta is embedded in typed responses and holds selected headers of the HTTP response
+// they were decoded from. Comparing ProcessingTime with the duration of a call tells the time
+// spent in the network and in queues apart from the time the API spent processing the request.
+// It is zero for responses that were not decoded from a JSON response body.
+type ResponseMeta struct {
+	// header is a pointer so embedding types stay comparable.
+	header *http.Header
+
+	// RequestID is the x-request-id header, the identifier to quote to OpenAI support.
+	RequestID string
+	// ProcessingTime is the openai-processing-ms header.
+	ProcessingTime time.Duration
+	// Version is the openai-version header, the API version that served the request.
+	Version string
+	// Organization is the openai-organization header.
+	Organization string
+	RateLimit    RateLimitHeaders
+}
+
+func (m *ResponseMeta) setResponseMeta(h http.Header) {
+	ms, _ := strconv.ParseFloat(h.Get("openai-processing-ms"), 64)
+	*m = ResponseMeta{
+		header:         &h,
+		RequestID:      h.Get("x-request-id"),
+		ProcessingTime: time.Duration(ms * float64(time.Millisecond)),
+		Version:        h.Get("openai-version"),
+		Organization:   h.Get("openai-organization"),
+		RateLimit:      newRateLimitHeaders(h),
+	}
+}
+
+// Header returns the headers of the HTTP response, for those ResponseMeta has no field for.
+func (m *ResponseMeta) Header() http.Header {
+	if m.header == nil {
+		return nil
+	}
+	return *m.header
+}
+
+type responseMetaSetter interface {
+	setResponseMeta(h http.Header)
+}
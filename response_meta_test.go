@@ -0,0 +1,98 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestResponseMeta(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-request-id", "req_1")
+		w.Header().Set("openai-processing-ms", "250")
+		w.Header().Set("openai-version", "2020-10-01")
+		w.Header().Set("openai-organization", "org-1")
+		w.Header().Set("x-ratelimit-remaining-requests", "99")
+		w.Header().Set("x-ratelimit-reset-tokens", "6m0s")
+		_, _ = w.Write([]byte(`{"id":"chatcmpl-1","model":"gpt-4","choices":[]}`))
+	})
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-request-id", "req_2")
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	res, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{
+		Model:    GPT4,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hi"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletion error")
+	if res.RequestID != "req_1" || res.ProcessingTime != 250*time.Millisecond || res.Version != "2020-10-01" ||
+		res.Organization != "org-1" || res.RateLimit.RemainingRequests != 99 ||
+		res.RateLimit.ResetTokens.Duration() != 6*time.Minute {
+		t.Fatalf("Unexpected response meta: %+v", res.ResponseMeta)
+	}
+
+	models, err := client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if models.RequestID != "req_2" {
+		t.Fatalf("Unexpected response meta: %+v", models.ResponseMeta)
+	}
+}
+
+func TestResponseMetaHeader(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/transcriptions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-request-id", "req_audio")
+		w.Header().Set("x-custom", "value")
+		_, _ = w.Write([]byte(`{"text":"hello"}`))
+	})
+	server.RegisterHandler("/v1/files/file-1", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-request-id", "req_file")
+		_, _ = w.Write([]byte(`{"id":"file-1","object":"file"}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	data := []byte("ID3")
+	name := "speech.mp3"
+	res, err := client.CreateTranscription(context.Background(), AudioRequest{
+		Model:     Whisper1,
+		FileBytes: &data,
+		FileName:  &name,
+		Format:    AudioResponseFormatJSON,
+	})
+	checks.NoError(t, err, "CreateTranscription error")
+	if res.RequestID != "req_audio" || res.Header().Get("x-custom") != "value" {
+		t.Fatalf("Unexpected response meta: %+v", res.ResponseMeta)
+	}
+
+	file, err := client.GetFile(context.Background(), "file-1")
+	checks.NoError(t, err, "GetFile error")
+	if file.RequestID != "req_file" || file.Header().Get("x-request-id") != "req_file" {
+		t.Fatalf("Unexpected response meta: %+v", file.ResponseMeta)
+	}
+}
+
+func TestResponseMetaComparable(t *testing.T) {
+	if (File{}) != (File{}) || (Engine{}) != (Engine{}) {
+		t.Fatal("Expected responses without slices to stay comparable")
+	}
+}
@@ -0,0 +1,58 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrChatCompletionResponseIncomplete = errors.New("chat completion response is missing fields")
+)
+
+// ResponseValidationPolicy controls what the client does when a chat completion response,
+// typically from an OpenAI-compatible server, lacks fields OpenAI always sets:
+// the id, the created timestamp or the token usage.
+type ResponseValidationPolicy int
+
+const (
+	// ResponseValidationAccept returns incomplete responses as they are.
+	ResponseValidationAccept ResponseValidationPolicy = iota
+	// ResponseValidationWarn returns incomplete responses, reporting them to
+	// ClientConfig.OnResponseWarning.
+	ResponseValidationWarn
+	// ResponseValidationError fails the call with ErrChatCompletionResponseIncomplete,
+	// the response being returned along with the error.
+	ResponseValidationError
+)
+
+// validateChatCompletionResponse applies policy to response, returning the error to fail the call with.
+func (c *Client) validateChatCompletionResponse(response ChatCompletionResponse) error {
+	policy := c.config.ResponseValidationPolicy
+	if policy == ResponseValidationAccept {
+		return nil
+	}
+
+	var missing []string
+	if response.ID == "" {
+		missing = append(missing, "id")
+	}
+	if response.Created == 0 {
+		missing = append(missing, "created")
+	}
+	if response.Usage.TotalTokens == 0 {
+		missing = append(missing, "usage")
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	err := fmt.Errorf("%w: %s", ErrChatCompletionResponseIncomplete, strings.Join(missing, ", "))
+	if policy == ResponseValidationError {
+		return err
+	}
+	if c.config.OnResponseWarning != nil {
+		c.config.OnResponseWarning(err)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestChatCompletionResponseValidation(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		// A compatible server leaving out the id and the usage.
+		_, _ = w.Write([]byte(`{"created":1,"choices":[{"message":{"role":"assistant","content":"Hi"}}]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	request := ChatCompletionRequest{
+		Model:    GPT3Dot5Turbo,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hello!"}},
+	}
+	newClient := func(policy ResponseValidationPolicy, warnings *[]error) *Client {
+		config := DefaultConfig(test.GetTestToken())
+		config.BaseURL = ts.URL + "/v1"
+		config.ResponseValidationPolicy = policy
+		config.OnResponseWarning = func(err error) { *warnings = append(*warnings, err) }
+		return NewClientWithConfig(config)
+	}
+	ctx := context.Background()
+
+	var warnings []error
+	_, err := newClient(ResponseValidationAccept, &warnings).CreateChatCompletion(ctx, request)
+	checks.NoError(t, err, "ResponseValidationAccept should accept incomplete responses")
+	if len(warnings) != 0 {
+		t.Fatalf("ResponseValidationAccept should not warn: %v", warnings)
+	}
+
+	_, err = newClient(ResponseValidationWarn, &warnings).CreateChatCompletion(ctx, request)
+	checks.NoError(t, err, "ResponseValidationWarn should accept incomplete responses")
+	if len(warnings) != 1 || warnings[0].Error() != "chat completion response is missing fields: id, usage" {
+		t.Fatalf("Unexpected warnings: %v", warnings)
+	}
+
+	response, err := newClient(ResponseValidationError, &warnings).CreateChatCompletion(ctx, request)
+	checks.ErrorIs(t, err, ErrChatCompletionResponseIncomplete, "ResponseValidationError should fail")
+	if response.Choices[0].Message.Content != "Hi" {
+		t.Fatalf("The response should be returned with the error: %+v", response)
+	}
+}
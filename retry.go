@@ -0,0 +1,169 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultRetryInitialDelay = 500 * time.Millisecond
+	defaultRetryMaxDelay     = 30 * time.Second
+	// defaultAudioRetryAttempts is the number of attempts of audio calls when no retries are configured.
+	defaultAudioRetryAttempts = 3
+)
+
+// RetryConfig enables retrying calls that failed with a transport error, a timeout (408),
+// rate limiting (429) or a server error (5xx). The delay doubles after every attempt, and is
+// extended to the Retry-After of the response, up to MaxDelay. Streams, and uploads whose body
+// cannot be replayed, are not retried. Audio calls are tried 3 times, i.e. retried twice, when
+// no RetryConfig is set.
+type RetryConfig struct {
+	// MaxAttempts is the number of attempts made, including the first one.
+	MaxAttempts int
+	// InitialDelay is the delay before the second attempt; 500ms by default.
+	InitialDelay time.Duration
+	// MaxDelay caps the delay between attempts; 30s by default.
+	MaxDelay time.Duration
+}
+
+// RetryAttempt is a failed attempt of a call.
+type RetryAttempt struct {
+	// StatusCode is zero when the attempt failed without a response.
+	StatusCode int
+	// RequestID is the x-request-id of the response, if any.
+	RequestID string
+	// Delay is the time waited after the attempt, zero for the last one.
+	Delay time.Duration
+	Err   error
+}
+
+// RetryExhaustedError is returned when every attempt of a retried call failed. It wraps the
+// error of the last attempt, so errors.As still finds the APIError of the final failure.
+type RetryExhaustedError struct {
+	Attempts []RetryAttempt
+	Err      error
+}
+
+func (e *RetryExhaustedError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "giving up after %d attempts:", len(e.Attempts))
+	for i, attempt := range e.Attempts {
+		if i > 0 {
+			b.WriteByte(';')
+		}
+		fmt.Fprintf(&b, " #%d", i+1)
+		if attempt.StatusCode != 0 {
+			fmt.Fprintf(&b, " status %d", attempt.StatusCode)
+		}
+		if attempt.RequestID != "" {
+			fmt.Fprintf(&b, " request %s", attempt.RequestID)
+		}
+		if attempt.Delay > 0 {
+			fmt.Fprintf(&b, " retried after %s", attempt.Delay)
+		}
+	}
+	fmt.Fprintf(&b, ": %v", e.Err)
+	return b.String()
+}
+
+func (e *RetryExhaustedError) Unwrap() error {
+	return e.Err
+}
+
+// doWithRetries sends req like do, attempting it again as configured by ClientConfig.Retry.
+// It returns the response of the last attempt, which may have a failed status, and the
+// attempts that failed before it.
+func (c *Client) doWithRetries(req *http.Request) (res *http.Response, failed []RetryAttempt, err error) {
+	retry := c.retryConfig(req.Context())
+	replayable := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	if retry == nil || retry.MaxAttempts <= 1 || !replayable {
+		res, err = c.do(req)
+		return
+	}
+
+	delay := retry.InitialDelay
+	if delay <= 0 {
+		delay = defaultRetryInitialDelay
+	}
+	maxDelay := retry.MaxDelay
+	if maxDelay <= 0 {
+		maxDelay = defaultRetryMaxDelay
+	}
+
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			if req.Body, err = req.GetBody(); err != nil {
+				return
+			}
+		}
+		start := time.Now()
+		res, err = c.do(req)
+		if attempt == retry.MaxAttempts || !isRetryable(req.Context(), res, err) {
+			return
+		}
+		applyLatencyBudgetFallback(req, time.Since(start))
+
+		failure := RetryAttempt{Err: err, Delay: delay}
+		if err == nil {
+			if retryAfter := parseRetryAfter(res.Header); retryAfter > failure.Delay {
+				failure.Delay = minDuration(retryAfter, maxDelay)
+			}
+			failure.StatusCode = res.StatusCode
+			failure.RequestID = res.Header.Get("x-request-id")
+			failure.Err = c.handleErrorResp(res)
+			_, _ = io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+		failed = append(failed, failure)
+		if onRetry := requestOptionsFromContext(req.Context()).onRetry; onRetry != nil {
+			onRetry(failure)
+		}
+		c.reportRetryPath(req.Context(), req.Method, req.URL.Path, attempt+1, failure.Err)
+
+		timer := time.NewTimer(failure.Delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			res, err = nil, req.Context().Err()
+			return
+		case <-timer.C:
+		}
+		if delay *= 2; delay > maxDelay {
+			delay = maxDelay
+		}
+	}
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// isRetryable reports whether an attempt that ended with res or err may succeed when repeated.
+func isRetryable(ctx context.Context, res *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	return res.StatusCode == http.StatusRequestTimeout || res.StatusCode == http.StatusTooManyRequests ||
+		res.StatusCode >= http.StatusInternalServerError
+}
+
+// retryExhausted wraps the error of the last attempt of a call once earlier attempts failed.
+func retryExhausted(failed []RetryAttempt, res *http.Response, err error) error {
+	if err == nil || len(failed) == 0 {
+		return err
+	}
+	last := RetryAttempt{Err: err}
+	if res != nil {
+		last.StatusCode = res.StatusCode
+		last.RequestID = res.Header.Get("x-request-id")
+	}
+	return &RetryExhaustedError{Attempts: append(failed, last), Err: err}
+}
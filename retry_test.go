@@ -0,0 +1,151 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRetryExhausted(t *testing.T) {
+	var calls int
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if req, err := getChatCompletionBody(r); err != nil || req.Model != GPT4 {
+			t.Errorf("Attempt %d sent an unexpected body: %v", calls, err)
+		}
+		w.Header().Set("x-request-id", fmt.Sprintf("req_%d", calls))
+		w.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = w.Write([]byte(`{"error":{"message":"overloaded","type":"server_error"}}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var retries []int
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.Retry = &RetryConfig{MaxAttempts: 3, InitialDelay: time.Millisecond}
+	config.CallHooks = &CallHooks{OnRetry: func(info CallInfo, attempt int, err error) {
+		retries = append(retries, attempt)
+	}}
+	client := NewClientWithConfig(config)
+
+	_, err := client.CreateChatCompletion(context.Background(), ChatCompletionRequest{
+		Model:    GPT4,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hi"}},
+	})
+	var exhausted *RetryExhaustedError
+	if !errors.As(err, &exhausted) {
+		t.Fatalf("Expected RetryExhaustedError, got %v", err)
+	}
+	if calls != 3 || len(exhausted.Attempts) != 3 || len(retries) != 2 || retries[0] != 2 || retries[1] != 3 {
+		t.Fatalf("Unexpected attempts: %d calls, %+v, retries %v", calls, exhausted.Attempts, retries)
+	}
+	for i, attempt := range exhausted.Attempts {
+		if attempt.StatusCode != http.StatusServiceUnavailable || attempt.RequestID != fmt.Sprintf("req_%d", i+1) {
+			t.Errorf("Unexpected attempt %d: %+v", i+1, attempt)
+		}
+	}
+	if exhausted.Attempts[0].Delay != time.Millisecond || exhausted.Attempts[1].Delay != 2*time.Millisecond ||
+		exhausted.Attempts[2].Delay != 0 {
+		t.Errorf("Unexpected delays: %+v", exhausted.Attempts)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Expected the final APIError to be wrapped, got %v", err)
+	}
+	for _, want := range []string{"giving up after 3 attempts", "#2 status 503 request req_2", "overloaded"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Error %q does not contain %q", err, want)
+		}
+	}
+}
+
+func TestRetrySucceeds(t *testing.T) {
+	var calls int
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.Retry = &RetryConfig{MaxAttempts: 3, InitialDelay: time.Millisecond}
+	client := NewClientWithConfig(config)
+
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if calls != 2 {
+		t.Fatalf("Expected 2 attempts, got %d", calls)
+	}
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	var calls int
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"bad request"}}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.Retry = &RetryConfig{MaxAttempts: 3, InitialDelay: time.Millisecond}
+	client := NewClientWithConfig(config)
+
+	_, err := client.ListModels(context.Background())
+	var exhausted *RetryExhaustedError
+	if calls != 1 || errors.As(err, &exhausted) {
+		t.Fatalf("Expected a single attempt failing with the API error, got %d attempts: %v", calls, err)
+	}
+}
+
+func TestRetryHonorsRetryAfter(t *testing.T) {
+	var calls int
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		if calls++; calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"data":[]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	// The one second requested by the server is capped by MaxDelay.
+	config.Retry = &RetryConfig{MaxAttempts: 2, InitialDelay: time.Millisecond, MaxDelay: 50 * time.Millisecond}
+	client := NewClientWithConfig(config)
+
+	start := time.Now()
+	_, err := client.ListModels(context.Background())
+	checks.NoError(t, err, "ListModels error")
+	if elapsed := time.Since(start); calls != 2 || elapsed < 50*time.Millisecond || elapsed > time.Second {
+		t.Fatalf("Expected a retry after 50ms, got %d calls in %s", calls, elapsed)
+	}
+}
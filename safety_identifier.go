@@ -0,0 +1,68 @@
+package openai
+
+import (
+	"strings"
+)
+
+// ParameterDeprecation describes a request using a deprecated parameter with a model that
+// supports its replacement.
+type ParameterDeprecation struct {
+	Model       string
+	Parameter   string
+	Replacement string
+}
+
+// safetyIdentifierModelPrefixes lists the model families that accept safety_identifier,
+// which replaces user for abuse detection.
+var safetyIdentifierModelPrefixes = []string{"gpt-4o", "gpt-4.1", "o1", "o3", "o4", "gpt-5"}
+
+// safetyIdentifierLegacyModels lists the models known to predate safety_identifier and only
+// accept user.
+var safetyIdentifierLegacyModels = map[string]bool{
+	GPT3Dot5Turbo:     true,
+	GPT3Dot5Turbo0301: true,
+	GPT4:              true,
+	GPT40314:          true,
+	GPT432K:           true,
+	GPT432K0314:       true,
+}
+
+func supportsSafetyIdentifier(model string) bool {
+	for _, prefix := range safetyIdentifierModelPrefixes {
+		if model == prefix || strings.HasPrefix(model, prefix+"-") {
+			return true
+		}
+	}
+	return false
+}
+
+// migrateSafetyIdentifier maps the user and safety_identifier fields of request to the one
+// its model family understands, so callers can set either while migrating: models that
+// accept safety_identifier get the user value in it, and known legacy models get the
+// safety_identifier value as user. Using user with a newer model is reported to
+// ClientConfig.OnDeprecatedParameter. Both fields are sent as set for any other model,
+// such as fine-tunes and deployment names.
+func (c *Client) migrateSafetyIdentifier(request *ChatCompletionRequest) {
+	if safetyIdentifierLegacyModels[request.Model] {
+		if request.User == "" {
+			request.User = request.SafetyIdentifier
+		}
+		request.SafetyIdentifier = ""
+		return
+	}
+	if !supportsSafetyIdentifier(request.Model) || request.User == "" {
+		return
+	}
+
+	if c.config.OnDeprecatedParameter != nil {
+		c.config.OnDeprecatedParameter(ParameterDeprecation{
+			Model:       request.Model,
+			Parameter:   "user",
+			Replacement: "safety_identifier",
+		})
+	}
+	if request.SafetyIdentifier == "" {
+		request.SafetyIdentifier = request.User
+		request.User = ""
+	}
+}
@@ -0,0 +1,65 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestSafetyIdentifierMigration(t *testing.T) {
+	var sent ChatCompletionRequest
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		sent, _ = getChatCompletionBody(r)
+		_, _ = w.Write([]byte(`{"choices":[]}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var deprecations []ParameterDeprecation
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.OnDeprecatedParameter = func(deprecation ParameterDeprecation) {
+		deprecations = append(deprecations, deprecation)
+	}
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	testcases := []struct {
+		model, user, safetyIdentifier string
+		sentUser, sentSafety          string
+		deprecated                    bool
+	}{
+		{model: "gpt-4o-mini", user: "u1", sentSafety: "u1", deprecated: true},
+		{model: GPT5, user: "u1", safetyIdentifier: "s1", sentUser: "u1", sentSafety: "s1", deprecated: true},
+		{model: "gpt-4.1-2025-04-14", safetyIdentifier: "s1", sentSafety: "s1"},
+		{model: GPT3Dot5Turbo, safetyIdentifier: "s1", sentUser: "s1"},
+		{model: GPT4, user: "u1", safetyIdentifier: "s1", sentUser: "u1"},
+		{model: "my-deployment", user: "u1", safetyIdentifier: "s1", sentUser: "u1", sentSafety: "s1"},
+		{model: "ft:gpt-4o-mini:org::abc", safetyIdentifier: "s1", sentSafety: "s1"},
+	}
+	for _, tc := range testcases {
+		deprecations = nil
+		_, err := client.CreateChatCompletion(ctx, ChatCompletionRequest{
+			Model:            tc.model,
+			Messages:         []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hi"}},
+			User:             tc.user,
+			SafetyIdentifier: tc.safetyIdentifier,
+		})
+		checks.NoError(t, err, "CreateChatCompletion error")
+		if sent.User != tc.sentUser || sent.SafetyIdentifier != tc.sentSafety {
+			t.Errorf("%s: expected user %q and safety_identifier %q, sent %q and %q",
+				tc.model, tc.sentUser, tc.sentSafety, sent.User, sent.SafetyIdentifier)
+		}
+		if tc.deprecated != (len(deprecations) == 1) {
+			t.Errorf("%s: unexpected deprecations %+v", tc.model, deprecations)
+		} else if tc.deprecated && deprecations[0].Replacement != "safety_identifier" {
+			t.Errorf("%s: unexpected deprecation %+v", tc.model, deprecations[0])
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Speech models defined by the OpenAI API.
+const (
+	TTSModel1         = "tts-1"
+	TTSModel1HD       = "tts-1-hd"
+	TTSModelGPT4oMini = "gpt-4o-mini-tts"
+)
+
+// SpeechVoice is the voice audio is generated with.
+type SpeechVoice string
+
+const (
+	VoiceAlloy   SpeechVoice = "alloy"
+	VoiceAsh     SpeechVoice = "ash"
+	VoiceCoral   SpeechVoice = "coral"
+	VoiceEcho    SpeechVoice = "echo"
+	VoiceFable   SpeechVoice = "fable"
+	VoiceOnyx    SpeechVoice = "onyx"
+	VoiceNova    SpeechVoice = "nova"
+	VoiceSage    SpeechVoice = "sage"
+	VoiceShimmer SpeechVoice = "shimmer"
+)
+
+// Voices only supported by TTSModelGPT4oMini.
+const (
+	VoiceBallad SpeechVoice = "ballad"
+	VoiceVerse  SpeechVoice = "verse"
+	VoiceMarin  SpeechVoice = "marin"
+	VoiceCedar  SpeechVoice = "cedar"
+)
+
+// SpeechResponseFormat is the audio format of the generated speech; SpeechResponseFormatMP3 by default.
+type SpeechResponseFormat string
+
+const (
+	SpeechResponseFormatMP3  SpeechResponseFormat = "mp3"
+	SpeechResponseFormatOpus SpeechResponseFormat = "opus"
+	SpeechResponseFormatAAC  SpeechResponseFormat = "aac"
+	SpeechResponseFormatFLAC SpeechResponseFormat = "flac"
+	SpeechResponseFormatWAV  SpeechResponseFormat = "wav"
+	SpeechResponseFormatPCM  SpeechResponseFormat = "pcm"
+)
+
+const (
+	minSpeechSpeed = 0.25
+	maxSpeechSpeed = 4.0
+)
+
+var (
+	ErrSpeechInputEmpty                = errors.New("speech input must not be empty")
+	ErrSpeechSpeedInvalid              = errors.New("speech speed must be between 0.25 and 4.0")
+	ErrSpeechInstructionsNotSupported  = errors.New("instructions are not supported by tts-1 and tts-1-hd")
+	ErrSpeechVoiceNotSupportedForModel = errors.New("this voice is only supported by gpt-4o-mini-tts")
+)
+
+// SpeechRequest represents a request structure for the speech API.
+type SpeechRequest struct {
+	Model          string               `json:"model"`
+	Input          string               `json:"input"`
+	Voice          SpeechVoice          `json:"voice"`
+	ResponseFormat SpeechResponseFormat `json:"response_format,omitempty"`
+	// Speed of the generated audio, from 0.25 to 4.0; 1.0 when zero.
+	Speed float64 `json:"speed,omitempty"`
+	// Instructions steer the voice of TTSModelGPT4oMini, e.g. its tone, accent or pace.
+	// They are not supported by TTSModel1 and TTSModel1HD.
+	Instructions string `json:"instructions,omitempty"`
+}
+
+// CreateSpeech — API call to generate audio from text. Returns the audio file contents
+// in the requested format, from ClientConfig.SpeechCache when the request was generated before.
+func (c *Client) CreateSpeech(
+	ctx context.Context,
+	request SpeechRequest,
+	opts ...RequestOption,
+) (audio []byte, err error) {
+	ctx = withCallOptions(ctx, opts)
+	if err = validateSpeechRequest(request); err != nil {
+		return
+	}
+
+	if c.config.SpeechCache != nil {
+		return c.cachedSpeech(ctx, request, func() ([]byte, error) {
+			return c.createSpeech(ctx, request)
+		})
+	}
+	return c.createSpeech(ctx, request)
+}
+
+func (c *Client) createSpeech(ctx context.Context, request SpeechRequest) (audio []byte, err error) {
+	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL("/audio/speech"), request)
+	if err != nil {
+		return
+	}
+
+	err = c.sendRequest(req, &audio)
+	return
+}
+
+func validateSpeechRequest(request SpeechRequest) error {
+	if request.Input == "" {
+		return ErrSpeechInputEmpty
+	}
+	if request.Speed != 0 && (request.Speed < minSpeechSpeed || request.Speed > maxSpeechSpeed) {
+		return ErrSpeechSpeedInvalid
+	}
+	if request.Model == TTSModel1 || request.Model == TTSModel1HD {
+		if request.Instructions != "" {
+			return ErrSpeechInstructionsNotSupported
+		}
+		switch request.Voice {
+		case VoiceBallad, VoiceVerse, VoiceMarin, VoiceCedar:
+			return fmt.Errorf("%w: %s does not support %q", ErrSpeechVoiceNotSupportedForModel, request.Model, request.Voice)
+		}
+	}
+	return nil
+}
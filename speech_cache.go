@@ -0,0 +1,93 @@
+package openai
+
+import (
+	"context"
+	"sync"
+)
+
+// SpeechCache stores generated speech, keyed by SpeechCacheKey.
+type SpeechCache interface {
+	// Get returns the audio stored under key; ok is false when there is none.
+	Get(ctx context.Context, key string) (audio []byte, ok bool, err error)
+	Put(ctx context.Context, key string, audio []byte) error
+}
+
+// SpeechCacheConfig enables caching the audio returned by CreateSpeech, so repeated inputs,
+// e.g. notifications or IVR prompts, are only synthesized once. CreateSpeechStream is not cached.
+type SpeechCacheConfig struct {
+	Cache SpeechCache
+	// OnError receives the errors returned by Cache. They do not fail the call: the speech is
+	// generated by the API instead when the cache cannot be read.
+	OnError func(err error)
+}
+
+// SpeechCacheKey returns the key request is cached under: a hash of its input, voice, model,
+// format, speed and instructions. Requests that only differ in the default response format key
+// the same.
+func SpeechCacheKey(request SpeechRequest) (string, error) {
+	if request.ResponseFormat == "" {
+		request.ResponseFormat = SpeechResponseFormatMP3
+	}
+	return RequestHash(request)
+}
+
+// cachedSpeech returns the cached audio of request, or generates and caches it with create.
+func (c *Client) cachedSpeech(
+	ctx context.Context,
+	request SpeechRequest,
+	create func() ([]byte, error),
+) (audio []byte, err error) {
+	config := c.config.SpeechCache
+	key, err := SpeechCacheKey(request)
+	if err != nil {
+		return
+	}
+
+	audio, ok, cacheErr := config.Cache.Get(ctx, key)
+	if cacheErr != nil {
+		config.reportError(cacheErr)
+	} else if ok {
+		return
+	}
+
+	if audio, err = create(); err != nil {
+		return
+	}
+	if cacheErr = config.Cache.Put(ctx, key, audio); cacheErr != nil {
+		config.reportError(cacheErr)
+	}
+	return
+}
+
+func (config *SpeechCacheConfig) reportError(err error) {
+	if config.OnError != nil {
+		config.OnError(err)
+	}
+}
+
+// MemorySpeechCache is a SpeechCache holding the audio in memory. It is safe for concurrent use.
+type MemorySpeechCache struct {
+	mu    sync.Mutex
+	audio map[string][]byte
+}
+
+// NewMemorySpeechCache creates an empty MemorySpeechCache.
+func NewMemorySpeechCache() *MemorySpeechCache {
+	return &MemorySpeechCache{audio: make(map[string][]byte)}
+}
+
+// Get implements SpeechCache.
+func (m *MemorySpeechCache) Get(_ context.Context, key string) (audio []byte, ok bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	audio, ok = m.audio[key]
+	return
+}
+
+// Put implements SpeechCache.
+func (m *MemorySpeechCache) Put(_ context.Context, key string, audio []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.audio[key] = audio
+	return nil
+}
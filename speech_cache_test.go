@@ -0,0 +1,76 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+type failingSpeechCache struct{}
+
+func (failingSpeechCache) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, errors.New("cache unavailable")
+}
+
+func (failingSpeechCache) Put(context.Context, string, []byte) error {
+	return errors.New("cache unavailable")
+}
+
+func TestSpeechCache(t *testing.T) {
+	var calls int
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		var req SpeechRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		_, _ = w.Write([]byte(string(req.Voice) + ":" + req.Input))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.SpeechCache = &SpeechCacheConfig{Cache: NewMemorySpeechCache()}
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	requests := []SpeechRequest{
+		{Model: TTSModel1, Input: "Your call is important to us.", Voice: VoiceAlloy},
+		{Model: TTSModel1, Input: "Your call is important to us.", Voice: VoiceAlloy,
+			ResponseFormat: SpeechResponseFormatMP3},
+		{Model: TTSModel1, Input: "Your call is important to us.", Voice: VoiceEcho},
+		{Model: TTSModel1, Input: "Your call is important to us.", Voice: VoiceAlloy},
+	}
+	for _, request := range requests {
+		audio, err := client.CreateSpeech(ctx, request)
+		checks.NoError(t, err, "CreateSpeech error")
+		if want := string(request.Voice) + ":" + request.Input; string(audio) != want {
+			t.Fatalf("Expected audio %q, got %q", want, audio)
+		}
+	}
+	if calls != 2 {
+		t.Fatalf("Expected 2 syntheses, got %d", calls)
+	}
+
+	var cacheErrors int
+	config.SpeechCache = &SpeechCacheConfig{
+		Cache:   failingSpeechCache{},
+		OnError: func(err error) { cacheErrors++ },
+	}
+	client = NewClientWithConfig(config)
+	_, err := client.CreateSpeech(ctx, requests[0])
+	checks.NoError(t, err, "CreateSpeech should not fail when the cache does")
+	if calls != 3 || cacheErrors != 2 {
+		t.Fatalf("Expected a synthesis and 2 cache errors, got %d calls and %d errors", calls, cacheErrors)
+	}
+}
@@ -0,0 +1,103 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// SpeechStream is the audio generated by CreateSpeechStream, readable while the API is
+// still generating it. It must be closed.
+type SpeechStream struct {
+	response   *http.Response
+	finishCall func(err error, response any)
+	cancel     context.CancelFunc
+	closeOnce  sync.Once
+}
+
+// CreateSpeechStream — API call to generate audio from text, like CreateSpeech, without
+// buffering the audio in memory: playback can start with the first bytes read.
+func (c *Client) CreateSpeechStream(
+	ctx context.Context,
+	request SpeechRequest,
+	opts ...RequestOption,
+) (stream *SpeechStream, err error) {
+	ctx = withCallOptions(ctx, opts)
+	if err = validateSpeechRequest(request); err != nil {
+		return
+	}
+
+	ctx, cancel := callContext(ctx)
+	req, err := c.requestBuilder.build(ctx, http.MethodPost, c.fullURL("/audio/speech"), request)
+	if err != nil {
+		cancel()
+		return
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	c.setCommonHeaders(req)
+
+	req, finishCall := c.startCall(req, true)
+	resp, err := c.do(req) //nolint:bodyclose // body is closed in stream.Close()
+	if err != nil {
+		cancel()
+		finishCall(err, nil)
+		return
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
+		err = c.handleErrorResp(resp)
+		resp.Body.Close()
+		cancel()
+		finishCall(err, nil)
+		return nil, err
+	}
+
+	stream = &SpeechStream{response: resp, finishCall: finishCall, cancel: cancel}
+	return
+}
+
+// WriteSpeech generates audio from text into w as the API produces it, returning the
+// number of bytes written.
+func (c *Client) WriteSpeech(
+	ctx context.Context,
+	request SpeechRequest,
+	w io.Writer,
+	opts ...RequestOption,
+) (n int64, err error) {
+	ctx = withCallOptions(ctx, opts)
+	stream, err := c.CreateSpeechStream(ctx, request)
+	if err != nil {
+		return
+	}
+	defer stream.Close()
+
+	n, err = io.Copy(w, stream)
+	return
+}
+
+// Read reads the next bytes of audio.
+func (s *SpeechStream) Read(p []byte) (n int, err error) {
+	n, err = s.response.Body.Read(p)
+	if errors.Is(err, io.EOF) {
+		s.finishCall(nil, nil)
+	} else if err != nil {
+		s.finishCall(err, nil)
+	}
+	return
+}
+
+// Close stops the generation of audio that was not read yet.
+func (s *SpeechStream) Close() (err error) {
+	s.closeOnce.Do(func() {
+		s.finishCall(context.Canceled, nil)
+		s.cancel()
+		err = s.response.Body.Close()
+	})
+	return
+}
+
+// Header returns the response headers, e.g. the Content-Type of the audio.
+func (s *SpeechStream) Header() http.Header {
+	return s.response.Header
+}
@@ -0,0 +1,149 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+)
+
+func TestCreateSpeech(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		var req SpeechRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Speed != 1.5 ||
+			req.Voice != VoiceAlloy && (req.Voice != VoiceCoral || req.Instructions != "Speak cheerfully.") {
+			http.Error(w, "unexpected request", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "audio/mpeg")
+		_, _ = w.Write([]byte{0xFF, 0xFB, 0x90, 0x00})
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	audio, err := client.CreateSpeech(ctx, SpeechRequest{
+		Model: TTSModel1,
+		Input: "Hello!",
+		Voice: VoiceAlloy,
+		Speed: 1.5,
+	})
+	checks.NoError(t, err, "CreateSpeech error")
+	if len(audio) != 4 || audio[0] != 0xFF {
+		t.Fatalf("Unexpected audio: %x", audio)
+	}
+	_, err = client.CreateSpeech(ctx, SpeechRequest{
+		Model:        TTSModelGPT4oMini,
+		Input:        "Hello!",
+		Voice:        VoiceCoral,
+		Speed:        1.5,
+		Instructions: "Speak cheerfully.",
+	})
+	checks.NoError(t, err, "CreateSpeech with instructions error")
+
+	_, err = client.CreateSpeech(ctx, SpeechRequest{Model: TTSModel1, Voice: VoiceAlloy})
+	checks.ErrorIs(t, err, ErrSpeechInputEmpty, "CreateSpeech should reject empty input")
+	_, err = client.CreateSpeech(ctx, SpeechRequest{Model: TTSModel1, Input: "Hi", Voice: VoiceAlloy, Speed: 5})
+	checks.ErrorIs(t, err, ErrSpeechSpeedInvalid, "CreateSpeech should reject speeds above 4")
+	_, err = client.CreateSpeech(ctx, SpeechRequest{
+		Model:        TTSModel1,
+		Input:        "Hi",
+		Voice:        VoiceAlloy,
+		Instructions: "Whisper.",
+	})
+	checks.ErrorIs(t, err, ErrSpeechInstructionsNotSupported, "CreateSpeech should reject instructions for tts-1")
+	_, err = client.CreateSpeech(ctx, SpeechRequest{Model: TTSModel1HD, Input: "Hi", Voice: VoiceVerse})
+	checks.ErrorIs(t, err, ErrSpeechVoiceNotSupportedForModel, "CreateSpeech should reject verse for tts-1-hd")
+}
+
+func TestCreateSpeechStream(t *testing.T) {
+	firstRead := make(chan struct{})
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "audio/ogg")
+		_, _ = w.Write([]byte("OggS"))
+		w.(http.Flusher).Flush()
+		// The rest of the audio is only generated once the client has played the start.
+		select {
+		case <-firstRead:
+		case <-r.Context().Done():
+			return
+		}
+		_, _ = w.Write([]byte("-rest"))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+	request := SpeechRequest{Model: TTSModel1, Input: "Hello!", Voice: VoiceNova, ResponseFormat: SpeechResponseFormatOpus}
+
+	stream, err := client.CreateSpeechStream(ctx, request)
+	checks.NoError(t, err, "CreateSpeechStream error")
+	defer stream.Close()
+	if stream.Header().Get("Content-Type") != "audio/ogg" {
+		t.Errorf("Unexpected content type: %q", stream.Header().Get("Content-Type"))
+	}
+
+	start := make([]byte, 4)
+	_, err = io.ReadFull(stream, start)
+	checks.NoError(t, err, "Read error")
+	close(firstRead)
+	rest, err := io.ReadAll(stream)
+	checks.NoError(t, err, "Read error")
+	if string(start)+string(rest) != "OggS-rest" {
+		t.Fatalf("Unexpected audio: %q", string(start)+string(rest))
+	}
+	checks.NoError(t, stream.Close(), "Close error")
+
+	var buf bytes.Buffer
+	n, err := client.WriteSpeech(ctx, request, &buf)
+	checks.NoError(t, err, "WriteSpeech error")
+	if n != 9 || buf.String() != "OggS-rest" {
+		t.Fatalf("Unexpected audio: %d bytes %q", n, buf.String())
+	}
+
+	_, err = client.CreateSpeechStream(ctx, SpeechRequest{Model: TTSModel1, Voice: VoiceNova})
+	checks.ErrorIs(t, err, ErrSpeechInputEmpty, "CreateSpeechStream should reject empty input")
+}
+
+func TestCreateSpeechStreamError(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/audio/speech", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"message":"unknown voice","type":"invalid_request_error"}}`))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	client := NewClientWithConfig(config)
+
+	_, err := client.CreateSpeechStream(context.Background(), SpeechRequest{Model: TTSModel1, Input: "Hi", Voice: "x"})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.HTTPStatusCode != http.StatusBadRequest {
+		t.Fatalf("Expected an APIError with status 400, got %v", err)
+	}
+}
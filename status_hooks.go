@@ -0,0 +1,64 @@
+package openai
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+)
+
+// StatusEvent describes a response (or failure) that triggered a StatusHooks callback.
+type StatusEvent struct {
+	Method     string
+	Path       string
+	StatusCode int
+	RateLimit  RateLimitHeaders
+	// RetryAfter is the delay requested by the Retry-After header, if any.
+	RetryAfter time.Duration
+	// Err is set for timeouts.
+	Err error
+}
+
+// StatusHooks are called for specific classes of responses, e.g. to pause a worker pool
+// while rate limited, without wrapping the transport. Hooks are called synchronously
+// before the error is returned to the caller, so they should be fast.
+type StatusHooks struct {
+	On429     func(event StatusEvent)
+	On5xx     func(event StatusEvent)
+	OnTimeout func(event StatusEvent)
+}
+
+func (c *Client) runStatusHooks(req *http.Request, res *http.Response, err error) {
+	hooks := c.config.StatusHooks
+	if hooks == nil {
+		return
+	}
+
+	event := StatusEvent{Method: req.Method, Path: req.URL.Path}
+	if err != nil {
+		if hooks.OnTimeout != nil && isTimeout(err) {
+			event.Err = err
+			hooks.OnTimeout(event)
+		}
+		return
+	}
+
+	event.StatusCode = res.StatusCode
+	event.RateLimit = newRateLimitHeaders(res.Header)
+	event.RetryAfter = parseRetryAfter(res.Header)
+	switch {
+	case res.StatusCode == http.StatusTooManyRequests && hooks.On429 != nil:
+		hooks.On429(event)
+	case res.StatusCode >= http.StatusInternalServerError && hooks.On5xx != nil:
+		hooks.On5xx(event)
+	}
+}
+
+func isTimeout(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) && netErr.Timeout()
+}
@@ -0,0 +1,69 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStatusHooks(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/models", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("x-ratelimit-limit-requests", "60")
+		w.Header().Set("x-ratelimit-remaining-requests", "0")
+		w.Header().Set("x-ratelimit-reset-requests", "1s")
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+		_, _ = w.Write([]byte(`{"error":{"message":"Rate limit reached","type":"requests"}}`))
+	})
+	server.RegisterHandler("/v1/engines", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	server.RegisterHandler("/v1/files", func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var rateLimited, serverErrors, timeouts []StatusEvent
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.StatusHooks = &StatusHooks{
+		On429:     func(event StatusEvent) { rateLimited = append(rateLimited, event) },
+		On5xx:     func(event StatusEvent) { serverErrors = append(serverErrors, event) },
+		OnTimeout: func(event StatusEvent) { timeouts = append(timeouts, event) },
+	}
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	_, err := client.ListModels(ctx)
+	checks.HasError(t, err, "ListModels should fail")
+	if len(rateLimited) != 1 {
+		t.Fatalf("On429 should be called once, got %d", len(rateLimited))
+	}
+	event := rateLimited[0]
+	if event.Path != "/v1/models" || event.RateLimit.LimitRequests != 60 ||
+		event.RateLimit.ResetRequests.Duration() != time.Second || event.RetryAfter != 2*time.Second {
+		t.Fatalf("Unexpected 429 event: %+v", event)
+	}
+
+	_, err = client.ListEngines(ctx)
+	checks.HasError(t, err, "ListEngines should fail")
+	if len(serverErrors) != 1 || serverErrors[0].StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("Unexpected 5xx events: %+v", serverErrors)
+	}
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = client.ListFiles(timeoutCtx)
+	checks.ErrorIs(t, err, context.DeadlineExceeded, "ListFiles should time out")
+	if len(timeouts) != 1 || timeouts[0].Err == nil {
+		t.Fatalf("Unexpected timeout events: %+v", timeouts)
+	}
+}
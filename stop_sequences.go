@@ -0,0 +1,59 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// maxStopSequences is the number of stop sequences accepted by the chat and completion endpoints.
+const maxStopSequences = 4
+
+var (
+	ErrStopSequencesInvalid = errors.New("invalid stop sequences")
+)
+
+// StopSequences are the sequences where the API stops generating further tokens.
+// The API accepts a string or an array; StopSequences decodes both and always encodes an array.
+type StopSequences []string
+
+// UnmarshalJSON decodes a single string or an array of strings.
+func (s *StopSequences) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var sequence string
+		if err := json.Unmarshal(data, &sequence); err != nil {
+			return err
+		}
+		*s = StopSequences{sequence}
+		return nil
+	}
+	var sequences []string
+	if err := json.Unmarshal(data, &sequences); err != nil {
+		return err
+	}
+	*s = sequences
+	return nil
+}
+
+// validate checks s against the limits of model: at most four non-empty sequences, and none
+// for reasoning models, which do not support stop sequences.
+func (s StopSequences) validate(model string) error {
+	if len(s) == 0 {
+		return nil
+	}
+	if isReasoningModel(model) {
+		return fmt.Errorf("%w: %s does not support stop sequences", ErrStopSequencesInvalid, model)
+	}
+	if len(s) > maxStopSequences {
+		return fmt.Errorf("%w: %d sequences given, at most %d are supported",
+			ErrStopSequencesInvalid, len(s), maxStopSequences)
+	}
+	for i, sequence := range s {
+		if sequence == "" {
+			return fmt.Errorf("%w: sequence %d is empty", ErrStopSequencesInvalid, i)
+		}
+	}
+	return nil
+}
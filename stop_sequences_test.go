@@ -0,0 +1,51 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"testing"
+)
+
+func TestStopSequencesJSON(t *testing.T) {
+	var request ChatCompletionRequest
+	checks.NoError(t, json.Unmarshal([]byte(`{"stop":"\n"}`), &request), "Unmarshal error")
+	if len(request.Stop) != 1 || request.Stop[0] != "\n" {
+		t.Errorf("Expected a single stop sequence, got %q", request.Stop)
+	}
+	checks.NoError(t, json.Unmarshal([]byte(`{"stop":["a","b"]}`), &request), "Unmarshal error")
+	if len(request.Stop) != 2 || request.Stop[1] != "b" {
+		t.Errorf("Expected two stop sequences, got %q", request.Stop)
+	}
+	checks.HasError(t, json.Unmarshal([]byte(`{"stop":1}`), &request), "Unmarshal should reject numbers")
+
+	data, err := json.Marshal(CompletionRequest{Stop: StopSequences{"\n"}})
+	checks.NoError(t, err, "Marshal error")
+	if string(data) != `{"model":"","stop":["\n"]}` {
+		t.Errorf("Unexpected encoding: %s", data)
+	}
+}
+
+func TestStopSequencesValidation(t *testing.T) {
+	client := NewClient(test.GetTestToken())
+	ctx := context.Background()
+	messages := []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hi"}}
+
+	invalid := []ChatCompletionRequest{
+		{Model: GPT4, Messages: messages, Stop: StopSequences{"1", "2", "3", "4", "5"}},
+		{Model: GPT4, Messages: messages, Stop: StopSequences{""}},
+		{Model: O3Mini, Messages: messages, Stop: StopSequences{"\n"}},
+	}
+	for _, request := range invalid {
+		_, err := client.CreateChatCompletion(ctx, request)
+		checks.ErrorIs(t, err, ErrStopSequencesInvalid, "CreateChatCompletion should reject the stop sequences")
+		_, err = client.CreateChatCompletionStream(ctx, request)
+		checks.ErrorIs(t, err, ErrStopSequencesInvalid, "CreateChatCompletionStream should reject the stop sequences")
+	}
+
+	_, err := client.CreateCompletion(ctx, CompletionRequest{Model: GPT3Ada, Prompt: "Hi", Stop: []string{""}})
+	checks.ErrorIs(t, err, ErrStopSequencesInvalid, "CreateCompletion should reject empty stop sequences")
+}
@@ -22,30 +22,51 @@ type CompletionStream struct {
 func (c *Client) CreateCompletionStream(
 	ctx context.Context,
 	request CompletionRequest,
+	opts ...RequestOption,
 ) (stream *CompletionStream, err error) {
+	ctx = withCallOptions(ctx, opts)
 	urlSuffix := "/completions"
 	if !checkEndpointSupportsModel(urlSuffix, request.Model) {
 		err = ErrCompletionUnsupportedModel
 		return
 	}
 
+	c.warnIfDeprecated(request.Model)
+
 	if !checkPromptType(request.Prompt) {
 		err = ErrCompletionRequestPromptTypeNotSupported
 		return
 	}
 
+	if err = request.Stop.validate(request.Model); err != nil {
+		return
+	}
+
+	// Streams do not report usage, so the projected tokens are kept.
+	if _, err = c.acquireTokens(ctx, estimatePromptTokens(request.Prompt), request.MaxTokens, request.N); err != nil {
+		return
+	}
+
 	request.Stream = true
+	ctx, cancel := callContext(ctx)
 	req, err := c.newStreamRequest(ctx, "POST", urlSuffix, request)
 	if err != nil {
+		cancel()
 		return
 	}
 
-	resp, err := c.config.HTTPClient.Do(req) //nolint:bodyclose // body is closed in stream.Close()
+	req, finishCall := c.startCall(req, true)
+	resp, err := c.do(req) //nolint:bodyclose // body is closed in stream.Close()
 	if err != nil {
+		cancel()
+		finishCall(err, nil)
 		return
 	}
 	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusBadRequest {
-		return nil, c.handleErrorResp(resp)
+		err = c.handleErrorResp(resp)
+		cancel()
+		finishCall(err, nil)
+		return nil, err
 	}
 
 	stream = &CompletionStream{
@@ -54,7 +75,11 @@ func (c *Client) CreateCompletionStream(
 			reader:             bufio.NewReader(resp.Body),
 			response:           resp,
 			errAccumulator:     newErrorAccumulator(),
-			unmarshaler:        &jsonUnmarshaler{},
+			unmarshaler:        c.unmarshaler(),
+			onEvent:            c.streamEventHook(req),
+			watch:              c.newStreamWatch(req),
+			finishCall:         finishCall,
+			cancel:             cancel,
 		},
 	}
 	return
@@ -3,13 +3,17 @@ package openai
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"sync/atomic"
+	"time"
 )
 
 type streamable interface {
-	ChatCompletionStreamResponse | CompletionResponse
+	ChatCompletionStreamResponse | CompletionResponse | TranscriptionStreamEvent
 }
 
 type streamReader[T streamable] struct {
@@ -20,6 +24,16 @@ type streamReader[T streamable] struct {
 	response       *http.Response
 	errAccumulator errorAccumulator
 	unmarshaler    unmarshaler
+
+	onEvent    func()
+	finishCall func(err error, response any)
+	watch      *streamWatch
+
+	// cancel cancels the context of the request, tearing down the connection.
+	cancel context.CancelFunc
+	// finished is set once the stream ended, by its end, an error or Close.
+	finished int32
+	canceled int32
 }
 
 func (stream *streamReader[T]) Recv() (response T, err error) {
@@ -28,10 +42,28 @@ func (stream *streamReader[T]) Recv() (response T, err error) {
 		return
 	}
 
+	response, err = stream.recv()
+	switch {
+	case err == nil:
+		if stream.onEvent != nil {
+			stream.onEvent()
+		}
+	case errors.Is(err, io.EOF):
+		stream.finish(nil)
+	default:
+		stream.finish(err)
+	}
+	return
+}
+
+func (stream *streamReader[T]) recv() (response T, err error) {
 	var emptyMessagesCount uint
 
 waitForData:
 	line, err := stream.reader.ReadBytes('\n')
+	if stream.watch != nil && len(line) > 0 {
+		stream.watch.touch()
+	}
 	if err != nil {
 		respErr := stream.errAccumulator.unmarshalError()
 		if respErr != nil {
@@ -67,6 +99,46 @@ waitForData:
 	return
 }
 
+// finish reports the end of the stream with err, unless it already ended. The stream is
+// canceled if err is the error of a canceled or expired context.
+func (stream *streamReader[T]) finish(err error) {
+	if !atomic.CompareAndSwapInt32(&stream.finished, 0, 1) {
+		return
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		atomic.StoreInt32(&stream.canceled, 1)
+	}
+	if stream.watch != nil {
+		stream.watch.close()
+	}
+	if stream.finishCall != nil {
+		stream.finishCall(err, nil)
+	}
+}
+
+// Close ends the stream. A stream closed before it ended, by its end or an error, is canceled:
+// the connection is torn down (or the HTTP/2 stream reset), which is what makes the
+// server stop generating and consuming tokens. Close may be called from another goroutine
+// to abort a blocked Recv.
 func (stream *streamReader[T]) Close() {
+	stream.finish(context.Canceled)
+	if stream.cancel != nil {
+		stream.cancel()
+	}
 	stream.response.Body.Close()
 }
+
+// SinceLastEvent returns the time elapsed since the stream last received anything from the
+// server, including keep-alive messages, or since it was opened.
+func (stream *streamReader[T]) SinceLastEvent() time.Duration {
+	if stream.watch == nil {
+		return 0
+	}
+	return stream.watch.since()
+}
+
+// Canceled reports whether the stream was closed, or its context canceled, before the
+// server finished it, so the generation was aborted rather than completed.
+func (stream *streamReader[T]) Canceled() bool {
+	return atomic.LoadInt32(&stream.canceled) == 1
+}
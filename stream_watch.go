@@ -0,0 +1,91 @@
+package openai
+
+import (
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// StreamStall describes a stream that received nothing for longer than
+// StreamStallConfig.Threshold.
+type StreamStall struct {
+	Method string
+	Path   string
+	// Since is the time elapsed since the stream last received anything.
+	Since time.Duration
+	// Remaining is the time left before the deadline of the context of the stream; it is only
+	// set when HasDeadline is.
+	Remaining   time.Duration
+	HasDeadline bool
+}
+
+// StreamStallConfig reports streams going silent, telling a model that is still thinking apart
+// from a dead connection before the context deadline is reached. Keep-alive messages are
+// activity too, so connections that send them are only reported when the server stops them.
+type StreamStallConfig struct {
+	Threshold time.Duration
+	// OnStall is called at most once per silence, from its own goroutine.
+	OnStall func(stall StreamStall)
+}
+
+// streamWatch tracks the activity of a stream and reports stalls to ClientConfig.StreamStall.
+type streamWatch struct {
+	lastEvent int64
+	stop      chan struct{}
+	stopOnce  sync.Once
+}
+
+// newStreamWatch returns the watch of the stream made with req, watching it for stalls if
+// ClientConfig.StreamStall is set.
+func (c *Client) newStreamWatch(req *http.Request) *streamWatch {
+	w := &streamWatch{lastEvent: time.Now().UnixNano(), stop: make(chan struct{})}
+	config := c.config.StreamStall
+	if config == nil || config.Threshold <= 0 || config.OnStall == nil {
+		return w
+	}
+
+	stall := StreamStall{Method: req.Method, Path: req.URL.Path}
+	deadline, hasDeadline := req.Context().Deadline()
+	interval := config.Threshold / 4
+	if interval < time.Millisecond {
+		interval = time.Millisecond
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		var reported int64
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-req.Context().Done():
+				// The stream was abandoned without being closed or read to its end.
+				return
+			case <-ticker.C:
+			}
+			last := atomic.LoadInt64(&w.lastEvent)
+			if since := w.since(); since >= config.Threshold && last != reported {
+				reported = last
+				stall.Since = since
+				if hasDeadline {
+					stall.Remaining, stall.HasDeadline = time.Until(deadline), true
+				}
+				config.OnStall(stall)
+			}
+		}
+	}()
+	return w
+}
+
+func (w *streamWatch) touch() {
+	atomic.StoreInt64(&w.lastEvent, time.Now().UnixNano())
+}
+
+func (w *streamWatch) since() time.Duration {
+	return time.Since(time.Unix(0, atomic.LoadInt64(&w.lastEvent)))
+}
+
+func (w *streamWatch) close() {
+	w.stopOnce.Do(func() { close(w.stop) })
+}
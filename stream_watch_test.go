@@ -0,0 +1,116 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestStreamStall(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		chunk := "data: {\"id\":\"1\",\"choices\":[{\"index\":0,\"delta\":{\"content\":\"Hi\"}}]}\n\n"
+		_, _ = w.Write([]byte(chunk))
+		w.(http.Flusher).Flush()
+		time.Sleep(150 * time.Millisecond)
+		_, _ = w.Write([]byte(chunk + "data: [DONE]\n\n"))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var (
+		mu     sync.Mutex
+		stalls []StreamStall
+	)
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.StreamStall = &StreamStallConfig{
+		Threshold: 40 * time.Millisecond,
+		OnStall: func(stall StreamStall) {
+			mu.Lock()
+			defer mu.Unlock()
+			stalls = append(stalls, stall)
+		},
+	}
+	client := NewClientWithConfig(config)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	stream, err := client.CreateChatCompletionStream(ctx, ChatCompletionRequest{
+		Model:    GPT4,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hi"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	defer stream.Close()
+
+	for i := 0; i < 2; i++ {
+		_, err = stream.Recv()
+		checks.NoError(t, err, "Recv error")
+	}
+	if since := stream.SinceLastEvent(); since >= 40*time.Millisecond {
+		t.Errorf("Expected a recent event, got %s since the last one", since)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(stalls) != 1 {
+		t.Fatalf("Expected a single stall, got %+v", stalls)
+	}
+	stall := stalls[0]
+	if stall.Path != "/v1/chat/completions" || stall.Since < 40*time.Millisecond || !stall.HasDeadline ||
+		stall.Remaining <= 0 || stall.Remaining > 10*time.Second {
+		t.Fatalf("Unexpected stall: %+v", stall)
+	}
+}
+
+func TestStreamStallStopsWithContext(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var (
+		mu     sync.Mutex
+		stalls int
+	)
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.StreamStall = &StreamStallConfig{
+		Threshold: 30 * time.Millisecond,
+		OnStall: func(StreamStall) {
+			mu.Lock()
+			defer mu.Unlock()
+			stalls++
+		},
+	}
+	client := NewClientWithConfig(config)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	// The stream is abandoned: neither read to its end nor closed.
+	_, err := client.CreateChatCompletionStream(ctx, ChatCompletionRequest{
+		Model:    GPT4,
+		Messages: []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hi"}},
+	})
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	cancel()
+	time.Sleep(100 * time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if stalls != 0 {
+		t.Fatalf("Expected the watch to stop with the context, got %d stalls", stalls)
+	}
+}
@@ -0,0 +1,107 @@
+package openai
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ErrSubtitlesInvalid = errors.New("invalid subtitles")
+)
+
+// ParseSubtitles parses the Text of an AudioResponseFormatSRT or AudioResponseFormatVTT
+// transcription into cues, which FormatCaptions renders back. VTT cue settings, comments,
+// styles and regions are dropped, and VTT cues without a numeric identifier are numbered by
+// their position.
+func ParseSubtitles(text string, format AudioResponseFormat) ([]CaptionCue, error) {
+	text = strings.TrimPrefix(strings.ReplaceAll(text, "\r\n", "\n"), "\ufeff")
+	blocks := strings.Split(strings.TrimSpace(text), "\n\n")
+	switch format {
+	case AudioResponseFormatSRT:
+	case AudioResponseFormatVTT:
+		if !strings.HasPrefix(blocks[0], "WEBVTT") {
+			return nil, fmt.Errorf("%w: missing WEBVTT header", ErrSubtitlesInvalid)
+		}
+		blocks = blocks[1:]
+	default:
+		return nil, fmt.Errorf("%w: subtitles can be parsed from %q or %q, not %q",
+			ErrAudioResponseFormatNotSupported, AudioResponseFormatSRT, AudioResponseFormatVTT, format)
+	}
+
+	var cues []CaptionCue
+	for _, block := range blocks {
+		lines := strings.Split(strings.Trim(block, "\n"), "\n")
+		if lines[0] == "" || format == AudioResponseFormatVTT && isVTTMetadataBlock(lines[0]) {
+			continue
+		}
+		cue := CaptionCue{Index: len(cues) + 1}
+		if !strings.Contains(lines[0], "-->") {
+			index, err := strconv.Atoi(strings.TrimSpace(lines[0]))
+			if err == nil {
+				cue.Index = index
+			} else if format == AudioResponseFormatSRT {
+				return nil, fmt.Errorf("%w: cue %d has no index", ErrSubtitlesInvalid, len(cues)+1)
+			}
+			lines = lines[1:]
+		}
+		if len(lines) == 0 {
+			return nil, fmt.Errorf("%w: cue %d has no timing", ErrSubtitlesInvalid, len(cues)+1)
+		}
+		var err error
+		if cue.Start, cue.End, err = parseSubtitleTiming(lines[0]); err != nil {
+			return nil, fmt.Errorf("%w: cue %d: %s", ErrSubtitlesInvalid, len(cues)+1, err.Error())
+		}
+		cue.Text = strings.Join(lines[1:], "\n")
+		cues = append(cues, cue)
+	}
+	return cues, nil
+}
+
+func isVTTMetadataBlock(line string) bool {
+	return line == "NOTE" || line == "STYLE" || line == "REGION" ||
+		strings.HasPrefix(line, "NOTE ") || strings.HasPrefix(line, "NOTE\t")
+}
+
+// parseSubtitleTiming parses a "start --> end" line, ignoring VTT cue settings after end.
+func parseSubtitleTiming(line string) (start, end time.Duration, err error) {
+	from, to, found := strings.Cut(line, "-->")
+	if !found {
+		err = fmt.Errorf("invalid timing %q", line)
+		return
+	}
+	if fields := strings.Fields(to); len(fields) > 0 {
+		to = fields[0]
+	}
+	if start, err = parseSubtitleTimestamp(strings.TrimSpace(from)); err != nil {
+		return
+	}
+	end, err = parseSubtitleTimestamp(to)
+	return
+}
+
+// parseSubtitleTimestamp parses [hh:]mm:ss,mmm and [hh:]mm:ss.mmm timestamps.
+func parseSubtitleTimestamp(timestamp string) (time.Duration, error) {
+	clock, millis, found := strings.Cut(strings.Replace(timestamp, ",", ".", 1), ".")
+	parts := strings.Split(clock, ":")
+	if !found || len(millis) != 3 || len(parts) < 2 || len(parts) > 3 {
+		return 0, fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+
+	var d time.Duration
+	units := []time.Duration{time.Second, time.Minute, time.Hour}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 || i > 0 && n >= 60 {
+			return 0, fmt.Errorf("invalid timestamp %q", timestamp)
+		}
+		d += time.Duration(n) * units[len(parts)-1-i]
+	}
+	ms, err := strconv.Atoi(millis)
+	if err != nil {
+		return 0, fmt.Errorf("invalid timestamp %q", timestamp)
+	}
+	return d + time.Duration(ms)*time.Millisecond, nil
+}
@@ -0,0 +1,77 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"testing"
+	"time"
+)
+
+func TestParseSRT(t *testing.T) {
+	srt := "\ufeff1\r\n00:00:00,000 --> 00:00:02,500\r\nHello,\r\nworld.\r\n\r\n" +
+		"2\r\n00:00:02,500 --> 01:00:05,040\r\nBye.\r\n"
+	cues, err := ParseSubtitles(srt, AudioResponseFormatSRT)
+	checks.NoError(t, err, "ParseSubtitles error")
+	expected := []CaptionCue{
+		{Index: 1, Start: 0, End: 2500 * time.Millisecond, Text: "Hello,\nworld."},
+		{Index: 2, Start: 2500 * time.Millisecond, End: time.Hour + 5040*time.Millisecond, Text: "Bye."},
+	}
+	if len(cues) != len(expected) {
+		t.Fatalf("Expected %d cues, got %+v", len(expected), cues)
+	}
+	for i := range expected {
+		if cues[i] != expected[i] {
+			t.Errorf("Cue %d: expected %+v, got %+v", i, expected[i], cues[i])
+		}
+	}
+
+	formatted, err := FormatCaptions(cues, AudioResponseFormatSRT)
+	checks.NoError(t, err, "FormatCaptions error")
+	if formatted != "1\n00:00:00,000 --> 00:00:02,500\nHello,\nworld.\n\n2\n00:00:02,500 --> 01:00:05,040\nBye.\n\n" {
+		t.Errorf("Unexpected SRT: %q", formatted)
+	}
+
+	for _, invalid := range []string{
+		"one\n00:00:00,000 --> 00:00:01,000\nHi",
+		"1\n00:00:00 --> 00:00:01,000\nHi",
+		"1\n00:61:00,000 --> 00:62:00,000\nHi",
+		"1",
+	} {
+		_, err = ParseSubtitles(invalid, AudioResponseFormatSRT)
+		checks.ErrorIs(t, err, ErrSubtitlesInvalid, "ParseSubtitles should reject "+invalid)
+	}
+}
+
+func TestParseVTT(t *testing.T) {
+	vtt := "WEBVTT - transcript\n\nNOTE generated\n\nSTYLE\n::cue { color: red }\n\n" +
+		"00:01.000 --> 00:04.000 align:start\nFirst\n\n" +
+		"intro\n00:00:04.000 --> 00:00:05.250\nSecond\n\n" +
+		"7\n00:00:05.250 --> 00:00:06.000\nThird\n"
+	cues, err := ParseSubtitles(vtt, AudioResponseFormatVTT)
+	checks.NoError(t, err, "ParseSubtitles error")
+	expected := []CaptionCue{
+		{Index: 1, Start: time.Second, End: 4 * time.Second, Text: "First"},
+		{Index: 2, Start: 4 * time.Second, End: 5250 * time.Millisecond, Text: "Second"},
+		{Index: 7, Start: 5250 * time.Millisecond, End: 6 * time.Second, Text: "Third"},
+	}
+	if len(cues) != len(expected) {
+		t.Fatalf("Expected %d cues, got %+v", len(expected), cues)
+	}
+	for i := range expected {
+		if cues[i] != expected[i] {
+			t.Errorf("Cue %d: expected %+v, got %+v", i, expected[i], cues[i])
+		}
+	}
+
+	formatted, err := FormatCaptions(cues[:1], AudioResponseFormatVTT)
+	checks.NoError(t, err, "FormatCaptions error")
+	if formatted != "WEBVTT\n\n00:00:01.000 --> 00:00:04.000\nFirst\n\n" {
+		t.Errorf("Unexpected VTT: %q", formatted)
+	}
+
+	_, err = ParseSubtitles("00:01.000 --> 00:04.000\nFirst", AudioResponseFormatVTT)
+	checks.ErrorIs(t, err, ErrSubtitlesInvalid, "ParseSubtitles should require the WEBVTT header")
+	_, err = ParseSubtitles("{}", AudioResponseFormatJSON)
+	checks.ErrorIs(t, err, ErrAudioResponseFormatNotSupported, "ParseSubtitles should reject JSON")
+}
@@ -0,0 +1,151 @@
+package openai
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// defaultProjectedCompletionTokens is used to project the completion size of requests
+// that do not set MaxTokens.
+const defaultProjectedCompletionTokens = 256
+
+// TokenBudgetError is returned when a request does not fit into the tokens-per-minute budget
+// of a TokenLimiter.
+type TokenBudgetError struct {
+	Requested int
+	Available int
+	// RetryAfter is how long it takes until the budget can admit the request,
+	// zero if the request is larger than the whole budget.
+	RetryAfter time.Duration
+}
+
+func (e *TokenBudgetError) Error() string {
+	return fmt.Sprintf("token budget exceeded: requested %d tokens, %d available", e.Requested, e.Available)
+}
+
+// TokenLimiter admits requests based on their estimated prompt tokens plus projected completion
+// tokens against a tokens-per-minute budget. The budget refills continuously, like the API's own
+// rate limiter. Once a response arrives the estimate is corrected with the reported usage.
+// TokenLimiter is safe for concurrent use and may be shared by several clients.
+type TokenLimiter struct {
+	tokensPerMinute int
+	wait            bool
+
+	mu        sync.Mutex
+	available float64
+	updatedAt time.Time
+	now       func() time.Time
+}
+
+// NewTokenLimiter creates a limiter for tokensPerMinute. When wait is true requests are
+// queued until the budget admits them (or their context ends), otherwise they are rejected
+// immediately with a *TokenBudgetError.
+func NewTokenLimiter(tokensPerMinute int, wait bool) *TokenLimiter {
+	return &TokenLimiter{
+		tokensPerMinute: tokensPerMinute,
+		wait:            wait,
+		available:       float64(tokensPerMinute),
+		updatedAt:       time.Now(),
+		now:             time.Now,
+	}
+}
+
+// Acquire takes tokens from the budget.
+func (l *TokenLimiter) Acquire(ctx context.Context, tokens int) error {
+	for {
+		err := l.tryAcquire(tokens)
+		if err == nil {
+			return nil
+		}
+		if !l.wait || err.RetryAfter == 0 {
+			return err
+		}
+
+		timer := time.NewTimer(err.RetryAfter)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// Settle corrects the budget once the actual usage of a request admitted for estimated tokens is known.
+func (l *TokenLimiter) Settle(estimated, actual int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	l.available += float64(estimated - actual)
+	if l.available > float64(l.tokensPerMinute) {
+		l.available = float64(l.tokensPerMinute)
+	}
+}
+
+func (l *TokenLimiter) tryAcquire(tokens int) *TokenBudgetError {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	if float64(tokens) <= l.available {
+		l.available -= float64(tokens)
+		return nil
+	}
+
+	err := &TokenBudgetError{Requested: tokens, Available: int(l.available)}
+	if tokens <= l.tokensPerMinute {
+		missing := float64(tokens) - l.available
+		err.RetryAfter = time.Duration(missing / float64(l.tokensPerMinute) * float64(time.Minute))
+	}
+	return err
+}
+
+func (l *TokenLimiter) refill() {
+	now := l.now()
+	elapsed := now.Sub(l.updatedAt)
+	if elapsed <= 0 {
+		return
+	}
+	l.updatedAt = now
+	l.available += elapsed.Minutes() * float64(l.tokensPerMinute)
+	if l.available > float64(l.tokensPerMinute) {
+		l.available = float64(l.tokensPerMinute)
+	}
+}
+
+// acquireTokens takes the projected tokens of a request from the configured limiter, if any.
+func (c *Client) acquireTokens(ctx context.Context, promptTokens, maxTokens, n int) (estimated int, err error) {
+	if c.config.TokenLimiter == nil {
+		return
+	}
+	estimated = projectedTokens(promptTokens, maxTokens, n)
+	err = c.config.TokenLimiter.Acquire(ctx, estimated)
+	return
+}
+
+// settleTokens corrects the limiter estimate with the usage reported by the API.
+// Failed requests give their tokens back.
+func (c *Client) settleTokens(estimated int, usage Usage, err error) {
+	if c.config.TokenLimiter == nil {
+		return
+	}
+	if err != nil {
+		c.config.TokenLimiter.Settle(estimated, 0)
+		return
+	}
+	c.config.TokenLimiter.Settle(estimated, usage.TotalTokens)
+}
+
+// projectedTokens returns the prompt estimate plus the completion tokens a request may use.
+func projectedTokens(promptTokens, maxTokens, n int) int {
+	if maxTokens <= 0 {
+		maxTokens = defaultProjectedCompletionTokens
+	}
+	if n <= 0 {
+		n = 1
+	}
+	return promptTokens + maxTokens*n
+}
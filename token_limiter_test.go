@@ -0,0 +1,95 @@
+package openai //nolint:testpackage // testing private field
+
+import (
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTokenLimiter(t *testing.T) {
+	now := time.Now()
+	limiter := NewTokenLimiter(600, false)
+	limiter.updatedAt = now
+	limiter.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	checks.NoError(t, limiter.Acquire(ctx, 500), "Acquire error")
+
+	err := limiter.Acquire(ctx, 200)
+	var budgetErr *TokenBudgetError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("Expected TokenBudgetError, got %v", err)
+	}
+	if budgetErr.Available != 100 || budgetErr.RetryAfter != 10*time.Second {
+		t.Fatalf("Unexpected budget error: %+v", budgetErr)
+	}
+
+	// 10 seconds refill 100 tokens.
+	now = now.Add(10 * time.Second)
+	checks.NoError(t, limiter.Acquire(ctx, 200), "Acquire error")
+
+	// The request used less than estimated.
+	limiter.Settle(200, 50)
+	checks.NoError(t, limiter.Acquire(ctx, 150), "Acquire error")
+
+	err = limiter.Acquire(ctx, 601)
+	if !errors.As(err, &budgetErr) || budgetErr.RetryAfter != 0 {
+		t.Fatalf("Requests larger than the budget should never be admitted, got %v", err)
+	}
+}
+
+func TestTokenLimiterWait(t *testing.T) {
+	limiter := NewTokenLimiter(60000, true)
+	ctx := context.Background()
+	checks.NoError(t, limiter.Acquire(ctx, 60000), "Acquire error")
+
+	// 10 tokens refill in 10ms.
+	start := time.Now()
+	checks.NoError(t, limiter.Acquire(ctx, 10), "Acquire should wait for the budget")
+	if time.Since(start) < 5*time.Millisecond {
+		t.Fatal("Acquire should have waited")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	err := limiter.Acquire(ctx, 60000)
+	checks.ErrorIs(t, err, context.DeadlineExceeded, "Acquire should stop waiting when the context ends")
+}
+
+func TestClientTokenLimiter(t *testing.T) {
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, `{"id":"chatcmpl-1","choices":[],"usage":{"total_tokens":20}}`)
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.TokenLimiter = NewTokenLimiter(100, false)
+	config.TokenLimiter.now = func() time.Time { return config.TokenLimiter.updatedAt }
+	client := NewClientWithConfig(config)
+
+	req := ChatCompletionRequest{
+		Model:     GPT3Dot5Turbo,
+		MaxTokens: 10,
+		Messages:  []ChatCompletionMessage{{Role: ChatMessageRoleUser, Content: "Hello!"}},
+	}
+	for i := 0; i < 5; i++ {
+		_, err := client.CreateChatCompletion(context.Background(), req)
+		checks.NoError(t, err, "CreateChatCompletion error")
+	}
+
+	_, err := client.CreateChatCompletion(context.Background(), req)
+	var budgetErr *TokenBudgetError
+	if !errors.As(err, &budgetErr) {
+		t.Fatalf("Expected TokenBudgetError after spending the budget, got %v", err)
+	}
+}
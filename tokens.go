@@ -0,0 +1,77 @@
+package openai
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// charsPerToken is the average number of ASCII characters per token for English text,
+// as documented by OpenAI for its BPE tokenizers.
+const charsPerToken = 4
+
+// estimateTokens returns an approximate token count for text. It is intentionally
+// conservative: every whitespace-separated run of ASCII characters costs one token per
+// charsPerToken characters (and at least one), while every non-ASCII rune (CJK, emoji,
+// accented letters) is counted as a token of its own.
+func estimateTokens(text string) int {
+	var tokens, run int
+	flush := func() {
+		tokens += (run + charsPerToken - 1) / charsPerToken
+		run = 0
+	}
+	for _, r := range text {
+		switch {
+		case unicode.IsSpace(r):
+			flush()
+		case r < utf8.RuneSelf:
+			run++
+		default:
+			flush()
+			tokens++
+		}
+	}
+	flush()
+	return tokens
+}
+
+// Per-message and reply priming overheads of the chat format, see
+// https://github.com/openai/openai-cookbook/blob/main/examples/How_to_count_tokens_with_tiktoken.ipynb
+const (
+	chatTokensPerMessage = 3
+	chatTokensPerName    = 1
+	chatTokensPerReply   = 3
+)
+
+// estimateChatTokens returns an approximate prompt token count for messages.
+func estimateChatTokens(messages []ChatCompletionMessage) int {
+	tokens := chatTokensPerReply
+	for _, message := range messages {
+		tokens += chatTokensPerMessage + estimateTokens(message.Role) + estimateTokens(message.Content)
+		for _, part := range message.MultiContent {
+			tokens += estimateTokens(part.Text)
+		}
+		if message.Name != "" {
+			tokens += chatTokensPerName + estimateTokens(message.Name)
+		}
+		for _, call := range message.ToolCalls {
+			tokens += estimateTokens(call.Function.Name) + estimateTokens(call.Function.Arguments)
+		}
+	}
+	return tokens
+}
+
+// estimatePromptTokens returns an approximate token count for a completion prompt.
+func estimatePromptTokens(prompt any) int {
+	switch p := prompt.(type) {
+	case string:
+		return estimateTokens(p)
+	case []string:
+		var tokens int
+		for _, s := range p {
+			tokens += estimateTokens(s)
+		}
+		return tokens
+	default:
+		return 0
+	}
+}
@@ -0,0 +1,60 @@
+package openai
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync/atomic"
+)
+
+// transferCounter counts the body bytes of the attempts of a call. Bytes are counted as
+// they are on the wire: compressed request and response bodies count their compressed size.
+type transferCounter struct {
+	sent     int64
+	received int64
+}
+
+type transferCounterKey struct{}
+
+// withTransferCounter returns req with a counter attached, which do fills in.
+func withTransferCounter(req *http.Request) (*http.Request, *transferCounter) {
+	counter := &transferCounter{}
+	return req.WithContext(context.WithValue(req.Context(), transferCounterKey{}, counter)), counter
+}
+
+// countTransfer makes the body of req and res count into the counter attached to req, if any.
+// It returns the request to send, as the body of req itself is left untouched.
+func countTransfer(req *http.Request) (counted *http.Request, countResponse func(res *http.Response)) {
+	counter, _ := req.Context().Value(transferCounterKey{}).(*transferCounter)
+	if counter == nil {
+		return req, func(*http.Response) {}
+	}
+
+	copied := *req
+	if req.Body != nil && req.Body != http.NoBody {
+		copied.Body = &countingReadCloser{ReadCloser: req.Body, count: &counter.sent}
+	}
+	if req.GetBody != nil {
+		copied.GetBody = func() (io.ReadCloser, error) {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			return &countingReadCloser{ReadCloser: body, count: &counter.sent}, nil
+		}
+	}
+	return &copied, func(res *http.Response) {
+		res.Body = &countingReadCloser{ReadCloser: res.Body, count: &counter.received}
+	}
+}
+
+type countingReadCloser struct {
+	io.ReadCloser
+	count *int64
+}
+
+func (r *countingReadCloser) Read(p []byte) (n int, err error) {
+	n, err = r.ReadCloser.Read(p)
+	atomic.AddInt64(r.count, int64(n))
+	return
+}
@@ -0,0 +1,90 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestTransferAccounting(t *testing.T) {
+	const (
+		completion = `{"model":"gpt-4","choices":[],"usage":{"total_tokens":3}}`
+		events     = "data: {\"choices\":[]}\n\ndata: [DONE]\n\n"
+	)
+	server := test.NewTestServer()
+	server.RegisterHandler("/v1/chat/completions", func(w http.ResponseWriter, r *http.Request) {
+		req, _ := getChatCompletionBody(r)
+		if req.Stream {
+			w.Header().Set("Content-Type", "text/event-stream")
+			_, _ = w.Write([]byte(events))
+			return
+		}
+		_, _ = w.Write([]byte(completion))
+	})
+	ts := server.OpenAITestServer()
+	ts.Start()
+	defer ts.Close()
+
+	var snapshots []UsageSnapshot
+	reporter := NewUsageReporter(UsageReporterConfig{
+		Interval:   time.Hour,
+		OnSnapshot: func(snapshot UsageSnapshot) { snapshots = append(snapshots, snapshot) },
+	})
+	var calls []CallInfo
+	config := DefaultConfig(test.GetTestToken())
+	config.BaseURL = ts.URL + "/v1"
+	config.CallHooks = &CallHooks{OnComplete: func(info CallInfo, err error, usage Usage, duration time.Duration) {
+		calls = append(calls, info)
+		reporter.OnComplete(info, err, usage, duration)
+	}}
+	client := NewClientWithConfig(config)
+	ctx := context.Background()
+
+	request := ChatCompletionRequest{Model: GPT4, Messages: []ChatCompletionMessage{{Role: "user", Content: "Hi"}}}
+	body, _ := json.Marshal(request)
+	_, err := client.CreateChatCompletion(ctx, request)
+	checks.NoError(t, err, "CreateChatCompletion error")
+
+	stream, err := client.CreateChatCompletionStream(ctx, request)
+	checks.NoError(t, err, "CreateChatCompletionStream error")
+	for {
+		if _, err = stream.Recv(); errors.Is(err, io.EOF) {
+			break
+		}
+		checks.NoError(t, err, "Recv error")
+	}
+	stream.Close()
+
+	request.Stream = true
+	streamBody, _ := json.Marshal(request)
+	if len(calls) != 2 {
+		t.Fatalf("Expected two calls, got %+v", calls)
+	}
+	if calls[0].BytesSent != int64(len(body)) || calls[0].BytesReceived != int64(len(completion)) {
+		t.Errorf("Expected %d bytes sent and %d received, got %+v", len(body), len(completion), calls[0])
+	}
+	if calls[1].BytesSent != int64(len(streamBody)) || calls[1].BytesReceived != int64(len(events)) {
+		t.Errorf("Expected %d bytes sent and %d received, got %+v", len(streamBody), len(events), calls[1])
+	}
+
+	reporter.Flush()
+	if len(snapshots) != 1 {
+		t.Fatalf("Expected one snapshot, got %+v", snapshots)
+	}
+	var sent, received int64
+	for _, entry := range snapshots[0].Entries {
+		sent += entry.BytesSent
+		received += entry.BytesReceived
+	}
+	if sent != calls[0].BytesSent+calls[1].BytesSent || received != calls[0].BytesReceived+calls[1].BytesReceived {
+		t.Errorf("Unexpected reported transfer: %d sent, %d received", sent, received)
+	}
+}
@@ -0,0 +1,205 @@
+package openai
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ModelPrice is the price of a model in USD per million tokens.
+type ModelPrice struct {
+	Prompt     float64
+	Completion float64
+}
+
+// UsageEntry is the usage of one group and model within a snapshot.
+type UsageEntry struct {
+	Group string
+	// Tag is the request tag the usage was recorded for, see WithRequestTag.
+	Tag      string
+	Model    string
+	Requests int
+	Usage    Usage
+	// BytesSent and BytesReceived are the request and response body bytes of the calls
+	// reported through OnComplete, see CallInfo.
+	BytesSent     int64
+	BytesReceived int64
+	// Cost is in USD, zero for models without a price.
+	Cost float64
+}
+
+// UsageSnapshot is the usage recorded within [Start, End), ordered by group, tag and model.
+type UsageSnapshot struct {
+	Start   time.Time
+	End     time.Time
+	Entries []UsageEntry
+}
+
+const defaultUsageReportInterval = time.Minute
+
+// UsageReporterConfig configures a UsageReporter.
+type UsageReporterConfig struct {
+	// Interval is the length of a bucket, e.g. time.Minute or time.Hour. Defaults to a minute.
+	Interval time.Duration
+	// Prices maps models to their prices. Models are matched exactly, so dated snapshots such as
+	// gpt-4-0613 need their own entry. The client ships no prices, since they change over time.
+	Prices map[string]ModelPrice
+	// GroupBy is the request metadata key calls are grouped by, e.g. "tenant".
+	// Calls are not grouped when empty.
+	GroupBy string
+	// OnSnapshot receives every bucket with recorded usage once it is over.
+	OnSnapshot func(snapshot UsageSnapshot)
+}
+
+type usageKey struct {
+	group string
+	tag   string
+	model string
+}
+
+// UsageReporter aggregates token usage and cost into time buckets, e.g. for chargeback in
+// multi-tenant services. It is safe for concurrent use. Install it with
+// CallHooks{OnComplete: reporter.OnComplete}, or feed it with Record.
+//
+// Snapshots are emitted when usage is recorded in a later bucket, by Run at the end of
+// every bucket, and by Flush.
+type UsageReporter struct {
+	config UsageReporterConfig
+	now    func() time.Time
+
+	mu      sync.Mutex
+	start   time.Time
+	entries map[usageKey]*UsageEntry
+}
+
+// NewUsageReporter creates a reporter.
+func NewUsageReporter(config UsageReporterConfig) *UsageReporter {
+	if config.Interval <= 0 {
+		config.Interval = defaultUsageReportInterval
+	}
+	return &UsageReporter{config: config, now: time.Now, entries: make(map[usageKey]*UsageEntry)}
+}
+
+// OnComplete records the usage of successful calls. It matches CallHooks.OnComplete.
+func (r *UsageReporter) OnComplete(info CallInfo, err error, usage Usage, _ time.Duration) {
+	if err != nil {
+		return
+	}
+	var group string
+	if r.config.GroupBy != "" {
+		group = info.Metadata[r.config.GroupBy]
+	}
+	r.record(usageKey{group: group, tag: info.Tag, model: info.Model}, usage, info.BytesSent, info.BytesReceived)
+}
+
+// Record adds the usage of a request of group to model.
+func (r *UsageReporter) Record(group, model string, usage Usage) {
+	r.RecordTagged(group, "", model, usage)
+}
+
+// RecordTagged adds the usage of a request of group, tagged with tag, to model.
+func (r *UsageReporter) RecordTagged(group, tag, model string, usage Usage) {
+	r.record(usageKey{group: group, tag: tag, model: model}, usage, 0, 0)
+}
+
+func (r *UsageReporter) record(key usageKey, usage Usage, bytesSent, bytesReceived int64) {
+	r.mu.Lock()
+	snapshot, ok := r.rollOver(r.now())
+	entry := r.entries[key]
+	if entry == nil {
+		entry = &UsageEntry{Group: key.group, Tag: key.tag, Model: key.model}
+		r.entries[key] = entry
+	}
+	entry.Requests++
+	entry.Usage.PromptTokens += usage.PromptTokens
+	entry.Usage.CompletionTokens += usage.CompletionTokens
+	entry.Usage.TotalTokens += usage.TotalTokens
+	entry.BytesSent += bytesSent
+	entry.BytesReceived += bytesReceived
+	entry.Cost += r.cost(key.model, usage)
+	r.mu.Unlock()
+
+	if ok {
+		r.emit(snapshot)
+	}
+}
+
+// Flush emits the current bucket, if it has usage, and starts a new one.
+func (r *UsageReporter) Flush() {
+	r.mu.Lock()
+	snapshot, ok := r.take(r.now())
+	r.mu.Unlock()
+	if ok {
+		r.emit(snapshot)
+	}
+}
+
+// Run emits every bucket at its end, until ctx is done.
+func (r *UsageReporter) Run(ctx context.Context) {
+	for {
+		now := r.now()
+		timer := time.NewTimer(now.Truncate(r.config.Interval).Add(r.config.Interval).Sub(now))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		r.mu.Lock()
+		snapshot, ok := r.rollOver(r.now())
+		r.mu.Unlock()
+		if ok {
+			r.emit(snapshot)
+		}
+	}
+}
+
+// rollOver takes the current bucket if now is past its end. r.mu must be held.
+func (r *UsageReporter) rollOver(now time.Time) (UsageSnapshot, bool) {
+	bucket := now.Truncate(r.config.Interval)
+	if r.start.IsZero() {
+		r.start = bucket
+	}
+	if !bucket.After(r.start) {
+		return UsageSnapshot{}, false
+	}
+	// After a Flush the bucket started mid-interval, so end it at the interval boundary.
+	snapshot, ok := r.take(r.start.Truncate(r.config.Interval).Add(r.config.Interval))
+	r.start = bucket
+	return snapshot, ok
+}
+
+// take returns the usage recorded since r.start as a snapshot ending at end. r.mu must be held.
+func (r *UsageReporter) take(end time.Time) (snapshot UsageSnapshot, ok bool) {
+	snapshot = UsageSnapshot{Start: r.start, End: end}
+	for _, entry := range r.entries {
+		snapshot.Entries = append(snapshot.Entries, *entry)
+	}
+	sort.Slice(snapshot.Entries, func(i, j int) bool {
+		a, b := snapshot.Entries[i], snapshot.Entries[j]
+		if a.Group != b.Group {
+			return a.Group < b.Group
+		}
+		if a.Tag != b.Tag {
+			return a.Tag < b.Tag
+		}
+		return a.Model < b.Model
+	})
+	r.entries = make(map[usageKey]*UsageEntry)
+	r.start = end
+	return snapshot, len(snapshot.Entries) > 0
+}
+
+func (r *UsageReporter) emit(snapshot UsageSnapshot) {
+	if r.config.OnSnapshot != nil {
+		r.config.OnSnapshot(snapshot)
+	}
+}
+
+// cost prices usage at the price of model.
+func (r *UsageReporter) cost(model string, usage Usage) float64 {
+	price := r.config.Prices[model]
+	return (float64(usage.PromptTokens)*price.Prompt + float64(usage.CompletionTokens)*price.Completion) / 1e6
+}
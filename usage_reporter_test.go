@@ -0,0 +1,71 @@
+package openai //nolint:testpackage // testing private field
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestUsageReporter(t *testing.T) {
+	var snapshots []UsageSnapshot
+	reporter := NewUsageReporter(UsageReporterConfig{
+		Interval: time.Minute,
+		Prices: map[string]ModelPrice{
+			"gpt-4-0613": {Prompt: 30, Completion: 60},
+			// Snapshots are not priced like their family.
+			"gpt-4": {Prompt: 60, Completion: 120},
+		},
+		GroupBy:    "tenant",
+		OnSnapshot: func(snapshot UsageSnapshot) { snapshots = append(snapshots, snapshot) },
+	})
+	now := time.Date(2024, 1, 1, 12, 0, 10, 0, time.UTC)
+	reporter.now = func() time.Time { return now }
+
+	usage := Usage{PromptTokens: 1000, CompletionTokens: 500, TotalTokens: 1500}
+	acme := CallInfo{Model: "gpt-4-0613", Metadata: map[string]string{"tenant": "acme"}}
+	reporter.OnComplete(acme, nil, usage, time.Second)
+	reporter.OnComplete(acme, nil, usage, time.Second)
+	reporter.OnComplete(CallInfo{Model: "local-model"}, nil, usage, time.Second)
+	reporter.OnComplete(acme, errTestRequestBuilderFailed, usage, time.Second)
+	if len(snapshots) != 0 {
+		t.Fatalf("No bucket is over yet: %+v", snapshots)
+	}
+
+	now = now.Add(time.Minute)
+	reporter.Record("acme", "gpt-4", usage)
+	if len(snapshots) != 1 {
+		t.Fatalf("The first bucket should be emitted, got %d snapshots", len(snapshots))
+	}
+	snapshot := snapshots[0]
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	if !snapshot.Start.Equal(start) || !snapshot.End.Equal(start.Add(time.Minute)) {
+		t.Fatalf("Unexpected bucket: %s - %s", snapshot.Start, snapshot.End)
+	}
+	if len(snapshot.Entries) != 2 {
+		t.Fatalf("Unexpected entries: %+v", snapshot.Entries)
+	}
+	unpriced, acmeEntry := snapshot.Entries[0], snapshot.Entries[1]
+	if unpriced.Group != "" || unpriced.Cost != 0 || unpriced.Requests != 1 {
+		t.Fatalf("Unexpected entry: %+v", unpriced)
+	}
+	// 2 * (1000 * $30 + 500 * $60) / 1M tokens.
+	if acmeEntry.Requests != 2 || acmeEntry.Usage.TotalTokens != 3000 || math.Abs(acmeEntry.Cost-0.12) > 1e-9 {
+		t.Fatalf("Unexpected entry: %+v", acmeEntry)
+	}
+
+	reporter.Flush()
+	if len(snapshots) != 2 || snapshots[1].Entries[0].Model != "gpt-4" {
+		t.Fatalf("Flush should emit the current bucket: %+v", snapshots)
+	}
+	reporter.Flush()
+	if len(snapshots) != 2 {
+		t.Fatal("Empty buckets should not be emitted")
+	}
+}
+
+func TestUsageReporterDefaultInterval(t *testing.T) {
+	reporter := NewUsageReporter(UsageReporterConfig{})
+	if reporter.config.Interval != time.Minute {
+		t.Fatalf("Expected a non-positive interval to default to a minute, got %s", reporter.config.Interval)
+	}
+}
@@ -0,0 +1,148 @@
+package openai
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/gif" // Registers the GIF decoder; GIFs are re-encoded as PNG.
+	"image/jpeg"
+	"image/png"
+	"net/http"
+	"os"
+)
+
+// ImageURLDetail is the detail level an image of a chat message is processed at.
+type ImageURLDetail string
+
+const (
+	ImageURLDetailHigh ImageURLDetail = "high"
+	ImageURLDetailLow  ImageURLDetail = "low"
+	ImageURLDetailAuto ImageURLDetail = "auto"
+)
+
+// Image dimensions the API scales vision inputs to, see
+// https://platform.openai.com/docs/guides/vision#calculating-costs.
+const (
+	visionMaxSide       = 2048
+	visionMaxShortSide  = 768
+	visionLowDetailSide = 512
+	visionJPEGQuality   = 85
+)
+
+var ErrImageFormatNotSupported = errors.New("image format is not supported, use PNG, JPEG or GIF")
+
+// DownscaleImage shrinks a PNG, JPEG or GIF image to the largest dimensions the API processes
+// it at for detail, so high-resolution inputs are not uploaded only to be scaled down by the
+// API. JPEG images are re-encoded as JPEG, others as PNG; images that already fit are returned
+// unchanged. mimeType is the type of scaled, e.g. for ImageDataURL.
+func DownscaleImage(data []byte, detail ImageURLDetail) (scaled []byte, mimeType string, err error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		err = fmt.Errorf("%w: %s", ErrImageFormatNotSupported, err.Error())
+		return
+	}
+	width, height := visionDimensions(config.Width, config.Height, detail)
+	if width == config.Width && height == config.Height {
+		return data, http.DetectContentType(data), nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+	var buf bytes.Buffer
+	resized := resizeImage(img, width, height)
+	if format == "jpeg" {
+		err = jpeg.Encode(&buf, resized, &jpeg.Options{Quality: visionJPEGQuality})
+		mimeType = "image/jpeg"
+	} else {
+		err = png.Encode(&buf, resized)
+		mimeType = "image/png"
+	}
+	scaled = buf.Bytes()
+	return
+}
+
+// ImageDataURL returns data as a data URL, which the API accepts in place of an image URL.
+func ImageDataURL(data []byte, mimeType string) string {
+	return "data:" + mimeType + ";base64," + base64.StdEncoding.EncodeToString(data)
+}
+
+// ImageURLPart returns a part of a message with the image at url, processed at detail.
+func ImageURLPart(url string, detail ImageURLDetail) ChatMessagePart {
+	return ChatMessagePart{
+		Type:     ChatMessagePartTypeImageURL,
+		ImageURL: &ChatMessageImageURL{URL: url, Detail: detail},
+	}
+}
+
+// ImageFilePart returns a part of a message with the local PNG, JPEG or GIF image at path,
+// downscaled for detail with DownscaleImage and sent as a data URL.
+func ImageFilePart(path string, detail ImageURLDetail) (part ChatMessagePart, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	scaled, mimeType, err := DownscaleImage(data, detail)
+	if err != nil {
+		return
+	}
+	return ImageURLPart(ImageDataURL(scaled, mimeType), detail), nil
+}
+
+// visionDimensions returns the dimensions a width x height image is processed at for detail.
+func visionDimensions(width, height int, detail ImageURLDetail) (int, int) {
+	fit := func(limit, side int) {
+		if side > limit {
+			width, height = maxInt(width*limit/side, 1), maxInt(height*limit/side, 1)
+		}
+	}
+	if detail == ImageURLDetailLow {
+		fit(visionLowDetailSide, maxInt(width, height))
+		return width, height
+	}
+	fit(visionMaxSide, maxInt(width, height))
+	fit(visionMaxShortSide, minInt(width, height))
+	return width, height
+}
+
+// resizeImage scales src down to width x height, averaging the source pixels of every pixel.
+func resizeImage(src image.Image, width, height int) *image.NRGBA {
+	bounds := src.Bounds()
+	dst := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*bounds.Dy()/height
+		y1 := maxInt(bounds.Min.Y+(y+1)*bounds.Dy()/height, y0+1)
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*bounds.Dx()/width
+			x1 := maxInt(bounds.Min.X+(x+1)*bounds.Dx()/width, x0+1)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					pr, pg, pb, pa := src.At(sx, sy).RGBA()
+					r, g, b, a, n = r+uint64(pr), g+uint64(pg), b+uint64(pb), a+uint64(pa), n+1
+				}
+			}
+			dst.Set(x, y, color.RGBA64{R: uint16(r / n), G: uint16(g / n), B: uint16(b / n), A: uint16(a / n)})
+		}
+	}
+	return dst
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
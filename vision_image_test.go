@@ -0,0 +1,102 @@
+package openai_test
+
+import (
+	. "github.com/sashabaranov/go-openai"
+	"github.com/sashabaranov/go-openai/internal/test/checks"
+
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDownscaleImage(t *testing.T) {
+	src := image.NewRGBA(image.Rect(0, 0, 4000, 1000))
+	for x := 0; x < 4000; x++ {
+		src.Set(x, 0, color.RGBA{R: 255, A: 255})
+	}
+	var encoded bytes.Buffer
+	checks.NoError(t, png.Encode(&encoded, src), "png.Encode error")
+
+	testcases := []struct {
+		detail        ImageURLDetail
+		width, height int
+	}{
+		{ImageURLDetailHigh, 2048, 512},
+		{ImageURLDetailLow, 512, 128},
+	}
+	for _, tc := range testcases {
+		scaled, mimeType, err := DownscaleImage(encoded.Bytes(), tc.detail)
+		checks.NoError(t, err, "DownscaleImage error")
+		config, format, err := image.DecodeConfig(bytes.NewReader(scaled))
+		checks.NoError(t, err, "DecodeConfig error")
+		if mimeType != "image/png" || format != "png" || config.Width != tc.width || config.Height != tc.height {
+			t.Errorf("%s: unexpected %s %dx%d image", tc.detail, mimeType, config.Width, config.Height)
+		}
+	}
+}
+
+func TestDownscaleImageKeepsSmallImages(t *testing.T) {
+	var encoded bytes.Buffer
+	checks.NoError(t, jpeg.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, 300, 200)), nil), "jpeg.Encode error")
+
+	scaled, mimeType, err := DownscaleImage(encoded.Bytes(), ImageURLDetailAuto)
+	checks.NoError(t, err, "DownscaleImage error")
+	if !bytes.Equal(scaled, encoded.Bytes()) || mimeType != "image/jpeg" {
+		t.Fatalf("Expected the image to be unchanged, got %d bytes of %s", len(scaled), mimeType)
+	}
+	if url := ImageDataURL(scaled, mimeType); !strings.HasPrefix(url, "data:image/jpeg;base64,/9j/") {
+		t.Fatalf("Unexpected data URL %.30s", url)
+	}
+
+	_, _, err = DownscaleImage([]byte("RIFF....WEBP"), ImageURLDetailAuto)
+	checks.ErrorIs(t, err, ErrImageFormatNotSupported, "WebP should not be supported")
+}
+
+func TestImageParts(t *testing.T) {
+	data, err := json.Marshal(ChatCompletionMessage{
+		Role: ChatMessageRoleUser,
+		MultiContent: []ChatMessagePart{
+			{Type: ChatMessagePartTypeText, Text: "What is this?"},
+			ImageURLPart("https://example.com/cat.png", ImageURLDetailLow),
+		},
+	})
+	checks.NoError(t, err, "Marshal error")
+	want := `{"role":"user","content":[{"type":"text","text":"What is this?"},` +
+		`{"type":"image_url","image_url":{"url":"https://example.com/cat.png","detail":"low"}}]}`
+	if string(data) != want {
+		t.Fatalf("Unexpected message:\n%s\nwant:\n%s", data, want)
+	}
+
+	var encoded bytes.Buffer
+	checks.NoError(t, png.Encode(&encoded, image.NewRGBA(image.Rect(0, 0, 1024, 1024))), "png.Encode error")
+	path := filepath.Join(t.TempDir(), "large.png")
+	checks.NoError(t, os.WriteFile(path, encoded.Bytes(), 0o600), "WriteFile error")
+
+	part, err := ImageFilePart(path, ImageURLDetailLow)
+	checks.NoError(t, err, "ImageFilePart error")
+	if part.Type != ChatMessagePartTypeImageURL || part.ImageURL.Detail != ImageURLDetailLow {
+		t.Fatalf("Unexpected part: %+v", part)
+	}
+	url := part.ImageURL.URL
+	if !strings.HasPrefix(url, "data:image/png;base64,") {
+		t.Fatalf("Unexpected data URL %.30s", url)
+	}
+	scaled, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(url, "data:image/png;base64,"))
+	checks.NoError(t, err, "DecodeString error")
+	config, _, err := image.DecodeConfig(bytes.NewReader(scaled))
+	checks.NoError(t, err, "DecodeConfig error")
+	if config.Width != 512 || config.Height != 512 {
+		t.Fatalf("Expected the image to be downscaled to 512x512, got %dx%d", config.Width, config.Height)
+	}
+
+	_, err = ImageFilePart(filepath.Join(t.TempDir(), "missing.png"), ImageURLDetailAuto)
+	checks.ErrorIs(t, err, os.ErrNotExist, "ImageFilePart should fail for missing files")
+}